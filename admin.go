@@ -0,0 +1,449 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package astradb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/datastax/astra-db-go/options"
+)
+
+// defaultAdminPollInterval is the time between status checks while
+// blocking on an admin operation.
+const defaultAdminPollInterval = 10 * time.Second
+
+// AstraAdmin manages Astra databases through the DevOps API.
+// Obtain one with [DataAPIClient.Admin].
+//
+// The client's token must have permission to manage databases.
+type AstraAdmin struct {
+	client    *DataAPIClient
+	dbOptions *options.APIOptions // Set for admins of a database's keyspaces
+	options   *options.APIOptions
+}
+
+// Admin returns an AstraAdmin for managing databases.
+//
+// Options set here override those set on the client.
+//
+// Example:
+//
+//	admin := client.Admin(options.WithAstraEnvironment(options.EnvironmentDev))
+//	dbs, err := admin.ListDatabases(ctx)
+func (c *DataAPIClient) Admin(opts ...options.APIOption) *AstraAdmin {
+	return &AstraAdmin{
+		client:  c,
+		options: options.NewAPIOptions(opts...),
+	}
+}
+
+// resolveOptions merges client, database and admin options.
+func (a *AstraAdmin) resolveOptions() *options.APIOptions {
+	var clientOpts *options.APIOptions
+	if a.client != nil {
+		clientOpts = a.client.options
+	}
+	return options.Merge(clientOpts, a.dbOptions, a.options)
+}
+
+// DatabaseStatus is the lifecycle status of an Astra database.
+type DatabaseStatus string
+
+// Database statuses reported by the DevOps API.
+const (
+	DatabaseStatusActive       DatabaseStatus = "ACTIVE"
+	DatabaseStatusPending      DatabaseStatus = "PENDING"
+	DatabaseStatusPreparing    DatabaseStatus = "PREPARING"
+	DatabaseStatusPrepared     DatabaseStatus = "PREPARED"
+	DatabaseStatusInitializing DatabaseStatus = "INITIALIZING"
+	DatabaseStatusParking      DatabaseStatus = "PARKING"
+	DatabaseStatusParked       DatabaseStatus = "PARKED"
+	DatabaseStatusUnparking    DatabaseStatus = "UNPARKING"
+	DatabaseStatusTerminating  DatabaseStatus = "TERMINATING"
+	DatabaseStatusTerminated   DatabaseStatus = "TERMINATED"
+	DatabaseStatusResizing     DatabaseStatus = "RESIZING"
+	DatabaseStatusMaintenance  DatabaseStatus = "MAINTENANCE"
+	DatabaseStatusHibernated   DatabaseStatus = "HIBERNATED"
+	DatabaseStatusResuming     DatabaseStatus = "RESUMING"
+	DatabaseStatusError        DatabaseStatus = "ERROR"
+)
+
+// DatabaseInfo describes an Astra database as returned by the DevOps API.
+type DatabaseInfo struct {
+	ID               string          `json:"id"`
+	OrgID            string          `json:"orgId"`
+	OwnerID          string          `json:"ownerId"`
+	Status           DatabaseStatus  `json:"status"`
+	Info             DatabaseDetails `json:"info"`
+	CreationTime     string          `json:"creationTime,omitempty"`
+	TerminationTime  string          `json:"terminationTime,omitempty"`
+	AvailableActions []string        `json:"availableActions,omitempty"`
+}
+
+// DatabaseDetails contains the configuration of an Astra database.
+type DatabaseDetails struct {
+	Name                string   `json:"name"`
+	Keyspace            string   `json:"keyspace"`
+	Keyspaces           []string `json:"keyspaces,omitempty"`
+	AdditionalKeyspaces []string `json:"additionalKeyspaces,omitempty"`
+	CloudProvider       string   `json:"cloudProvider"`
+	Region              string   `json:"region"`
+	Tier                string   `json:"tier"`
+	CapacityUnits       int      `json:"capacityUnits"`
+	DBType              string   `json:"dbType,omitempty"`
+}
+
+// astraDomains maps each Astra environment to the domain of its Data API endpoints.
+var astraDomains = map[options.DBEnvironment]string{
+	options.EnvironmentProduction: "apps.astra.datastax.com",
+	options.EnvironmentDev:        "apps.astra-dev.datastax.com",
+	options.EnvironmentTest:       "apps.astra-test.datastax.com",
+}
+
+// APIEndpoint returns the Data API endpoint of the database in env.
+func (d *DatabaseInfo) APIEndpoint(env options.DBEnvironment) string {
+	return fmt.Sprintf("https://%s-%s.%s", d.ID, d.Info.Region, astraDomains[env])
+}
+
+// parseAstraEndpoint extracts the database ID and environment from an Astra
+// Data API endpoint such as https://<id>-<region>.apps.astra.datastax.com.
+// ok is false for endpoints that are not Astra endpoints.
+func parseAstraEndpoint(endpoint string) (id string, env options.DBEnvironment, ok bool) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", 0, false
+	}
+	host := u.Hostname()
+	for e, domain := range astraDomains {
+		if !strings.HasSuffix(host, "."+domain) {
+			continue
+		}
+		// Database IDs are UUIDs: 36 characters followed by "-<region>"
+		sub := strings.TrimSuffix(host, "."+domain)
+		if len(sub) < 38 || sub[36] != '-' {
+			return "", 0, false
+		}
+		return sub[:36], e, true
+	}
+	return "", 0, false
+}
+
+// devOpsError is an error as returned by the DevOps API.
+type devOpsError struct {
+	Message string `json:"message"`
+	Errors  []struct {
+		Description string `json:"description"`
+		ID          int    `json:"ID"`
+	} `json:"errors"`
+}
+
+// do sends a DevOps API request and decodes the JSON response into out, if non-nil.
+func (a *AstraAdmin) do(ctx context.Context, method, reqPath string, query url.Values, payload any, out any) (http.Header, error) {
+	opts := a.resolveOptions()
+	if err := requireFeature(opts, options.FeatureDevOpsAPI); err != nil {
+		return nil, err
+	}
+	reqURL, err := url.JoinPath(opts.GetAdminEndpoint(), reqPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(query) > 0 {
+		reqURL += "?" + query.Encode()
+	}
+
+	var b []byte
+	if payload != nil {
+		b, err = json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		resp     *http.Response
+		respBody []byte
+	)
+	for attempt := 0; ; attempt++ {
+		token, err := opts.ResolveToken(ctx)
+		if err != nil {
+			return nil, err
+		}
+		resp, respBody, err = a.send(ctx, opts, method, reqURL, b, token)
+		if err != nil {
+			return nil, err
+		}
+		// Retry once with a fresh token, as for Data API commands
+		if resp.StatusCode != http.StatusUnauthorized || attempt > 0 || !opts.InvalidateToken(token) {
+			break
+		}
+	}
+
+	if resp.StatusCode >= 400 {
+		var apiErr devOpsError
+		json.Unmarshal(respBody, &apiErr)
+		msg := apiErr.Message
+		if msg == "" && len(apiErr.Errors) > 0 {
+			msg = apiErr.Errors[0].Description
+		}
+		return resp.Header, &HTTPError{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header,
+			Body:       respBody,
+			Message:    msg,
+		}
+	}
+	if out != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return resp.Header, err
+		}
+	}
+	return resp.Header, nil
+}
+
+// send sends a DevOps API request with token and returns the response
+// along with its body, which has already been read and closed.
+func (a *AstraAdmin) send(ctx context.Context, opts *options.APIOptions, method, reqURL string, b []byte, token string) (*http.Response, []byte, error) {
+	var body io.Reader
+	if b != nil {
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+opts.GetEnvironment().FormatToken(token))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range opts.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := a.client.httpClientFor(opts).Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	return resp, respBody, err
+}
+
+// ListDatabases lists the databases in the organization.
+//
+// By default only non-terminated databases are returned.
+//
+// Example:
+//
+//	dbs, err := admin.ListDatabases(ctx, options.ListDatabases().SetInclude("active"))
+func (a *AstraAdmin) ListDatabases(ctx context.Context, opts ...options.Builder[options.ListDatabasesOptions]) ([]DatabaseInfo, error) {
+	merged, err := options.MergeOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	query := url.Values{}
+	if merged.Include != nil {
+		query.Set("include", *merged.Include)
+	}
+	if merged.Provider != nil {
+		query.Set("provider", *merged.Provider)
+	}
+	if merged.Limit != nil {
+		query.Set("limit", strconv.Itoa(*merged.Limit))
+	}
+	var dbs []DatabaseInfo
+	_, err = a.do(ctx, http.MethodGet, "/databases", query, nil, &dbs)
+	return dbs, err
+}
+
+// DescribeDatabase returns information about the database with the given ID.
+func (a *AstraAdmin) DescribeDatabase(ctx context.Context, id string) (*DatabaseInfo, error) {
+	if id == "" {
+		return nil, errors.New("database id cannot be empty")
+	}
+	var info DatabaseInfo
+	_, err := a.do(ctx, http.MethodGet, path.Join("/databases", id), nil, nil, &info)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// CreateDatabase creates a new database.
+//
+// By default this blocks until the database is ACTIVE, polling within the
+// [options.AdminTimeouts] Database budget. Use
+// options.Admin().SetBlocking(false) to return as soon as the request is accepted.
+//
+// Example:
+//
+//	info, err := admin.CreateDatabase(ctx, options.CreateDatabaseOptions{
+//		Name:          "my_db",
+//		CloudProvider: "GCP",
+//		Region:        "us-east1",
+//	})
+//	db := client.Database(info.APIEndpoint(options.EnvironmentProduction))
+func (a *AstraAdmin) CreateDatabase(ctx context.Context, def options.CreateDatabaseOptions, opts ...options.Builder[options.AdminOptions]) (*DatabaseInfo, error) {
+	if err := def.Validate(); err != nil {
+		return nil, err
+	}
+	adminOpts, err := options.MergeOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if def.Keyspace == "" {
+		def.Keyspace = "default_keyspace"
+	}
+	if def.Tier == "" {
+		def.Tier = "serverless"
+	}
+	if def.CapacityUnits == 0 {
+		def.CapacityUnits = 1
+	}
+	if def.DBType == "" {
+		def.DBType = "vector"
+	}
+
+	header, err := a.do(ctx, http.MethodPost, "/databases", nil, def, nil)
+	if err != nil {
+		return nil, err
+	}
+	// The new database ID is the last element of the Location header
+	id := path.Base(header.Get("Location"))
+	if id == "" || id == "." || id == "/" {
+		return nil, errors.New("createDatabase: missing database id in response")
+	}
+
+	if !adminOpts.IsBlocking() {
+		return a.DescribeDatabase(ctx, id)
+	}
+	return a.waitForStatus(ctx, id, DatabaseStatusActive, adminOpts)
+}
+
+// TerminateDatabase terminates (deletes) the database with the given ID.
+// This cannot be undone.
+//
+// By default this blocks until the database is TERMINATED.
+func (a *AstraAdmin) TerminateDatabase(ctx context.Context, id string, opts ...options.Builder[options.AdminOptions]) error {
+	return a.databaseAction(ctx, id, "terminate", nil, DatabaseStatusTerminated, opts...)
+}
+
+// ParkDatabase parks the database with the given ID. Only classic
+// databases can be parked.
+//
+// By default this blocks until the database is PARKED.
+func (a *AstraAdmin) ParkDatabase(ctx context.Context, id string, opts ...options.Builder[options.AdminOptions]) error {
+	return a.databaseAction(ctx, id, "park", nil, DatabaseStatusParked, opts...)
+}
+
+// UnparkDatabase unparks the database with the given ID.
+//
+// By default this blocks until the database is ACTIVE.
+func (a *AstraAdmin) UnparkDatabase(ctx context.Context, id string, opts ...options.Builder[options.AdminOptions]) error {
+	return a.databaseAction(ctx, id, "unpark", nil, DatabaseStatusActive, opts...)
+}
+
+// resizePayload is the payload for the resize action
+type resizePayload struct {
+	CapacityUnits int `json:"capacityUnits"`
+}
+
+// ResizeDatabase changes the number of capacity units of the database
+// with the given ID. Only classic databases can be resized.
+//
+// By default this blocks until the database is ACTIVE.
+func (a *AstraAdmin) ResizeDatabase(ctx context.Context, id string, capacityUnits int, opts ...options.Builder[options.AdminOptions]) error {
+	if capacityUnits <= 0 {
+		return errors.New("capacity units must be positive")
+	}
+	return a.databaseAction(ctx, id, "resize", resizePayload{CapacityUnits: capacityUnits}, DatabaseStatusActive, opts...)
+}
+
+// databaseAction posts an action for a database and optionally waits for target.
+func (a *AstraAdmin) databaseAction(ctx context.Context, id, action string, payload any, target DatabaseStatus, opts ...options.Builder[options.AdminOptions]) error {
+	if id == "" {
+		return errors.New("database id cannot be empty")
+	}
+	adminOpts, err := options.MergeOptions(opts...)
+	if err != nil {
+		return err
+	}
+	_, err = a.do(ctx, http.MethodPost, path.Join("/databases", id, action), nil, payload, nil)
+	if err != nil {
+		return err
+	}
+	if !adminOpts.IsBlocking() {
+		return nil
+	}
+	_, err = a.waitForStatus(ctx, id, target, adminOpts)
+	return err
+}
+
+// waitForStatus polls the database until it reaches target, enters the
+// ERROR state, or the database admin timeout elapses. A terminated database
+// may disappear entirely, so a 404 counts as reaching TERMINATED.
+func (a *AstraAdmin) waitForStatus(ctx context.Context, id string, target DatabaseStatus, adminOpts *options.AdminOptions) (*DatabaseInfo, error) {
+	timeout := adminOpts.GetTimeout(a.resolveOptions().GetDatabaseAdminTimeout())
+	return a.waitUntil(ctx, id, "become "+string(target), timeout, adminOpts, func(info *DatabaseInfo) bool {
+		if info == nil {
+			return target == DatabaseStatusTerminated
+		}
+		return info.Status == target
+	})
+}
+
+// waitUntil polls the database until done reports true, the database enters
+// the ERROR state, or timeout elapses. done is called with a nil info if the
+// database no longer exists. what describes the awaited condition in errors.
+func (a *AstraAdmin) waitUntil(ctx context.Context, id, what string, timeout time.Duration, adminOpts *options.AdminOptions, done func(*DatabaseInfo) bool) (*DatabaseInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	interval := adminOpts.GetPollInterval(defaultAdminPollInterval)
+
+	for {
+		info, err := a.DescribeDatabase(ctx, id)
+		var httpErr *HTTPError
+		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound && done(nil) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if done(info) {
+			return info, nil
+		}
+		if info.Status == DatabaseStatusError {
+			return info, fmt.Errorf("database %s entered status %s while waiting to %s", id, info.Status, what)
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return info, fmt.Errorf("waiting for database %s to %s (last status %s): %w", id, what, info.Status, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
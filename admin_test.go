@@ -0,0 +1,200 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package astradb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/datastax/astra-db-go/options"
+)
+
+// fakeDevOps is a minimal DevOps API serving a single database whose
+// status advances through statuses on each describe.
+type fakeDevOps struct {
+	mu       sync.Mutex
+	statuses []DatabaseStatus
+	created  map[string]any
+	actions  []string
+}
+
+func (f *fakeDevOps) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if r.Header.Get("Authorization") != "Bearer test-token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"errors":[{"description":"invalid token","ID":401}]}`))
+		return
+	}
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/databases":
+		if r.URL.Query().Get("include") != "active" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode([]DatabaseInfo{{ID: "db-1", Status: DatabaseStatusActive}})
+	case r.Method == http.MethodPost && r.URL.Path == "/databases":
+		json.NewDecoder(r.Body).Decode(&f.created)
+		w.Header().Set("Location", "/v2/databases/db-1")
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodGet && r.URL.Path == "/databases/db-1":
+		if len(f.statuses) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[{"description":"database not found","ID":404}]}`))
+			return
+		}
+		status := f.statuses[0]
+		if len(f.statuses) > 1 {
+			f.statuses = f.statuses[1:]
+		}
+		json.NewEncoder(w).Encode(DatabaseInfo{
+			ID:     "db-1",
+			Status: status,
+			Info:   DatabaseDetails{Name: "my_db", Region: "us-east1"},
+		})
+	case r.Method == http.MethodPost:
+		f.actions = append(f.actions, r.URL.Path)
+		if r.URL.Path == "/databases/db-1/terminate" {
+			f.statuses = nil
+		}
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestAdmin(t *testing.T, f *fakeDevOps) *AstraAdmin {
+	t.Helper()
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	client := NewClient(options.WithToken("test-token"))
+	return client.Admin(options.WithAdminEndpoint(srv.URL))
+}
+
+func TestAdminCreateDatabaseBlocks(t *testing.T) {
+	f := &fakeDevOps{statuses: []DatabaseStatus{
+		DatabaseStatusPending, DatabaseStatusInitializing, DatabaseStatusActive,
+	}}
+	admin := newTestAdmin(t, f)
+
+	info, err := admin.CreateDatabase(context.Background(), options.CreateDatabaseOptions{
+		Name:          "my_db",
+		CloudProvider: "GCP",
+		Region:        "us-east1",
+	}, options.Admin().SetPollInterval(time.Millisecond))
+	if err != nil {
+		t.Fatalf("CreateDatabase failed: %v", err)
+	}
+	if info.ID != "db-1" || info.Status != DatabaseStatusActive {
+		t.Errorf("unexpected database info: %+v", info)
+	}
+	if f.created["keyspace"] != "default_keyspace" || f.created["tier"] != "serverless" {
+		t.Errorf("expected defaults in payload, got %v", f.created)
+	}
+	if got := info.APIEndpoint(options.EnvironmentProduction); got != "https://db-1-us-east1.apps.astra.datastax.com" {
+		t.Errorf("unexpected API endpoint %q", got)
+	}
+}
+
+func TestAdminCreateDatabaseNonBlocking(t *testing.T) {
+	f := &fakeDevOps{statuses: []DatabaseStatus{DatabaseStatusPending, DatabaseStatusActive}}
+	admin := newTestAdmin(t, f)
+
+	info, err := admin.CreateDatabase(context.Background(), options.CreateDatabaseOptions{
+		Name:          "my_db",
+		CloudProvider: "GCP",
+		Region:        "us-east1",
+	}, options.Admin().SetBlocking(false))
+	if err != nil {
+		t.Fatalf("CreateDatabase failed: %v", err)
+	}
+	if info.Status != DatabaseStatusPending {
+		t.Errorf("expected PENDING, got %s", info.Status)
+	}
+}
+
+func TestAdminCreateDatabaseValidation(t *testing.T) {
+	admin := newTestAdmin(t, &fakeDevOps{})
+	_, err := admin.CreateDatabase(context.Background(), options.CreateDatabaseOptions{Name: "my_db"})
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+}
+
+func TestAdminWaitErrorStatus(t *testing.T) {
+	f := &fakeDevOps{statuses: []DatabaseStatus{DatabaseStatusParking, DatabaseStatusError}}
+	admin := newTestAdmin(t, f)
+
+	err := admin.ParkDatabase(context.Background(), "db-1", options.Admin().SetPollInterval(time.Millisecond))
+	if err == nil {
+		t.Fatal("expected error for ERROR status")
+	}
+	if len(f.actions) != 1 || f.actions[0] != "/databases/db-1/park" {
+		t.Errorf("unexpected actions %v", f.actions)
+	}
+}
+
+func TestAdminWaitTimeout(t *testing.T) {
+	f := &fakeDevOps{statuses: []DatabaseStatus{DatabaseStatusResizing}}
+	admin := newTestAdmin(t, f)
+
+	err := admin.ResizeDatabase(context.Background(), "db-1", 2,
+		options.Admin().SetPollInterval(time.Millisecond).SetTimeout(20*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestAdminTerminateDatabase(t *testing.T) {
+	f := &fakeDevOps{statuses: []DatabaseStatus{DatabaseStatusActive}}
+	admin := newTestAdmin(t, f)
+
+	if err := admin.TerminateDatabase(context.Background(), "db-1", options.Admin().SetPollInterval(time.Millisecond)); err != nil {
+		t.Fatalf("TerminateDatabase failed: %v", err)
+	}
+}
+
+func TestAdminListDatabasesAndErrors(t *testing.T) {
+	admin := newTestAdmin(t, &fakeDevOps{})
+
+	dbs, err := admin.ListDatabases(context.Background(), options.ListDatabases().SetInclude("active"))
+	if err != nil {
+		t.Fatalf("ListDatabases failed: %v", err)
+	}
+	if len(dbs) != 1 || dbs[0].ID != "db-1" {
+		t.Errorf("unexpected databases %+v", dbs)
+	}
+
+	_, err = admin.DescribeDatabase(context.Background(), "db-1")
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected 404 HTTPError, got %v", err)
+	}
+	if httpErr.Message != "database not found" {
+		t.Errorf("unexpected message %q", httpErr.Message)
+	}
+
+	bad := NewClient(options.WithToken("wrong")).Admin(options.WithAdminEndpoint(admin.resolveOptions().GetAdminEndpoint()))
+	_, err = bad.ListDatabases(context.Background())
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401 HTTPError, got %v", err)
+	}
+}
@@ -0,0 +1,521 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package astradbtest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// writeQuery is a decoded update, replace or delete command.
+type writeQuery struct {
+	Filter      map[string]any  `json:"filter"`
+	Update      map[string]any  `json:"update"`
+	Replacement map[string]any  `json:"replacement"`
+	Sort        json.RawMessage `json:"sort"`
+	Projection  map[string]any  `json:"projection"`
+	Options     struct {
+		Upsert         bool   `json:"upsert"`
+		ReturnDocument string `json:"returnDocument"`
+	} `json:"options"`
+}
+
+// executeCollection runs the document commands of a collection.
+func (s *Server) executeCollection(c *collectionData, req request) (response, error) {
+	metric := func(string) string { return c.metric() }
+	switch req.name {
+	case "insertOne", "insertMany":
+		return s.insertDocuments(c, req)
+	case "find", "findOne":
+		return s.find(c.docs, metric, true, req)
+	case "countDocuments":
+		var q findQuery
+		if err := req.decode(&q); err != nil {
+			return response{}, err
+		}
+		found, _, err := search(c.docs, findQuery{Filter: q.Filter}, metric)
+		if err != nil {
+			return response{}, err
+		}
+		if len(found) > maxCount {
+			return response{Status: map[string]any{"count": maxCount, "moreData": true}}, nil
+		}
+		return response{Status: map[string]any{"count": len(found)}}, nil
+	case "estimatedDocumentCount":
+		return response{Status: map[string]any{"count": len(c.docs)}}, nil
+	case "updateOne", "updateMany", "replaceOne", "findOneAndUpdate", "findOneAndReplace":
+		return s.updateDocuments(c, req)
+	case "deleteOne", "deleteMany", "findOneAndDelete":
+		return s.deleteDocuments(c, req)
+	}
+	return response{}, unknownCommand(req)
+}
+
+// insertDocuments runs insertOne and insertMany on a collection. Like the
+// Data API, an unordered insertMany inserts every document it can and
+// reports the failures, while an ordered one stops at the first failure.
+func (s *Server) insertDocuments(c *collectionData, req request) (response, error) {
+	var p struct {
+		Document  map[string]any   `json:"document"`
+		Documents []map[string]any `json:"documents"`
+		Options   struct {
+			Ordered bool `json:"ordered"`
+		} `json:"options"`
+	}
+	if err := req.decode(&p); err != nil {
+		return response{}, err
+	}
+	docs := p.Documents
+	if req.name == "insertOne" {
+		docs = []map[string]any{p.Document}
+	}
+
+	var resp response
+	ids := []any{}
+	for _, doc := range docs {
+		doc = deepCopy(doc)
+		if doc == nil {
+			doc = map[string]any{}
+		}
+		if err := c.validate(doc); err != nil {
+			resp.Errors = append(resp.Errors, err.(*apiError))
+			if req.name == "insertOne" || p.Options.Ordered {
+				break
+			}
+			continue
+		}
+		ids = append(ids, doc["_id"])
+		c.docs = append(c.docs, doc)
+	}
+	resp.Status = map[string]any{"insertedIds": ids}
+	return resp, nil
+}
+
+// validate checks a document before it is inserted into the collection,
+// assigning an _id if it has none.
+func (c *collectionData) validate(doc map[string]any) error {
+	if _, ok := doc["$vectorize"]; ok {
+		return errorf("UNSUPPORTED_VECTORIZE", "astradbtest: $vectorize needs a server-side model and is not supported")
+	}
+	if vec, ok := doc["$vector"]; ok && vec != nil {
+		v, ok := vectorOf(vec)
+		if !ok {
+			return errorf("SHRED_BAD_VECTOR_VALUE", "$vector must be an array of numbers")
+		}
+		if opts, ok := c.options["vector"].(map[string]any); ok {
+			if dim, ok := number(opts["dimension"]); ok && int(dim) != len(v) {
+				return errorf("VECTOR_SIZE_MISMATCH", "$vector has %d dimensions, collection expects %v", len(v), dim)
+			}
+		}
+	}
+	id, ok := doc["_id"]
+	if !ok {
+		id = c.newID()
+		doc["_id"] = id
+	}
+	for _, existing := range c.docs {
+		if equal(existing["_id"], id) {
+			return errorf("DOCUMENT_ALREADY_EXISTS", "document with _id %v already exists", id)
+		}
+	}
+	return nil
+}
+
+// newID returns a generated _id of the collection's default ID type.
+func (c *collectionData) newID() any {
+	var kind string
+	if opts, ok := c.options["defaultId"].(map[string]any); ok {
+		kind, _ = opts["type"].(string)
+	}
+	switch kind {
+	case "objectId":
+		b := make([]byte, 12)
+		rand.Read(b)
+		return map[string]any{"$objectId": hex.EncodeToString(b)}
+	case "uuid", "uuidv6", "uuidv7":
+		return map[string]any{"$uuid": newUUID()}
+	}
+	return newUUID()
+}
+
+// newUUID returns a random version 4 UUID.
+func newUUID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// find runs find and findOne on the given documents or rows.
+func (s *Server) find(docs []map[string]any, metric func(string) string, collection bool, req request) (response, error) {
+	var q findQuery
+	if err := req.decode(&q); err != nil {
+		return response{}, err
+	}
+	found, fields, err := search(docs, q, metric)
+	if err != nil {
+		return response{}, err
+	}
+	vs := vectorSort(fields)
+	result := func(f scored) map[string]any {
+		doc := project(f.doc, q.Projection, collection)
+		if q.Options.IncludeSimilarity && vs != nil {
+			doc["$similarity"] = f.similarity
+		}
+		return doc
+	}
+
+	if req.name == "findOne" {
+		var doc map[string]any
+		if len(found) > 0 {
+			doc = result(found[0])
+		}
+		return response{Data: map[string]any{"document": doc}}, nil
+	}
+
+	selected, next, err := page(found, fields, q, s.pageSize)
+	if err != nil {
+		return response{}, err
+	}
+	out := make([]map[string]any, 0, len(selected))
+	for _, f := range selected {
+		out = append(out, result(f))
+	}
+	data := map[string]any{"documents": out, "nextPageState": next}
+	if q.Options.IncludeSortVector && vs != nil {
+		data["sortVector"] = vs.vector
+	}
+	return response{Data: data}, nil
+}
+
+// updateDocuments runs the update and replace commands on a collection.
+func (s *Server) updateDocuments(c *collectionData, req request) (response, error) {
+	var q writeQuery
+	if err := req.decode(&q); err != nil {
+		return response{}, err
+	}
+	replace := strings.Contains(req.name, "Replace") || req.name == "replaceOne"
+	if replace {
+		for k := range q.Replacement {
+			if strings.HasPrefix(k, "$") && k != "$vector" {
+				return response{}, errorf("INVALID_REPLACEMENT", "replacement document cannot contain operator %q", k)
+			}
+		}
+	} else if len(q.Update) == 0 {
+		return response{}, errorf("MISSING_UPDATE_OPERATIONS", "%s needs an update clause", req.name)
+	}
+
+	found, _, err := search(c.docs, findQuery{Filter: q.Filter, Sort: q.Sort}, func(string) string { return c.metric() })
+	if err != nil {
+		return response{}, err
+	}
+	if req.name != "updateMany" {
+		found = found[:min(1, len(found))]
+	}
+
+	var before, after map[string]any
+	modified := 0
+	for _, f := range found {
+		doc := deepCopy(c.docs[f.index])
+		before = c.docs[f.index]
+		changed := false
+		if replace {
+			replacement := deepCopy(q.Replacement)
+			if id, ok := replacement["_id"]; ok && !equal(id, doc["_id"]) {
+				return response{}, errorf("UPDATE_FORBIDDEN_FOR_DOC_ID", "cannot change _id of a replaced document")
+			}
+			replacement["_id"] = doc["_id"]
+			changed = !equal(doc, replacement)
+			doc = replacement
+		} else if changed, err = applyUpdate(doc, q.Update, false); err != nil {
+			return response{}, err
+		}
+		if changed {
+			modified++
+		}
+		c.docs[f.index] = doc
+		after = doc
+	}
+
+	status := map[string]any{"matchedCount": len(found), "modifiedCount": modified}
+	if len(found) == 0 && q.Options.Upsert {
+		var doc map[string]any
+		if replace {
+			doc = deepCopy(q.Replacement)
+			if id, ok := q.Filter["_id"]; ok {
+				if _, set := doc["_id"]; !set {
+					doc["_id"] = id
+				}
+			}
+		} else if doc, err = upsertDocument(q.Filter, q.Update); err != nil {
+			return response{}, err
+		}
+		if err := c.validate(doc); err != nil {
+			return response{}, err
+		}
+		c.docs = append(c.docs, doc)
+		status["upsertedId"] = doc["_id"]
+		after = doc
+	}
+
+	resp := response{Status: status}
+	if strings.HasPrefix(req.name, "findOneAnd") {
+		doc := before
+		if q.Options.ReturnDocument == "after" {
+			doc = after
+		}
+		if doc != nil {
+			doc = project(doc, q.Projection, true)
+		}
+		resp.Data = map[string]any{"document": doc}
+	}
+	return resp, nil
+}
+
+// deleteDocuments runs the delete commands on a collection.
+func (s *Server) deleteDocuments(c *collectionData, req request) (response, error) {
+	var q writeQuery
+	if err := req.decode(&q); err != nil {
+		return response{}, err
+	}
+	found, _, err := search(c.docs, findQuery{Filter: q.Filter, Sort: q.Sort}, func(string) string { return c.metric() })
+	if err != nil {
+		return response{}, err
+	}
+	if req.name != "deleteMany" {
+		found = found[:min(1, len(found))]
+	}
+	c.docs = remove(c.docs, found)
+
+	resp := response{Status: map[string]any{"deletedCount": len(found)}}
+	if req.name == "findOneAndDelete" {
+		var doc map[string]any
+		if len(found) > 0 {
+			doc = project(found[0].doc, q.Projection, true)
+		}
+		resp.Data = map[string]any{"document": doc}
+	}
+	return resp, nil
+}
+
+// remove returns docs without the found entries.
+func remove(docs []map[string]any, found []scored) []map[string]any {
+	drop := make(map[int]bool, len(found))
+	for _, f := range found {
+		drop[f.index] = true
+	}
+	kept := docs[:0]
+	for i, doc := range docs {
+		if !drop[i] {
+			kept = append(kept, doc)
+		}
+	}
+	clear(docs[len(kept):])
+	return kept
+}
+
+// executeTable runs the row and index commands of a table.
+func (s *Server) executeTable(ks *keyspace, t *tableData, req request) (response, error) {
+	switch req.name {
+	case "insertOne", "insertMany":
+		return s.insertRows(t, req)
+	case "find", "findOne":
+		return s.find(t.rows, t.metric, false, req)
+	case "updateOne":
+		return s.updateRow(t, req)
+	case "deleteOne", "deleteMany":
+		var q writeQuery
+		if err := req.decode(&q); err != nil {
+			return response{}, err
+		}
+		found, _, err := search(t.rows, findQuery{Filter: q.Filter}, t.metric)
+		if err != nil {
+			return response{}, err
+		}
+		if req.name == "deleteOne" {
+			found = found[:min(1, len(found))]
+		}
+		t.rows = remove(t.rows, found)
+		return response{Status: map[string]any{"deletedCount": len(found)}}, nil
+	case "alterTable":
+		return s.alterTable(t, req)
+	case "createIndex", "createVectorIndex", "createTextIndex":
+		return s.createIndex(t, req)
+	case "listIndexes":
+		return s.listIndexes(t, req)
+	}
+	return response{}, unknownCommand(req)
+}
+
+// insertRows runs insertOne and insertMany on a table. Inserts are upserts:
+// a row with an existing primary key overwrites the given columns.
+func (s *Server) insertRows(t *tableData, req request) (response, error) {
+	var p struct {
+		Document  map[string]any   `json:"document"`
+		Documents []map[string]any `json:"documents"`
+		Options   struct {
+			Ordered bool `json:"ordered"`
+		} `json:"options"`
+	}
+	if err := req.decode(&p); err != nil {
+		return response{}, err
+	}
+	rows := p.Documents
+	if req.name == "insertOne" {
+		rows = []map[string]any{p.Document}
+	}
+
+	var resp response
+	pk := t.primaryKey()
+	ids := []any{}
+	for _, row := range rows {
+		row = deepCopy(row)
+		if err := t.validate(row); err != nil {
+			resp.Errors = append(resp.Errors, err.(*apiError))
+			if req.name == "insertOne" || p.Options.Ordered {
+				break
+			}
+			continue
+		}
+		t.upsert(row)
+		id := make([]any, len(pk))
+		for i, col := range pk {
+			id[i] = row[col]
+		}
+		ids = append(ids, id)
+	}
+	schema := map[string]any{}
+	for _, col := range pk {
+		schema[col] = map[string]any{"type": t.definition.Columns[col].Type}
+	}
+	resp.Status = map[string]any{"insertedIds": ids, "primaryKeySchema": schema}
+	return resp, nil
+}
+
+// validate checks a row against the table definition and drops its null
+// values, which the Data API doesn't store.
+func (t *tableData) validate(row map[string]any) error {
+	for col, v := range row {
+		if _, ok := t.definition.Columns[col]; !ok {
+			return errorf("UNKNOWN_TABLE_COLUMNS", "column %q is not defined in the table", col)
+		}
+		if v == nil {
+			delete(row, col)
+		}
+	}
+	for _, col := range t.primaryKey() {
+		if _, ok := row[col]; !ok {
+			return errorf("MISSING_PRIMARY_KEY_COLUMNS", "primary key column %q is missing", col)
+		}
+	}
+	return nil
+}
+
+// find returns the index of the row with the same primary key as row, or -1.
+func (t *tableData) find(row map[string]any) int {
+	pk := t.primaryKey()
+	return slices.IndexFunc(t.rows, func(existing map[string]any) bool {
+		for _, col := range pk {
+			if !equal(existing[col], row[col]) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// upsert inserts row, or overwrites the given columns of the row with the
+// same primary key.
+func (t *tableData) upsert(row map[string]any) {
+	i := t.find(row)
+	if i < 0 {
+		t.rows = append(t.rows, row)
+		return
+	}
+	for col, v := range row {
+		t.rows[i][col] = v
+	}
+}
+
+// updateRow runs updateOne on a table. The filter must match the full
+// primary key; if no row exists, one is created from the filter and $set.
+func (s *Server) updateRow(t *tableData, req request) (response, error) {
+	var q writeQuery
+	if err := req.decode(&q); err != nil {
+		return response{}, err
+	}
+	key := map[string]any{}
+	for _, col := range t.primaryKey() {
+		v, ok := q.Filter[col]
+		if ops, isMap := v.(map[string]any); isMap && isOperatorObject(ops) {
+			v, ok = ops["$eq"]
+		}
+		if !ok {
+			return response{}, errorf("FILTER_MISSING_PRIMARY_KEY_COLUMNS", "updateOne filter must include primary key column %q", col)
+		}
+		key[col] = v
+	}
+	for op, fields := range q.Update {
+		if op != "$set" && op != "$unset" {
+			return response{}, errorf("UNSUPPORTED_UPDATE_OPERATIONS_FOR_TABLE", "update operator %q is not supported on tables", op)
+		}
+		m, _ := fields.(map[string]any)
+		for col := range m {
+			if _, isKey := key[col]; isKey {
+				return response{}, errorf("UPDATE_PRIMARY_KEY_COLUMNS", "cannot update primary key column %q", col)
+			}
+			if _, ok := t.definition.Columns[col]; !ok {
+				return response{}, errorf("UNKNOWN_TABLE_COLUMNS", "column %q is not defined in the table", col)
+			}
+		}
+	}
+
+	i := t.find(key)
+	if i < 0 {
+		set, _ := q.Update["$set"].(map[string]any)
+		if len(set) == 0 {
+			return response{Status: map[string]any{"matchedCount": 0, "modifiedCount": 0}}, nil
+		}
+		row := deepCopy(key)
+		for col, v := range set {
+			row[col] = deepCopy(v)
+		}
+		if err := t.validate(row); err != nil {
+			return response{}, err
+		}
+		t.rows = append(t.rows, row)
+		return response{Status: map[string]any{"matchedCount": 0, "modifiedCount": 0, "upsertedId": key}}, nil
+	}
+
+	row := deepCopy(t.rows[i])
+	changed, err := applyUpdate(row, q.Update, false)
+	if err != nil {
+		return response{}, err
+	}
+	if err := t.validate(row); err != nil {
+		return response{}, err
+	}
+	t.rows[i] = row
+	modified := 0
+	if changed {
+		modified = 1
+	}
+	return response{Status: map[string]any{"matchedCount": 1, "modifiedCount": modified}}, nil
+}
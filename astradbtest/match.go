@@ -0,0 +1,404 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package astradbtest
+
+import (
+	"cmp"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Documents are held as decoded JSON: map[string]any, []any, json.Number,
+// string, bool and nil. Extended types such as {"$date": 1700000000000}
+// stay single-key objects and are compared by their value.
+
+// lookup returns the value at the dotted path in doc. Numeric segments
+// index into arrays.
+func lookup(doc map[string]any, path string) (any, bool) {
+	var cur any = doc
+	for seg := range strings.SplitSeq(path, ".") {
+		switch v := cur.(type) {
+		case map[string]any:
+			next, ok := v[seg]
+			if !ok {
+				return nil, false
+			}
+			cur = next
+		case []any:
+			i, err := strconv.Atoi(seg)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			cur = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// setPath sets the value at the dotted path in doc, creating intermediate
+// objects as needed.
+func setPath(doc map[string]any, path string, value any) error {
+	segs := strings.Split(path, ".")
+	cur := doc
+	for _, seg := range segs[:len(segs)-1] {
+		next, ok := cur[seg]
+		if !ok || next == nil {
+			m := map[string]any{}
+			cur[seg] = m
+			cur = m
+			continue
+		}
+		m, ok := next.(map[string]any)
+		if !ok {
+			return errorf("UNSUPPORTED_UPDATE_OPERATION_PATH", "cannot set %q: %q is not an object", path, seg)
+		}
+		cur = m
+	}
+	cur[segs[len(segs)-1]] = value
+	return nil
+}
+
+// unsetPath removes the value at the dotted path in doc.
+func unsetPath(doc map[string]any, path string) {
+	segs := strings.Split(path, ".")
+	cur := doc
+	for _, seg := range segs[:len(segs)-1] {
+		next, ok := cur[seg].(map[string]any)
+		if !ok {
+			return
+		}
+		cur = next
+	}
+	delete(cur, segs[len(segs)-1])
+}
+
+// literal reports whether v is an extended JSON value such as {"$date": n}
+// rather than an operator object, and returns its inner value.
+func literal(v any) (string, any, bool) {
+	m, ok := v.(map[string]any)
+	if !ok || len(m) != 1 {
+		return "", nil, false
+	}
+	for k, inner := range m {
+		switch k {
+		case "$date", "$uuid", "$objectId", "$binary":
+			return k, inner, true
+		}
+	}
+	return "", nil, false
+}
+
+// number returns v as a float64 if it is a JSON number.
+func number(v any) (float64, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// equal reports whether two JSON values are equal, comparing numbers by value.
+func equal(a, b any) bool {
+	if x, ok := number(a); ok {
+		y, ok := number(b)
+		return ok && x == y
+	}
+	switch x := a.(type) {
+	case map[string]any:
+		y, ok := b.(map[string]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for k, v := range x {
+			w, ok := y[k]
+			if !ok || !equal(v, w) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		y, ok := b.([]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equal(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// typeRank orders values of different types when sorting, following the
+// Data API: missing and null, numbers, strings, objects, arrays, booleans,
+// dates.
+func typeRank(v any) int {
+	if _, ok := number(v); ok {
+		return 1
+	}
+	if k, _, ok := literal(v); ok && k == "$date" {
+		return 6
+	}
+	switch v.(type) {
+	case nil:
+		return 0
+	case string:
+		return 2
+	case map[string]any:
+		return 3
+	case []any:
+		return 4
+	case bool:
+		return 5
+	}
+	return 7
+}
+
+// compare orders two JSON values. Values of different types are ordered by
+// [typeRank].
+func compare(a, b any) int {
+	if ra, rb := typeRank(a), typeRank(b); ra != rb {
+		return cmp.Compare(ra, rb)
+	}
+	if x, ok := number(a); ok {
+		y, _ := number(b)
+		return cmp.Compare(x, y)
+	}
+	if _, x, ok := literal(a); ok {
+		_, y, _ := literal(b)
+		return compare(x, y)
+	}
+	switch x := a.(type) {
+	case string:
+		return strings.Compare(x, b.(string))
+	case bool:
+		y := b.(bool)
+		switch {
+		case x == y:
+			return 0
+		case !x:
+			return -1
+		}
+		return 1
+	case []any:
+		y := b.([]any)
+		for i := 0; i < len(x) && i < len(y); i++ {
+			if c := compare(x[i], y[i]); c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(len(x), len(y))
+	}
+	return 0
+}
+
+// sameType reports whether an ordering operator can compare a and b,
+// i.e. whether they are non-null values of the same type.
+func sameType(a, b any) bool {
+	return typeRank(a) == typeRank(b) && typeRank(a) != 0
+}
+
+// matches reports whether doc matches the filter f.
+func matches(doc map[string]any, f map[string]any) (bool, error) {
+	for key, cond := range f {
+		var ok bool
+		var err error
+		switch key {
+		case "$and", "$or":
+			ok, err = matchLogical(doc, key, cond)
+		case "$not":
+			sub, isMap := cond.(map[string]any)
+			if !isMap {
+				return false, errorf("INVALID_FILTER_EXPRESSION", "$not needs an object")
+			}
+			ok, err = matches(doc, sub)
+			ok = !ok
+		default:
+			if strings.HasPrefix(key, "$") && key != "$vector" && key != "$vectorize" {
+				return false, errorf("UNSUPPORTED_FILTER_OPERATION", "unsupported filter operator %q", key)
+			}
+			ok, err = matchField(doc, key, cond)
+		}
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// matchLogical evaluates $and and $or.
+func matchLogical(doc map[string]any, op string, cond any) (bool, error) {
+	subs, ok := cond.([]any)
+	if !ok || len(subs) == 0 {
+		return false, errorf("INVALID_FILTER_EXPRESSION", "%s needs a non-empty array", op)
+	}
+	for _, sub := range subs {
+		m, ok := sub.(map[string]any)
+		if !ok {
+			return false, errorf("INVALID_FILTER_EXPRESSION", "%s needs an array of objects", op)
+		}
+		matched, err := matches(doc, m)
+		if err != nil {
+			return false, err
+		}
+		if op == "$or" && matched {
+			return true, nil
+		}
+		if op == "$and" && !matched {
+			return false, nil
+		}
+	}
+	return op == "$and", nil
+}
+
+// matchField evaluates the condition on a single field, which is either a
+// value to compare for equality or an object of operators.
+func matchField(doc map[string]any, path string, cond any) (bool, error) {
+	value, exists := lookup(doc, path)
+	ops, isMap := cond.(map[string]any)
+	if _, _, isLiteral := literal(cond); !isMap || isLiteral || len(ops) == 0 || !isOperatorObject(ops) {
+		return exists && matchEq(value, cond), nil
+	}
+	for op, arg := range ops {
+		ok, err := matchOperator(value, exists, op, arg)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// isOperatorObject reports whether all keys of m are operators.
+func isOperatorObject(m map[string]any) bool {
+	for k := range m {
+		if !strings.HasPrefix(k, "$") {
+			return false
+		}
+	}
+	return true
+}
+
+// matchEq reports whether value equals cond, or is an array containing it.
+func matchEq(value, cond any) bool {
+	if equal(value, cond) {
+		return true
+	}
+	if arr, ok := value.([]any); ok {
+		for _, elem := range arr {
+			if equal(elem, cond) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchOperator evaluates a single field operator.
+func matchOperator(value any, exists bool, op string, arg any) (bool, error) {
+	switch op {
+	case "$eq":
+		return exists && matchEq(value, arg), nil
+	case "$ne":
+		return !exists || !matchEq(value, arg), nil
+	case "$gt", "$gte", "$lt", "$lte":
+		if !exists {
+			return false, nil
+		}
+		return matchAny(value, func(v any) bool {
+			if !sameType(v, arg) {
+				return false
+			}
+			c := compare(v, arg)
+			switch op {
+			case "$gt":
+				return c > 0
+			case "$gte":
+				return c >= 0
+			case "$lt":
+				return c < 0
+			}
+			return c <= 0
+		}), nil
+	case "$in", "$nin":
+		list, ok := arg.([]any)
+		if !ok {
+			return false, errorf("INVALID_FILTER_EXPRESSION", "%s needs an array", op)
+		}
+		found := false
+		for _, item := range list {
+			if exists && matchEq(value, item) {
+				found = true
+				break
+			}
+		}
+		return found == (op == "$in"), nil
+	case "$exists":
+		want, ok := arg.(bool)
+		if !ok {
+			return false, errorf("INVALID_FILTER_EXPRESSION", "$exists needs a boolean")
+		}
+		return exists == want, nil
+	case "$all":
+		list, ok := arg.([]any)
+		if !ok {
+			return false, errorf("INVALID_FILTER_EXPRESSION", "$all needs an array")
+		}
+		arr, isArr := value.([]any)
+		if !exists || !isArr {
+			return false, nil
+		}
+		for _, item := range list {
+			if !matchEq(arr, item) {
+				return false, nil
+			}
+		}
+		return true, nil
+	case "$size":
+		n, ok := number(arg)
+		if !ok {
+			return false, errorf("INVALID_FILTER_EXPRESSION", "$size needs a number")
+		}
+		arr, isArr := value.([]any)
+		return exists && isArr && float64(len(arr)) == n, nil
+	}
+	return false, errorf("UNSUPPORTED_FILTER_OPERATION", "unsupported filter operator %q", op)
+}
+
+// matchAny reports whether pred holds for value or, if value is an array,
+// for any of its elements.
+func matchAny(value any, pred func(any) bool) bool {
+	if pred(value) {
+		return true
+	}
+	if arr, ok := value.([]any); ok {
+		for _, elem := range arr {
+			if pred(elem) {
+				return true
+			}
+		}
+	}
+	return false
+}
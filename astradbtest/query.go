@@ -0,0 +1,353 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package astradbtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"slices"
+	"strconv"
+)
+
+// maxVectorLimit is the largest number of documents returned by a vector
+// search, as in the Data API.
+const maxVectorLimit = 1000
+
+// sortField is one entry of a sort clause.
+type sortField struct {
+	path  string
+	order int
+	// vector is set for vector sorts.
+	vector []float64
+}
+
+// parseSort decodes a sort clause, keeping the order of its fields.
+func parseSort(raw json.RawMessage) ([]sortField, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, errorf("INVALID_SORT_CLAUSE", "sort must be an object")
+	}
+	var fields []sortField
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, errorf("INVALID_SORT_CLAUSE", "invalid sort: %v", err)
+		}
+		path := tok.(string)
+		var value any
+		if err := dec.Decode(&value); err != nil {
+			return nil, errorf("INVALID_SORT_CLAUSE", "invalid sort: %v", err)
+		}
+		switch path {
+		case "$vectorize", "$lexical", "$hybrid":
+			return nil, errorf("UNSUPPORTED_SORT_CLAUSE", "astradbtest: %s sorts need a server-side model and are not supported", path)
+		}
+		if arr, ok := value.([]any); ok {
+			vec := make([]float64, len(arr))
+			for i, v := range arr {
+				f, ok := number(v)
+				if !ok {
+					return nil, errorf("INVALID_SORT_CLAUSE", "vector sort on %q needs an array of numbers", path)
+				}
+				vec[i] = f
+			}
+			fields = append(fields, sortField{path: path, vector: vec})
+			continue
+		}
+		if _, ok := value.(string); ok {
+			return nil, errorf("UNSUPPORTED_SORT_CLAUSE", "astradbtest: vectorize sort on %q needs a server-side model and is not supported", path)
+		}
+		n, ok := number(value)
+		if !ok || (n != 1 && n != -1) {
+			return nil, errorf("INVALID_SORT_CLAUSE", "sort order for %q must be 1 or -1", path)
+		}
+		fields = append(fields, sortField{path: path, order: int(n)})
+	}
+	if vectorSort(fields) != nil && len(fields) > 1 {
+		return nil, errorf("INVALID_SORT_CLAUSE", "a vector sort cannot be combined with other sort fields")
+	}
+	return fields, nil
+}
+
+// vectorSort returns the vector sort field of fields, or nil.
+func vectorSort(fields []sortField) *sortField {
+	for i := range fields {
+		if fields[i].vector != nil {
+			return &fields[i]
+		}
+	}
+	return nil
+}
+
+// findQuery is a decoded find or findOne command.
+type findQuery struct {
+	Filter     map[string]any  `json:"filter"`
+	Sort       json.RawMessage `json:"sort"`
+	Projection map[string]any  `json:"projection"`
+	Options    struct {
+		Limit             *int    `json:"limit"`
+		Skip              *int    `json:"skip"`
+		IncludeSimilarity bool    `json:"includeSimilarity"`
+		IncludeSortVector bool    `json:"includeSortVector"`
+		PageState         *string `json:"pageState"`
+	} `json:"options"`
+}
+
+// scored is a matching document with its similarity for vector sorts.
+type scored struct {
+	index      int
+	doc        map[string]any
+	similarity float64
+}
+
+// search returns the documents matching the filter of q, in sort order.
+// The index of each match in docs is kept so callers can modify it.
+func search(docs []map[string]any, q findQuery, metric func(string) string) ([]scored, []sortField, error) {
+	fields, err := parseSort(q.Sort)
+	if err != nil {
+		return nil, nil, err
+	}
+	var found []scored
+	for i, doc := range docs {
+		ok, err := matches(doc, q.Filter)
+		if err != nil {
+			return nil, nil, err
+		}
+		if ok {
+			found = append(found, scored{index: i, doc: doc})
+		}
+	}
+
+	if vs := vectorSort(fields); vs != nil {
+		m := metric(vs.path)
+		kept := found[:0]
+		for _, s := range found {
+			vec, ok := vectorOf(s.doc[vs.path])
+			if !ok || len(vec) != len(vs.vector) {
+				continue
+			}
+			s.similarity = similarity(m, vec, vs.vector)
+			kept = append(kept, s)
+		}
+		found = kept
+		slices.SortStableFunc(found, func(a, b scored) int {
+			switch {
+			case a.similarity > b.similarity:
+				return -1
+			case a.similarity < b.similarity:
+				return 1
+			}
+			return 0
+		})
+		return found, fields, nil
+	}
+
+	if len(fields) > 0 {
+		slices.SortStableFunc(found, func(a, b scored) int {
+			for _, f := range fields {
+				va, _ := lookup(a.doc, f.path)
+				vb, _ := lookup(b.doc, f.path)
+				if c := compare(va, vb); c != 0 {
+					return c * f.order
+				}
+			}
+			return 0
+		})
+	}
+	return found, fields, nil
+}
+
+// page returns the slice of found selected by the skip, limit and
+// pageState options of q, and the state of the next page. Vector searches
+// return a single page of up to [maxVectorLimit] documents.
+func page(found []scored, fields []sortField, q findQuery, pageSize int) ([]scored, *string, error) {
+	start := 0
+	if q.Options.Skip != nil {
+		if len(fields) == 0 {
+			return nil, nil, errorf("INVALID_FIND_OPTION", "skip requires a sort clause")
+		}
+		start = *q.Options.Skip
+	}
+	end := len(found)
+	if q.Options.Limit != nil {
+		end = min(end, start+*q.Options.Limit)
+	}
+	if vectorSort(fields) != nil {
+		end = min(end, start+maxVectorLimit)
+		return found[min(start, len(found)):max(start, end)], nil, nil
+	}
+
+	// The page state is the offset of the next page in found.
+	if q.Options.PageState != nil && *q.Options.PageState != "" {
+		offset, err := strconv.Atoi(*q.Options.PageState)
+		if err != nil || offset < start {
+			return nil, nil, errorf("INVALID_PAGE_STATE", "invalid page state %q", *q.Options.PageState)
+		}
+		start = offset
+	}
+	start = min(start, len(found))
+	end = max(start, end)
+	var next *string
+	if end-start > pageSize {
+		end = start + pageSize
+		state := strconv.Itoa(end)
+		next = &state
+	}
+	return found[start:end], next, nil
+}
+
+// vectorOf returns v as a vector if it is an array of numbers.
+func vectorOf(v any) ([]float64, bool) {
+	arr, ok := v.([]any)
+	if !ok {
+		return nil, false
+	}
+	vec := make([]float64, len(arr))
+	for i, e := range arr {
+		f, ok := number(e)
+		if !ok {
+			return nil, false
+		}
+		vec[i] = f
+	}
+	return vec, true
+}
+
+// similarity returns the normalized similarity score of a and b for metric,
+// between 0 and 1 as reported by the Data API.
+func similarity(metric string, a, b []float64) float64 {
+	var dot, na, nb, dist float64
+	for i := range a {
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+		d := a[i] - b[i]
+		dist += d * d
+	}
+	switch metric {
+	case "dot_product":
+		return (1 + dot) / 2
+	case "euclidean":
+		return 1 / (1 + dist)
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return (1 + dot/math.Sqrt(na*nb)) / 2
+}
+
+// project returns a copy of doc with the projection applied. For
+// collections, $vector is only returned when projected explicitly.
+func project(doc map[string]any, projection map[string]any, collection bool) map[string]any {
+	out := deepCopy(doc)
+	if collection {
+		if v, ok := projection["$vector"]; !ok || !truthy(v) {
+			if star, ok := projection["*"]; !ok || !truthy(star) {
+				delete(out, "$vector")
+			}
+		}
+	}
+	if len(projection) == 0 {
+		return out
+	}
+	if star, ok := projection["*"]; ok {
+		if truthy(star) {
+			return out
+		}
+		return map[string]any{}
+	}
+
+	include := false
+	for path, v := range projection {
+		if _, isSlice := v.(map[string]any); !isSlice && path != "_id" && path != "$vector" && truthy(v) {
+			include = true
+		}
+	}
+
+	if include {
+		selected := map[string]any{}
+		if v, ok := projection["_id"]; !ok || truthy(v) {
+			if id, ok := out["_id"]; ok {
+				selected["_id"] = id
+			}
+		}
+		for path, v := range projection {
+			if _, isSlice := v.(map[string]any); isSlice || !truthy(v) {
+				continue
+			}
+			if value, ok := lookup(out, path); ok {
+				setPath(selected, path, value)
+			}
+		}
+		out = selected
+	} else {
+		for path, v := range projection {
+			if _, isSlice := v.(map[string]any); !isSlice && !truthy(v) {
+				unsetPath(out, path)
+			}
+		}
+	}
+
+	for path, v := range projection {
+		if spec, ok := v.(map[string]any); ok {
+			if arr, ok := lookup(out, path); ok {
+				if arr, ok := arr.([]any); ok {
+					setPath(out, path, slice(arr, spec["$slice"]))
+				}
+			}
+		}
+	}
+	return out
+}
+
+// truthy reports whether a projection value selects a field.
+func truthy(v any) bool {
+	switch x := v.(type) {
+	case bool:
+		return x
+	case nil:
+		return false
+	}
+	n, ok := number(v)
+	return !ok || n != 0
+}
+
+// slice applies a $slice projection, either a count (negative counts from
+// the end) or a [skip, limit] pair.
+func slice(arr []any, spec any) []any {
+	if pair, ok := spec.([]any); ok && len(pair) == 2 {
+		skip, _ := number(pair[0])
+		limit, _ := number(pair[1])
+		start := int(skip)
+		if start < 0 {
+			start = max(0, len(arr)+start)
+		}
+		start = min(start, len(arr))
+		return arr[start:min(len(arr), start+max(0, int(limit)))]
+	}
+	n, ok := number(spec)
+	if !ok {
+		return arr
+	}
+	if n < 0 {
+		return arr[max(0, len(arr)+int(n)):]
+	}
+	return arr[:min(len(arr), int(n))]
+}
@@ -0,0 +1,338 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package astradbtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Redacted replaces the values of secret headers in recordings.
+const Redacted = "REDACTED"
+
+// Interaction is a request and its response as stored in a golden file.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the recorded part of a request.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	// Command is the name of the Data API command, if the body holds one.
+	Command string `json:"command,omitempty"`
+	// Body is the JSON request body.
+	Body json.RawMessage `json:"body,omitempty"`
+}
+
+// RecordedResponse is the recorded part of a response.
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	// Body is the response body if it is JSON, otherwise BodyText is.
+	Body     json.RawMessage `json:"body,omitempty"`
+	BodyText string          `json:"bodyText,omitempty"`
+}
+
+// isSecretHeader reports whether the header carries a credential: the Data
+// API token, an Authorization header or an embedding provider key.
+func isSecretHeader(name string) bool {
+	name = strings.ToLower(name)
+	return name == "token" || name == "authorization" || strings.HasPrefix(name, "x-embedding-")
+}
+
+// redact returns a copy of h with secret header values replaced by [Redacted].
+func redact(h http.Header) http.Header {
+	out := h.Clone()
+	for name := range out {
+		if isSecretHeader(name) {
+			out[name] = []string{Redacted}
+		}
+	}
+	return out
+}
+
+// readBody reads and returns the request or response body in *body,
+// leaving a fresh reader in its place.
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	b, err := io.ReadAll(*body)
+	(*body).Close()
+	*body = io.NopCloser(bytes.NewReader(b))
+	return b, err
+}
+
+// commandName returns the name of the Data API command in body, which is
+// the single key of the JSON object.
+func commandName(body []byte) string {
+	var cmd map[string]json.RawMessage
+	if json.Unmarshal(body, &cmd) != nil || len(cmd) != 1 {
+		return ""
+	}
+	for name := range cmd {
+		return name
+	}
+	return ""
+}
+
+// canonical returns body re-encoded with sorted object keys and no
+// insignificant whitespace, so equal commands compare equal.
+func canonical(body []byte) string {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if dec.Decode(&v) != nil {
+		return string(body)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return string(body)
+	}
+	return string(b)
+}
+
+// matchKey returns the key used to match a request on replay: the method,
+// the path and query without scheme and host, and the canonicalized body.
+// The path carries the API version, keyspace and collection or table, so
+// the same command sent to different resources doesn't match.
+func matchKey(method, rawURL string, body []byte) string {
+	path := rawURL
+	if u, err := url.Parse(rawURL); err == nil {
+		path = u.RequestURI()
+	}
+	key := method + " " + path
+	if len(body) > 0 {
+		key += " " + canonical(body)
+	}
+	return key
+}
+
+// Recorder is an [http.RoundTripper] that forwards requests and records
+// them with their responses. Secret headers are redacted before the
+// interactions are written to the golden file.
+//
+// Use it to capture traffic against a live database once:
+//
+//	rec := astradbtest.NewRecorder(t, "testdata/books.json", nil)
+//	client := astradb.NewClient(options.WithToken(token), options.WithHTTPClient(rec.Client()))
+//
+// The golden file is written when the test finishes.
+type Recorder struct {
+	path string
+	base http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+}
+
+// NewRecorder returns a Recorder that sends requests with base, or
+// [http.DefaultTransport] if base is nil, and writes the golden file at
+// path when tb finishes.
+func NewRecorder(tb testing.TB, path string, base http.RoundTripper) *Recorder {
+	tb.Helper()
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	r := &Recorder{path: path, base: base}
+	tb.Cleanup(func() {
+		if err := r.Save(); err != nil {
+			tb.Errorf("astradbtest: %v", err)
+		}
+	})
+	return r
+}
+
+// Client returns an [http.Client] using the Recorder, for use with
+// options.WithHTTPClient.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip implements [http.RoundTripper].
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	if len(reqBody) > 0 && !json.Valid(reqBody) {
+		return nil, fmt.Errorf("astradbtest: cannot record non-JSON request body to %s", req.URL)
+	}
+	resp, err := r.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	recorded := Interaction{
+		Request: RecordedRequest{
+			Method:  req.Method,
+			URL:     req.URL.String(),
+			Header:  redact(req.Header),
+			Command: commandName(reqBody),
+			Body:    reqBody,
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+		},
+	}
+	if json.Valid(respBody) {
+		recorded.Response.Body = respBody
+	} else {
+		recorded.Response.BodyText = string(respBody)
+	}
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, recorded)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// Interactions returns the interactions recorded so far.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction(nil), r.interactions...)
+}
+
+// Save writes the recorded interactions to the golden file. It is called
+// automatically when the test finishes.
+func (r *Recorder) Save() error {
+	b, err := json.MarshalIndent(r.Interactions(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding recording: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("writing recording: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(b, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing recording: %w", err)
+	}
+	return nil
+}
+
+// Replayer is an [http.RoundTripper] that answers requests from a golden
+// file written by [Recorder], without network access.
+//
+// Requests are matched by method, URL path and canonicalized JSON body, so
+// the same command against another keyspace, collection or table doesn't
+// match, while the scheme and host, headers and key order don't matter.
+// Each recorded interaction is used once, in recorded order among
+// identical requests. A request without a match fails the test and returns
+// an error to the client.
+//
+// Commands whose bodies vary between runs, such as inserts of documents
+// with generated IDs or timestamps, need deterministic test data to be
+// replayed.
+type Replayer struct {
+	tb testing.TB
+
+	mu      sync.Mutex
+	pending map[string][]Interaction
+}
+
+// NewReplayer returns a Replayer for the golden file at path. It fails
+// the test if the file can't be read.
+func NewReplayer(tb testing.TB, path string) *Replayer {
+	tb.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		tb.Fatalf("astradbtest: reading recording: %v", err)
+	}
+	var interactions []Interaction
+	if err := json.Unmarshal(b, &interactions); err != nil {
+		tb.Fatalf("astradbtest: decoding recording %s: %v", path, err)
+	}
+	r := &Replayer{tb: tb, pending: make(map[string][]Interaction)}
+	for _, in := range interactions {
+		key := matchKey(in.Request.Method, in.Request.URL, in.Request.Body)
+		r.pending[key] = append(r.pending[key], in)
+	}
+	return r
+}
+
+// Client returns an [http.Client] using the Replayer, for use with
+// options.WithHTTPClient.
+func (r *Replayer) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip implements [http.RoundTripper].
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	key := matchKey(req.Method, req.URL.String(), body)
+
+	r.mu.Lock()
+	queue := r.pending[key]
+	if len(queue) == 0 {
+		r.mu.Unlock()
+		err := fmt.Errorf("astradbtest: unexpected request %s %s %s", req.Method, req.URL.Path, body)
+		r.tb.Error(err)
+		return nil, err
+	}
+	in := queue[0]
+	r.pending[key] = queue[1:]
+	r.mu.Unlock()
+
+	respBody := []byte(in.Response.Body)
+	if in.Response.Body == nil {
+		respBody = []byte(in.Response.BodyText)
+	}
+	header := in.Response.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+		StatusCode:    in.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(respBody)),
+		ContentLength: int64(len(respBody)),
+		Request:       req,
+	}, nil
+}
+
+// Remaining returns the number of recorded interactions not yet replayed.
+func (r *Replayer) Remaining() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, queue := range r.pending {
+		n += len(queue)
+	}
+	return n
+}
@@ -0,0 +1,134 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package astradbtest_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	astradb "github.com/datastax/astra-db-go"
+	"github.com/datastax/astra-db-go/astradbtest"
+	"github.com/datastax/astra-db-go/filter"
+	"github.com/datastax/astra-db-go/options"
+)
+
+// captureTB records test failures instead of reporting them.
+type captureTB struct {
+	testing.TB
+	errors []string
+}
+
+func (c *captureTB) Error(args ...any) {
+	c.errors = append(c.errors, fmt.Sprint(args...))
+}
+
+// runBooks runs a fixed sequence of commands and returns the titles found.
+func runBooks(t *testing.T, db *astradb.Db) []string {
+	t.Helper()
+	ctx := context.Background()
+	coll, err := db.CreateCollection(ctx, "books", nil)
+	if err != nil {
+		t.Fatalf("CreateCollection failed: %v", err)
+	}
+	if _, err := coll.InsertMany(ctx, []book{{ID: "1", Title: "Dune"}, {ID: "2", Title: "Emma"}}); err != nil {
+		t.Fatalf("InsertMany failed: %v", err)
+	}
+	var found []book
+	if err := coll.Find(ctx, filter.F{"title": "Emma"}).All(ctx, &found); err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	var titles []string
+	for _, b := range found {
+		titles = append(titles, b.Title)
+	}
+	return titles
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	golden := filepath.Join(t.TempDir(), "testdata", "books.json")
+
+	t.Run("record", func(t *testing.T) {
+		fake := astradbtest.New(t)
+		rec := astradbtest.NewRecorder(t, golden, nil)
+		db := astradb.NewClient(
+			options.WithToken("AstraCS:secret"),
+			options.WithHeader("x-embedding-api-key", "sk-secret"),
+			options.WithHTTPClient(rec.Client()),
+		).Database(fake.URL)
+		if titles := runBooks(t, db); len(titles) != 1 || titles[0] != "Emma" {
+			t.Fatalf("unexpected titles %v", titles)
+		}
+		in := rec.Interactions()
+		if len(in) != 3 || in[0].Request.Command != "createCollection" {
+			t.Fatalf("unexpected interactions %+v", in)
+		}
+		if in[0].Request.Header.Get("Token") != astradbtest.Redacted {
+			t.Errorf("expected token to be redacted, got %q", in[0].Request.Header.Get("Token"))
+		}
+	})
+
+	b, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("golden file not written: %v", err)
+	}
+	if strings.Contains(string(b), "secret") {
+		t.Errorf("golden file contains a secret:\n%s", b)
+	}
+
+	t.Run("replay", func(t *testing.T) {
+		rep := astradbtest.NewReplayer(t, golden)
+		db := astradb.NewClient(
+			options.WithToken("other-token"),
+			options.WithHTTPClient(rep.Client()),
+		).Database("https://replayed.example.com")
+		if titles := runBooks(t, db); len(titles) != 1 || titles[0] != "Emma" {
+			t.Fatalf("unexpected replayed titles %v", titles)
+		}
+		if rep.Remaining() != 0 {
+			t.Errorf("expected all interactions to be replayed, %d left", rep.Remaining())
+		}
+	})
+
+	t.Run("unexpected request", func(t *testing.T) {
+		tb := &captureTB{TB: t}
+		rep := astradbtest.NewReplayer(tb, golden)
+		db := astradb.NewClient(options.WithHTTPClient(rep.Client())).Database("https://replayed.example.com")
+		err := db.Collection("books").FindOne(context.Background(), filter.F{"title": "Ulysses"}).Err()
+		if err == nil || !strings.Contains(err.Error(), "unexpected request") {
+			t.Errorf("expected unexpected request error, got %v", err)
+		}
+		if len(tb.errors) != 1 {
+			t.Errorf("expected the test to be failed once, got %v", tb.errors)
+		}
+	})
+
+	t.Run("other keyspace", func(t *testing.T) {
+		tb := &captureTB{TB: t}
+		rep := astradbtest.NewReplayer(tb, golden)
+		db := astradb.NewClient(options.WithHTTPClient(rep.Client())).
+			Database("https://replayed.example.com", options.WithKeyspace("other"))
+		_, err := db.CreateCollection(context.Background(), "books", nil)
+		if err == nil || !strings.Contains(err.Error(), "unexpected request") {
+			t.Errorf("expected unexpected request error, got %v", err)
+		}
+		if len(tb.errors) != 1 {
+			t.Errorf("expected the test to be failed once, got %v", tb.errors)
+		}
+	})
+}
@@ -0,0 +1,507 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package astradbtest
+
+import (
+	"encoding/json"
+	"maps"
+	"reflect"
+	"slices"
+
+	"github.com/datastax/astra-db-go/table"
+)
+
+// collectionData is the state of a collection.
+type collectionData struct {
+	options map[string]any
+	docs    []map[string]any
+}
+
+// metric returns the similarity metric of the collection's vector options.
+func (c *collectionData) metric() string {
+	if v, ok := c.options["vector"].(map[string]any); ok {
+		if m, ok := v["metric"].(string); ok {
+			return m
+		}
+	}
+	return "cosine"
+}
+
+// tableData is the state of a table.
+type tableData struct {
+	definition table.Definition
+	rows       []map[string]any
+	indexes    []indexData
+}
+
+// indexData is an index on a table, kept in the listIndexes format.
+type indexData struct {
+	Name       string `json:"name"`
+	Definition any    `json:"definition"`
+	IndexType  string `json:"indexType"`
+	column     string
+	metric     string
+}
+
+// primaryKey returns the primary key columns of the table in order.
+func (t *tableData) primaryKey() []string {
+	pk := slices.Clone(t.definition.PrimaryKey.PartitionBy)
+	return append(pk, slices.Sorted(maps.Keys(t.definition.PrimaryKey.PartitionSort))...)
+}
+
+// metric returns the similarity metric of the vector index on column.
+func (t *tableData) metric(column string) string {
+	for _, idx := range t.indexes {
+		if idx.column == column && idx.metric != "" {
+			return idx.metric
+		}
+	}
+	return "cosine"
+}
+
+// sortedKeys returns the keys of m in order.
+func sortedKeys[V any](m map[string]V) []string {
+	return slices.Sorted(maps.Keys(m))
+}
+
+// schemaOptions holds the options of schema commands.
+type schemaOptions struct {
+	Explain     bool `json:"explain"`
+	IfNotExists bool `json:"ifNotExists"`
+	IfExists    bool `json:"ifExists"`
+}
+
+// executeKeyspace runs the keyspace-level schema commands.
+func (s *Server) executeKeyspace(ks *keyspace, req request) (response, error) {
+	var p struct {
+		Name       string          `json:"name"`
+		Definition json.RawMessage `json:"definition"`
+		Operation  json.RawMessage `json:"operation"`
+		Options    json.RawMessage `json:"options"`
+	}
+	if err := req.decode(&p); err != nil {
+		return response{}, err
+	}
+	var opts schemaOptions
+	if len(p.Options) > 0 && req.name != "createCollection" {
+		if err := json.Unmarshal(p.Options, &opts); err != nil {
+			return response{}, errorf("INVALID_REQUEST", "invalid options: %v", err)
+		}
+	}
+
+	switch req.name {
+	case "createCollection":
+		var collOpts map[string]any
+		if err := decodeJSON(p.Options, &collOpts); err != nil {
+			return response{}, errorf("INVALID_REQUEST", "invalid options: %v", err)
+		}
+		if len(collOpts) == 0 {
+			collOpts = nil
+		}
+		if _, exists := ks.tables[p.Name]; exists {
+			return response{}, errorf("EXISTING_TABLE_NOT_DATA_API_COLLECTION", "a table named %q already exists", p.Name)
+		}
+		if c, exists := ks.collections[p.Name]; exists {
+			if !reflect.DeepEqual(c.options, collOpts) {
+				return response{}, errorf("EXISTING_COLLECTION_DIFFERENT_SETTINGS", "collection %q already exists with different settings", p.Name)
+			}
+			return response{Status: statusOK}, nil
+		}
+		ks.collections[p.Name] = &collectionData{options: collOpts}
+		return response{Status: statusOK}, nil
+
+	case "deleteCollection":
+		delete(ks.collections, p.Name)
+		return response{Status: statusOK}, nil
+
+	case "findCollections":
+		if !opts.Explain {
+			return response{Status: map[string]any{"collections": sortedKeys(ks.collections)}}, nil
+		}
+		colls := []any{}
+		for _, name := range sortedKeys(ks.collections) {
+			desc := map[string]any{"name": name, "options": ks.collections[name].options}
+			if desc["options"] == nil {
+				desc["options"] = map[string]any{}
+			}
+			colls = append(colls, desc)
+		}
+		return response{Status: map[string]any{"collections": colls}}, nil
+
+	case "createTable":
+		var def table.Definition
+		if err := json.Unmarshal(p.Definition, &def); err != nil {
+			return response{}, errorf("INVALID_REQUEST", "invalid table definition: %v", err)
+		}
+		if _, exists := ks.collections[p.Name]; exists {
+			return response{}, errorf("CANNOT_ADD_EXISTING_TABLE", "a collection named %q already exists", p.Name)
+		}
+		if _, exists := ks.tables[p.Name]; exists {
+			if opts.IfNotExists {
+				return response{Status: statusOK}, nil
+			}
+			return response{}, errorf("CANNOT_ADD_EXISTING_TABLE", "table %q already exists", p.Name)
+		}
+		if len(def.PrimaryKey.PartitionBy) == 0 {
+			return response{}, errorf("MISSING_PARTITION_COLUMNS", "table %q has no partition columns", p.Name)
+		}
+		for _, col := range append(def.PrimaryKey.PartitionBy, sortedKeys(def.PrimaryKey.PartitionSort)...) {
+			if _, ok := def.Columns[col]; !ok {
+				return response{}, errorf("UNKNOWN_PRIMARY_KEY_COLUMNS", "primary key column %q is not defined", col)
+			}
+		}
+		for name, col := range def.Columns {
+			if col.Type == table.TypeUDT && col.UDTName != nil {
+				if _, ok := ks.types[*col.UDTName]; !ok {
+					return response{}, errorf("UNKNOWN_USER_DEFINED_TYPE", "column %q uses unknown type %q", name, *col.UDTName)
+				}
+			}
+		}
+		ks.tables[p.Name] = &tableData{definition: def}
+		return response{Status: statusOK}, nil
+
+	case "dropTable":
+		if _, exists := ks.tables[p.Name]; !exists && !opts.IfExists {
+			return response{}, errorf("CANNOT_DROP_UNKNOWN_TABLE", "table %q does not exist", p.Name)
+		}
+		delete(ks.tables, p.Name)
+		return response{Status: statusOK}, nil
+
+	case "listTables":
+		if !opts.Explain {
+			return response{Status: map[string]any{"tables": sortedKeys(ks.tables)}}, nil
+		}
+		tables := []any{}
+		for _, name := range sortedKeys(ks.tables) {
+			def := ks.tables[name].definition
+			tables = append(tables, map[string]any{"name": name, "definition": map[string]any{
+				"columns":    explainColumns(def.Columns),
+				"primaryKey": def.PrimaryKey,
+			}})
+		}
+		return response{Status: map[string]any{"tables": tables}}, nil
+
+	case "dropIndex":
+		for _, t := range ks.tables {
+			for i, idx := range t.indexes {
+				if idx.Name == p.Name {
+					t.indexes = slices.Delete(t.indexes, i, i+1)
+					return response{Status: statusOK}, nil
+				}
+			}
+		}
+		if opts.IfExists {
+			return response{Status: statusOK}, nil
+		}
+		return response{}, errorf("CANNOT_DROP_UNKNOWN_INDEX", "index %q does not exist", p.Name)
+
+	case "createType":
+		var def table.TypeDefinition
+		if err := json.Unmarshal(p.Definition, &def); err != nil {
+			return response{}, errorf("INVALID_REQUEST", "invalid type definition: %v", err)
+		}
+		if _, exists := ks.types[p.Name]; exists {
+			if opts.IfNotExists {
+				return response{Status: statusOK}, nil
+			}
+			return response{}, errorf("CANNOT_ADD_EXISTING_TYPE", "type %q already exists", p.Name)
+		}
+		ks.types[p.Name] = def
+		return response{Status: statusOK}, nil
+
+	case "dropType":
+		if _, exists := ks.types[p.Name]; !exists {
+			if opts.IfExists {
+				return response{Status: statusOK}, nil
+			}
+			return response{}, errorf("CANNOT_DROP_UNKNOWN_TYPE", "type %q does not exist", p.Name)
+		}
+		for tname, t := range ks.tables {
+			for _, col := range t.definition.Columns {
+				if usesType(col, p.Name) {
+					return response{}, errorf("CANNOT_DROP_TYPE_USED_BY_TABLE", "type %q is used by table %q", p.Name, tname)
+				}
+			}
+		}
+		delete(ks.types, p.Name)
+		return response{Status: statusOK}, nil
+
+	case "alterType":
+		return s.alterType(ks, p.Name, p.Operation)
+
+	case "listTypes":
+		if !opts.Explain {
+			return response{Status: map[string]any{"types": sortedKeys(ks.types)}}, nil
+		}
+		types := []any{}
+		for _, name := range sortedKeys(ks.types) {
+			types = append(types, map[string]any{"type": table.TypeUDT, "udtName": name, "definition": ks.types[name]})
+		}
+		return response{Status: map[string]any{"types": types}}, nil
+	}
+	return response{}, unknownCommand(req)
+}
+
+// explainColumns returns columns as listTables explains them, which gives
+// scalar value types as type names rather than column objects.
+func explainColumns(columns map[string]table.Column) map[string]any {
+	explained := make(map[string]any, len(columns))
+	for name, col := range columns {
+		explained[name] = explainColumn(col)
+	}
+	return explained
+}
+
+// explainColumn returns col as listTables explains it.
+func explainColumn(col table.Column) map[string]any {
+	out := map[string]any{"type": col.Type}
+	if col.Dimension != nil {
+		out["dimension"] = *col.Dimension
+	}
+	if col.Service != nil {
+		out["service"] = col.Service
+	}
+	if col.KeyType != nil {
+		out["keyType"] = *col.KeyType
+	}
+	if col.UDTName != nil {
+		out["udtName"] = *col.UDTName
+	}
+	if v := col.ValueType; v != nil {
+		if v.Dimension == nil && v.Service == nil && v.ValueType == nil && v.KeyType == nil && v.UDTName == nil {
+			out["valueType"] = v.Type
+		} else {
+			out["valueType"] = explainColumn(*v)
+		}
+	}
+	return out
+}
+
+// usesType reports whether col or its value type is the user-defined type name.
+func usesType(col table.Column, name string) bool {
+	if col.UDTName != nil && *col.UDTName == name {
+		return true
+	}
+	return col.ValueType != nil && usesType(*col.ValueType, name)
+}
+
+// alterType runs the alterType command.
+func (s *Server) alterType(ks *keyspace, name string, operation json.RawMessage) (response, error) {
+	def, exists := ks.types[name]
+	if !exists {
+		return response{}, errorf("UNKNOWN_USER_DEFINED_TYPE", "type %q does not exist", name)
+	}
+	var op struct {
+		Add *struct {
+			Fields map[string]table.Column `json:"fields"`
+		} `json:"add"`
+		Rename *struct {
+			Fields map[string]string `json:"fields"`
+		} `json:"rename"`
+	}
+	if err := json.Unmarshal(operation, &op); err != nil {
+		return response{}, errorf("INVALID_REQUEST", "invalid alterType operation: %v", err)
+	}
+	fields := maps.Clone(def.Fields)
+	if op.Add != nil {
+		for field, col := range op.Add.Fields {
+			if _, exists := fields[field]; exists {
+				return response{}, errorf("CANNOT_ADD_EXISTING_FIELD", "field %q already exists in type %q", field, name)
+			}
+			fields[field] = col
+		}
+	}
+	if op.Rename != nil {
+		for from, to := range op.Rename.Fields {
+			col, exists := fields[from]
+			if !exists {
+				return response{}, errorf("UNKNOWN_TYPE_FIELD", "field %q does not exist in type %q", from, name)
+			}
+			delete(fields, from)
+			fields[to] = col
+		}
+	}
+	ks.types[name] = table.TypeDefinition{Fields: fields}
+	return response{Status: statusOK}, nil
+}
+
+// alterTable runs the alterTable command.
+func (s *Server) alterTable(t *tableData, req request) (response, error) {
+	var p struct {
+		Operation map[string]struct {
+			Columns json.RawMessage `json:"columns"`
+		} `json:"operation"`
+	}
+	if err := json.Unmarshal(req.payload, &p); err != nil || len(p.Operation) != 1 {
+		return response{}, errorf("INVALID_REQUEST", "alterTable needs exactly one operation")
+	}
+	columns := maps.Clone(t.definition.Columns)
+	pk := t.primaryKey()
+	for name, op := range p.Operation {
+		switch name {
+		case "add":
+			var add map[string]table.Column
+			if err := json.Unmarshal(op.Columns, &add); err != nil {
+				return response{}, errorf("INVALID_REQUEST", "invalid columns: %v", err)
+			}
+			for col, def := range add {
+				if _, exists := columns[col]; exists {
+					return response{}, errorf("CANNOT_ADD_EXISTING_COLUMNS", "column %q already exists", col)
+				}
+				columns[col] = def
+			}
+		case "drop":
+			var drop []string
+			if err := json.Unmarshal(op.Columns, &drop); err != nil {
+				return response{}, errorf("INVALID_REQUEST", "invalid columns: %v", err)
+			}
+			for _, col := range drop {
+				if slices.Contains(pk, col) {
+					return response{}, errorf("CANNOT_DROP_PRIMARY_KEY_COLUMNS", "column %q is part of the primary key", col)
+				}
+				if _, exists := columns[col]; !exists {
+					return response{}, errorf("CANNOT_DROP_UNKNOWN_COLUMNS", "column %q does not exist", col)
+				}
+				delete(columns, col)
+				for _, row := range t.rows {
+					delete(row, col)
+				}
+				t.indexes = slices.DeleteFunc(t.indexes, func(idx indexData) bool { return idx.column == col })
+			}
+		case "addVectorize":
+			var add map[string]table.VectorService
+			if err := json.Unmarshal(op.Columns, &add); err != nil {
+				return response{}, errorf("INVALID_REQUEST", "invalid columns: %v", err)
+			}
+			for col, service := range add {
+				def, exists := columns[col]
+				if !exists || def.Type != table.TypeVector {
+					return response{}, errorf("CANNOT_VECTORIZE_NON_VECTOR_COLUMNS", "column %q is not a vector column", col)
+				}
+				def.Service = &service
+				columns[col] = def
+			}
+		case "dropVectorize":
+			var drop []string
+			if err := json.Unmarshal(op.Columns, &drop); err != nil {
+				return response{}, errorf("INVALID_REQUEST", "invalid columns: %v", err)
+			}
+			for _, col := range drop {
+				def, exists := columns[col]
+				if !exists {
+					return response{}, errorf("CANNOT_DROP_VECTORIZE_FROM_UNKNOWN_COLUMNS", "column %q does not exist", col)
+				}
+				def.Service = nil
+				columns[col] = def
+			}
+		default:
+			return response{}, errorf("UNKNOWN_ALTER_TABLE_OPERATION", "unknown alterTable operation %q", name)
+		}
+	}
+	t.definition.Columns = columns
+	return response{Status: statusOK}, nil
+}
+
+// createIndex runs the createIndex, createVectorIndex and createTextIndex commands.
+func (s *Server) createIndex(t *tableData, req request) (response, error) {
+	var p struct {
+		Name       string `json:"name"`
+		Definition struct {
+			Column  any            `json:"column"`
+			Options map[string]any `json:"options"`
+		} `json:"definition"`
+		Options schemaOptions `json:"options"`
+	}
+	if err := req.decode(&p); err != nil {
+		return response{}, err
+	}
+	for _, idx := range t.indexes {
+		if idx.Name == p.Name {
+			if p.Options.IfNotExists {
+				return response{Status: statusOK}, nil
+			}
+			return response{}, errorf("CANNOT_ADD_EXISTING_INDEX", "index %q already exists", p.Name)
+		}
+	}
+
+	var column string
+	switch c := p.Definition.Column.(type) {
+	case string:
+		column = c
+	case map[string]any:
+		for name, target := range c {
+			if target != "$keys" && target != "$values" {
+				return response{}, errorf("INVALID_INDEX_DEFINITION", "invalid map index target %v", target)
+			}
+			column = name
+		}
+	}
+	def, exists := t.definition.Columns[column]
+	if !exists {
+		return response{}, errorf("CANNOT_INDEX_UNKNOWN_COLUMNS", "column %q does not exist", p.Definition.Column)
+	}
+
+	idx := indexData{Name: p.Name, column: column, IndexType: "regular"}
+	switch req.name {
+	case "createVectorIndex":
+		if def.Type != table.TypeVector {
+			return response{}, errorf("CANNOT_VECTOR_INDEX_NON_VECTOR_COLUMNS", "column %q is not a vector column", column)
+		}
+		idx.IndexType = "vector"
+		if m, ok := p.Definition.Options["metric"].(string); ok {
+			idx.metric = m
+		}
+	case "createTextIndex":
+		if def.Type != table.TypeText && def.Type != table.TypeAscii {
+			return response{}, errorf("CANNOT_TEXT_INDEX_NON_TEXT_COLUMNS", "column %q is not a text column", column)
+		}
+		idx.IndexType = "text"
+	}
+	definition := map[string]any{"column": p.Definition.Column, "options": p.Definition.Options}
+	if p.Definition.Options == nil {
+		definition["options"] = map[string]any{}
+	}
+	idx.Definition = definition
+	t.indexes = append(t.indexes, idx)
+	return response{Status: statusOK}, nil
+}
+
+// listIndexes runs the listIndexes command.
+func (s *Server) listIndexes(t *tableData, req request) (response, error) {
+	var p struct {
+		Options schemaOptions `json:"options"`
+	}
+	if err := req.decode(&p); err != nil {
+		return response{}, err
+	}
+	indexes := []any{}
+	for _, idx := range t.indexes {
+		if p.Options.Explain {
+			indexes = append(indexes, idx)
+		} else {
+			indexes = append(indexes, idx.Name)
+		}
+	}
+	return response{Status: map[string]any{"indexes": indexes}}, nil
+}
+
+// decodeJSON unmarshals b into v, keeping numbers exact. Empty input leaves
+// v unchanged.
+func decodeJSON(b json.RawMessage, v any) error {
+	if len(b) == 0 || string(b) == "null" {
+		return nil
+	}
+	return request{payload: b}.decode(v)
+}
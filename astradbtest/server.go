@@ -0,0 +1,287 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package astradbtest provides an in-memory fake of the Data API for tests
+// that exercise collections and tables without a live database.
+//
+// The fake is an [httptest.Server], so a client is pointed at it like any
+// other endpoint:
+//
+//	func TestBooks(t *testing.T) {
+//		fake := astradbtest.New(t)
+//		db := astradb.NewClient(options.WithToken("test")).Database(fake.URL)
+//		coll, err := db.CreateCollection(ctx, "books", nil)
+//		...
+//	}
+//
+// It implements the schema commands for keyspaces, collections, tables,
+// indexes and user-defined types, and the document commands insertOne,
+// insertMany, find, findOne, countDocuments, estimatedDocumentCount,
+// updateOne, updateMany, deleteOne, deleteMany, findOneAndUpdate and
+// findOneAndDelete, including the filter, update, sort and projection
+// operators and pagination via pageState. Vector sorts are supported,
+// while $vectorize and $lexical, which need a server-side model, are
+// rejected with an error.
+//
+// The fake is meant to be faithful enough for unit tests, not to replace
+// integration tests: it doesn't enforce index requirements on table
+// filters, and the state lives only as long as the server.
+//
+// To test against real responses instead, capture traffic from a live
+// database once with [Recorder] and replay it in CI with [Replayer].
+package astradbtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/datastax/astra-db-go/table"
+)
+
+// DefaultPageSize is the number of documents returned per page by find.
+const DefaultPageSize = 20
+
+// maxCount is the largest count returned by countDocuments before it sets
+// moreData, as in the Data API.
+const maxCount = 1000
+
+// Server is an in-memory fake of the Data API. Its zero value is not
+// usable; create one with [NewServer] or [New].
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	keyspaces map[string]*keyspace
+	pageSize  int
+}
+
+// Option configures a [Server].
+type Option func(*Server)
+
+// WithPageSize sets the number of documents returned per page by find.
+// Defaults to [DefaultPageSize].
+func WithPageSize(n int) Option {
+	return func(s *Server) {
+		s.pageSize = n
+	}
+}
+
+// WithKeyspaces creates the given keyspaces when the server starts.
+// Keyspaces are also created on first use, so this only matters for
+// tests that list keyspaces.
+func WithKeyspaces(names ...string) Option {
+	return func(s *Server) {
+		for _, name := range names {
+			s.keyspace(name)
+		}
+	}
+}
+
+// NewServer starts a new fake Data API server. The caller must call Close
+// when finished.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		keyspaces: make(map[string]*keyspace),
+		pageSize:  DefaultPageSize,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// New starts a new fake Data API server that is closed when tb finishes.
+func New(tb testing.TB, opts ...Option) *Server {
+	tb.Helper()
+	s := NewServer(opts...)
+	tb.Cleanup(s.Close)
+	return s
+}
+
+// keyspace holds the schema objects and data of one keyspace.
+type keyspace struct {
+	collections map[string]*collectionData
+	tables      map[string]*tableData
+	types       map[string]table.TypeDefinition
+}
+
+// keyspace returns the named keyspace, creating it if needed.
+func (s *Server) keyspace(name string) *keyspace {
+	ks, ok := s.keyspaces[name]
+	if !ok {
+		ks = &keyspace{
+			collections: make(map[string]*collectionData),
+			tables:      make(map[string]*tableData),
+			types:       make(map[string]table.TypeDefinition),
+		}
+		s.keyspaces[name] = ks
+	}
+	return ks
+}
+
+// apiError is an entry of the errors array of a response.
+type apiError struct {
+	ErrorCode string `json:"errorCode"`
+	Message   string `json:"message"`
+	Family    string `json:"family"`
+	Scope     string `json:"scope"`
+	Title     string `json:"title"`
+}
+
+// Error implements error.
+func (e *apiError) Error() string {
+	return e.ErrorCode + ": " + e.Message
+}
+
+// errorf returns an apiError for a problem with the request.
+func errorf(code, format string, args ...any) *apiError {
+	msg := fmt.Sprintf(format, args...)
+	return &apiError{
+		ErrorCode: code,
+		Message:   msg,
+		Family:    "REQUEST",
+		Scope:     "SCHEMA",
+		Title:     msg,
+	}
+}
+
+// response is the body of a Data API response.
+type response struct {
+	Data   any            `json:"data,omitempty"`
+	Status map[string]any `json:"status,omitempty"`
+	Errors []*apiError    `json:"errors,omitempty"`
+}
+
+// request is a decoded command with its target.
+type request struct {
+	keyspace string
+	resource string
+	name     string
+	payload  json.RawMessage
+}
+
+// decode unmarshals the command payload into v, keeping numbers exact.
+func (r request) decode(v any) error {
+	if len(r.payload) == 0 || string(r.payload) == "null" {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(r.payload))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return errorf("INVALID_REQUEST", "invalid %s payload: %v", r.name, err)
+	}
+	return nil
+}
+
+// ServeHTTP implements [http.Handler], routing commands posted to
+// /api/json/{version}[/{keyspace}[/{collection or table}]].
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 3 || len(parts) > 5 || parts[0] != "api" || parts[1] != "json" {
+		http.Error(w, `{"message":"not found"}`, http.StatusNotFound)
+		return
+	}
+	var req request
+	if len(parts) > 3 {
+		req.keyspace = parts[3]
+	}
+	if len(parts) > 4 {
+		req.resource = parts[4]
+	}
+
+	var body map[string]json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body) != 1 {
+		writeJSON(w, response{Errors: []*apiError{errorf("INVALID_REQUEST", "request must be a single command object")}})
+		return
+	}
+	for req.name, req.payload = range body {
+	}
+
+	s.mu.Lock()
+	resp, err := s.execute(req)
+	s.mu.Unlock()
+	if err != nil {
+		apiErr, ok := err.(*apiError)
+		if !ok {
+			apiErr = errorf("SERVER_UNHANDLED_ERROR", "%v", err)
+		}
+		resp.Errors = append(resp.Errors, apiErr)
+	}
+	writeJSON(w, resp)
+}
+
+// writeJSON writes resp as the JSON response body.
+func writeJSON(w http.ResponseWriter, resp response) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// execute runs a command against the server state.
+func (s *Server) execute(req request) (response, error) {
+	if req.keyspace == "" {
+		return s.executeDatabase(req)
+	}
+	ks := s.keyspace(req.keyspace)
+	if req.resource == "" {
+		return s.executeKeyspace(ks, req)
+	}
+	if c, ok := ks.collections[req.resource]; ok {
+		return s.executeCollection(c, req)
+	}
+	if t, ok := ks.tables[req.resource]; ok {
+		return s.executeTable(ks, t, req)
+	}
+	return response{}, errorf("COLLECTION_NOT_EXIST", "collection or table %q does not exist in keyspace %q", req.resource, req.keyspace)
+}
+
+// statusOK is the status of commands that only report success.
+var statusOK = map[string]any{"ok": 1}
+
+// executeDatabase runs the keyspace management commands.
+func (s *Server) executeDatabase(req request) (response, error) {
+	var p struct {
+		Name string `json:"name"`
+	}
+	if err := req.decode(&p); err != nil {
+		return response{}, err
+	}
+	switch req.name {
+	case "createKeyspace":
+		s.keyspace(p.Name)
+		return response{Status: statusOK}, nil
+	case "dropKeyspace":
+		delete(s.keyspaces, p.Name)
+		return response{Status: statusOK}, nil
+	case "findKeyspaces":
+		names := sortedKeys(s.keyspaces)
+		return response{Status: map[string]any{"keyspaces": names}}, nil
+	}
+	return response{}, unknownCommand(req)
+}
+
+// unknownCommand returns the error for commands the fake doesn't implement.
+func unknownCommand(req request) error {
+	return errorf("UNKNOWN_COMMAND", "astradbtest: unsupported command %q", req.name)
+}
@@ -0,0 +1,316 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package astradbtest_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	astradb "github.com/datastax/astra-db-go"
+	"github.com/datastax/astra-db-go/astradbtest"
+	"github.com/datastax/astra-db-go/filter"
+	"github.com/datastax/astra-db-go/options"
+	"github.com/datastax/astra-db-go/projection"
+	"github.com/datastax/astra-db-go/results"
+	"github.com/datastax/astra-db-go/sort"
+	"github.com/datastax/astra-db-go/table"
+)
+
+type book struct {
+	ID     string    `json:"_id"`
+	Title  string    `json:"title"`
+	Year   int       `json:"year"`
+	Tags   []string  `json:"tags,omitempty"`
+	Vector []float32 `json:"$vector,omitempty"`
+}
+
+func newBooks(t *testing.T, opts ...astradbtest.Option) (*astradbtest.Server, *astradb.Collection) {
+	t.Helper()
+	fake := astradbtest.New(t, opts...)
+	db := astradb.NewClient(options.WithToken("test")).Database(fake.URL)
+	coll, err := db.CreateCollection(context.Background(), "books", &options.CollectionOptions{
+		Vector: &options.VectorOptions{Dimension: 2, Metric: "cosine"},
+	})
+	if err != nil {
+		t.Fatalf("CreateCollection failed: %v", err)
+	}
+	var docs []book
+	for i := range 25 {
+		docs = append(docs, book{
+			ID:     fmt.Sprintf("b%02d", i),
+			Title:  fmt.Sprintf("Book %d", i),
+			Year:   1990 + i,
+			Tags:   []string{[]string{"fiction", "history", "science"}[i%3]},
+			Vector: []float32{float32(i), float32(25 - i)},
+		})
+	}
+	if _, err := coll.InsertMany(context.Background(), docs); err != nil {
+		t.Fatalf("InsertMany failed: %v", err)
+	}
+	return fake, coll
+}
+
+func TestCollectionFindAndPaginate(t *testing.T) {
+	_, coll := newBooks(t, astradbtest.WithPageSize(10))
+	ctx := context.Background()
+
+	var all []book
+	if err := coll.Find(ctx, filter.F{}).All(ctx, &all); err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if len(all) != 25 {
+		t.Fatalf("expected 25 documents across pages, got %d", len(all))
+	}
+	if all[0].Vector != nil {
+		t.Error("expected $vector to be excluded by default")
+	}
+
+	var sorted []book
+	err := coll.Find(ctx, filter.F{"year": filter.F{"$gte": 2000}},
+		options.WithCollectionSort(sort.Desc("year")),
+		options.WithCollectionSkip(2),
+		options.WithCollectionLimit(3),
+		options.WithCollectionProjection(projection.Include("year")),
+	).All(ctx, &sorted)
+	if err != nil {
+		t.Fatalf("sorted Find failed: %v", err)
+	}
+	if len(sorted) != 3 || sorted[0].Year != 2012 || sorted[2].Year != 2010 {
+		t.Errorf("unexpected sorted page %+v", sorted)
+	}
+	if sorted[0].ID == "" || sorted[0].Title != "" {
+		t.Errorf("expected projection of _id and year only, got %+v", sorted[0])
+	}
+
+	var nearest []map[string]any
+	err = coll.Find(ctx, filter.F{},
+		options.WithCollectionSort(sort.Vector(sort.FieldVector, []float32{1, 0})),
+		options.WithCollectionLimit(2),
+		options.WithCollectionIncludeSimilarity(true),
+	).All(ctx, &nearest)
+	if err != nil {
+		t.Fatalf("vector Find failed: %v", err)
+	}
+	if len(nearest) != 2 || nearest[0]["_id"] != "b24" || nearest[0]["$similarity"] == nil {
+		t.Errorf("unexpected vector search results %v", nearest)
+	}
+
+	var one book
+	if err := coll.FindOne(ctx, filter.F{"_id": "b07"}).Decode(&one); err != nil || one.Year != 1997 {
+		t.Errorf("FindOne returned %+v, %v", one, err)
+	}
+	if err := coll.FindOne(ctx, filter.F{"_id": "missing"}).Err(); !errors.Is(err, results.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestCollectionFilterOperators(t *testing.T) {
+	_, coll := newBooks(t)
+	tests := []struct {
+		name   string
+		filter filter.F
+		want   int
+	}{
+		{"empty", filter.F{}, 25},
+		{"equality", filter.F{"year": 1995}, 1},
+		{"array contains", filter.F{"tags": "history"}, 8},
+		{"ne", filter.F{"tags": filter.F{"$ne": "history"}}, 17},
+		{"range", filter.F{"year": filter.F{"$gt": 2000, "$lte": 2005}}, 5},
+		{"in", filter.F{"_id": filter.F{"$in": []string{"b01", "b02", "nope"}}}, 2},
+		{"nin", filter.F{"_id": filter.F{"$nin": []string{"b01", "b02"}}}, 23},
+		{"exists", filter.F{"missing": filter.F{"$exists": false}}, 25},
+		{"all", filter.F{"tags": filter.F{"$all": []string{"fiction"}}}, 9},
+		{"size", filter.F{"tags": filter.F{"$size": 1}}, 25},
+		{"or", filter.F{"$or": []filter.F{{"year": 1990}, {"year": 1991}}}, 2},
+		{"and", filter.F{"$and": []filter.F{{"tags": "science"}, {"year": filter.F{"$lt": 2000}}}}, 3},
+		{"not", filter.F{"$not": filter.F{"tags": "science"}}, 17},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := coll.CountDocuments(context.Background(), tt.filter, 1000)
+			if err != nil {
+				t.Fatalf("CountDocuments failed: %v", err)
+			}
+			if n != tt.want {
+				t.Errorf("expected %d documents, got %d", tt.want, n)
+			}
+		})
+	}
+}
+
+func TestCollectionErrors(t *testing.T) {
+	_, coll := newBooks(t)
+	ctx := context.Background()
+
+	_, err := coll.InsertOne(ctx, book{ID: "b01"})
+	var apiErr *astradb.DataAPIError
+	if !errors.As(err, &apiErr) || apiErr.ErrorCode != "DOCUMENT_ALREADY_EXISTS" {
+		t.Errorf("expected DOCUMENT_ALREADY_EXISTS, got %v", err)
+	}
+
+	err = coll.Find(ctx, filter.F{}, options.WithCollectionSort(sort.Vectorize(sort.FieldVectorize, "dune"))).All(ctx, &[]book{})
+	if err == nil || !strings.Contains(err.Error(), "not supported") {
+		t.Errorf("expected $vectorize to be rejected, got %v", err)
+	}
+
+	_, err = coll.Database().Collection("unknown").InsertOne(ctx, book{})
+	if !errors.As(err, &apiErr) || apiErr.ErrorCode != "COLLECTION_NOT_EXIST" {
+		t.Errorf("expected COLLECTION_NOT_EXIST, got %v", err)
+	}
+}
+
+// post sends a raw command to the fake and returns the decoded response.
+func post(t *testing.T, url string, command string) map[string]any {
+	t.Helper()
+	resp, err := http.Post(url, "application/json", strings.NewReader(command))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var body map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if errs, ok := body["errors"]; ok {
+		t.Fatalf("command %s failed: %v", command, errs)
+	}
+	return body
+}
+
+func TestCollectionUpdateAndDelete(t *testing.T) {
+	fake, coll := newBooks(t)
+	ctx := context.Background()
+	url := fake.URL + "/api/json/v1/default_keyspace/books"
+
+	body := post(t, url, `{"updateMany":{"filter":{"tags":"science"},"update":{"$inc":{"year":100},"$push":{"tags":"updated"}}}}`)
+	if status := body["status"].(map[string]any); status["matchedCount"] != 8.0 || status["modifiedCount"] != 8.0 {
+		t.Errorf("unexpected updateMany status %v", status)
+	}
+	var updated book
+	if err := coll.FindOne(ctx, filter.F{"_id": "b02"}).Decode(&updated); err != nil || updated.Year != 2092 || len(updated.Tags) != 2 {
+		t.Errorf("unexpected updated document %+v, %v", updated, err)
+	}
+
+	body = post(t, url, `{"findOneAndUpdate":{"filter":{"_id":"new"},"update":{"$set":{"title":"New"},"$setOnInsert":{"year":2024}},"options":{"upsert":true,"returnDocument":"after"}}}`)
+	if doc := body["data"].(map[string]any)["document"].(map[string]any); doc["_id"] != "new" || doc["year"] != 2024.0 {
+		t.Errorf("unexpected upserted document %v", doc)
+	}
+
+	body = post(t, url, `{"deleteMany":{"filter":{"year":{"$lt":2000}}}}`)
+	if status := body["status"].(map[string]any); status["deletedCount"] != 7.0 {
+		t.Errorf("unexpected deleteMany status %v", status)
+	}
+	body = post(t, url, `{"findOneAndDelete":{"filter":{"_id":"new"},"projection":{"title":1}}}`)
+	if doc := body["data"].(map[string]any)["document"].(map[string]any); doc["title"] != "New" || doc["year"] != nil {
+		t.Errorf("unexpected deleted document %v", doc)
+	}
+	if n, err := coll.CountDocuments(ctx, filter.F{}, 1000); err != nil || n != 18 {
+		t.Errorf("expected 18 documents left, got %d, %v", n, err)
+	}
+}
+
+type review struct {
+	BookID string  `json:"book_id"`
+	User   string  `json:"user"`
+	Rating float32 `json:"rating"`
+	Body   string  `json:"body,omitempty"`
+}
+
+func TestTable(t *testing.T) {
+	fake := astradbtest.New(t)
+	db := astradb.NewClient(options.WithToken("test")).Database(fake.URL)
+	ctx := context.Background()
+
+	def := table.NewDefinition().
+		AddTextColumn("book_id").
+		AddTextColumn("user").
+		AddFloatColumn("rating").
+		SetPartitionBy("book_id").
+		AddClusteringColumnAsc("user").
+		Build()
+	tbl, err := db.CreateTable(ctx, "reviews", def)
+	if err != nil {
+		t.Fatalf("CreateTable failed: %v", err)
+	}
+	resp, err := tbl.InsertMany(ctx, []review{
+		{BookID: "b1", User: "ann", Rating: 4},
+		{BookID: "b1", User: "bob", Rating: 2},
+		{BookID: "b2", User: "ann", Rating: 5},
+	})
+	if err != nil {
+		t.Fatalf("InsertMany failed: %v", err)
+	}
+	if len(resp.Status.InsertedIds) != 3 || (*resp.Status.PrimaryKeySchema)["book_id"].Type != "text" {
+		t.Errorf("unexpected insert response %+v", resp.Status)
+	}
+
+	// Inserting an existing primary key overwrites the row
+	if _, err := tbl.InsertOne(ctx, review{BookID: "b1", User: "bob", Rating: 3}); err != nil {
+		t.Fatalf("InsertOne failed: %v", err)
+	}
+	var rows []review
+	err = tbl.Find(ctx, filter.F{"book_id": "b1"}, options.WithSort(sort.Desc("rating"))).All(ctx, &rows)
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if len(rows) != 2 || rows[0].User != "ann" || rows[1].Rating != 3 {
+		t.Errorf("unexpected rows %+v", rows)
+	}
+
+	if _, err := tbl.InsertOne(ctx, map[string]any{"book_id": "b3", "user": "cy", "stars": 1}); err == nil {
+		t.Error("expected unknown column to be rejected")
+	}
+
+	if _, err := tbl.Alter(ctx, table.AlterAdd{Columns: map[string]table.Column{
+		"body": table.Text(),
+		"tags": table.Set(table.Text()),
+	}}); err != nil {
+		t.Fatalf("Alter failed: %v", err)
+	}
+	if _, err := tbl.CreateIndex(ctx, "reviews_rating_idx", "rating"); err != nil {
+		t.Fatalf("CreateIndex failed: %v", err)
+	}
+	if _, err := tbl.CreateTextIndex(ctx, "reviews_body_idx", "body"); err != nil {
+		t.Fatalf("CreateTextIndex failed: %v", err)
+	}
+	indexes, err := tbl.ListIndexes(ctx, options.ListIndexes().SetExplain(true))
+	if err != nil || len(indexes) != 2 || indexes[1].IndexType != astradb.IndexTypeText {
+		t.Errorf("unexpected indexes %+v, %v", indexes, err)
+	}
+	tables, err := db.ListTables(ctx)
+	if err != nil || len(tables) != 1 || tables[0].Definition.Columns["body"].Type != table.TypeText {
+		t.Fatalf("unexpected tables %+v, %v", tables, err)
+	}
+	if tags := tables[0].Definition.Columns["tags"]; tags.ValueType == nil || tags.ValueType.Type != table.TypeText {
+		t.Errorf("unexpected tags column %+v", tags)
+	}
+
+	url := fake.URL + "/api/json/v1/default_keyspace/reviews"
+	post(t, url, `{"updateOne":{"filter":{"book_id":"b2","user":"ann"},"update":{"$set":{"body":"Great"}}}}`)
+	var r review
+	if err := tbl.FindOne(ctx, filter.F{"book_id": "b2"}).Decode(&r); err != nil || r.Body != "Great" {
+		t.Errorf("unexpected updated row %+v, %v", r, err)
+	}
+	post(t, url, `{"deleteMany":{"filter":{"book_id":"b1"}}}`)
+	var left []review
+	if err := tbl.Find(ctx, filter.F{}).All(ctx, &left); err != nil || len(left) != 1 {
+		t.Errorf("expected one row left, got %+v, %v", left, err)
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package astradbtest
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// applyUpdate applies the update operators in update to doc and reports
+// whether the document changed. $setOnInsert is only applied when insert
+// is true.
+func applyUpdate(doc map[string]any, update map[string]any, insert bool) (bool, error) {
+	before := deepCopy(doc)
+	for _, op := range sortedKeys(update) {
+		fields, ok := update[op].(map[string]any)
+		if !ok {
+			return false, errorf("UNSUPPORTED_UPDATE_DATA_TYPE", "%s needs an object", op)
+		}
+		for _, path := range sortedKeys(fields) {
+			if path == "_id" && op != "$setOnInsert" {
+				return false, errorf("UPDATE_FORBIDDEN_FOR_DOC_ID", "cannot update _id")
+			}
+			if err := applyOperator(doc, op, path, fields[path], insert); err != nil {
+				return false, err
+			}
+		}
+	}
+	return !equal(before, doc), nil
+}
+
+// applyOperator applies a single update operator to the field at path.
+func applyOperator(doc map[string]any, op, path string, arg any, insert bool) error {
+	current, exists := lookup(doc, path)
+	switch op {
+	case "$set":
+		return setPath(doc, path, arg)
+	case "$setOnInsert":
+		if !insert {
+			return nil
+		}
+		return setPath(doc, path, arg)
+	case "$unset":
+		unsetPath(doc, path)
+		return nil
+	case "$inc", "$mul":
+		n, ok := number(arg)
+		if !ok {
+			return errorf("UNSUPPORTED_UPDATE_OPERATION_PARAM", "%s needs a number for %q", op, path)
+		}
+		if !exists {
+			if op == "$mul" {
+				n = 0
+			}
+			return setPath(doc, path, jsonNumber(n))
+		}
+		cur, ok := number(current)
+		if !ok {
+			return errorf("UNSUPPORTED_UPDATE_OPERATION_TARGET", "%s needs a numeric field at %q", op, path)
+		}
+		if op == "$inc" {
+			return setPath(doc, path, jsonNumber(cur+n))
+		}
+		return setPath(doc, path, jsonNumber(cur*n))
+	case "$min", "$max":
+		if exists {
+			c := compare(arg, current)
+			if (op == "$min" && c >= 0) || (op == "$max" && c <= 0) {
+				return nil
+			}
+		}
+		return setPath(doc, path, arg)
+	case "$rename":
+		to, ok := arg.(string)
+		if !ok {
+			return errorf("UNSUPPORTED_UPDATE_OPERATION_PARAM", "$rename needs a field name for %q", path)
+		}
+		if !exists {
+			return nil
+		}
+		unsetPath(doc, path)
+		return setPath(doc, to, current)
+	case "$push", "$addToSet":
+		arr, ok := current.([]any)
+		if exists && !ok {
+			return errorf("UNSUPPORTED_UPDATE_OPERATION_TARGET", "%s needs an array field at %q", op, path)
+		}
+		values := []any{arg}
+		if m, ok := arg.(map[string]any); ok {
+			if each, ok := m["$each"].([]any); ok {
+				values = each
+			}
+		}
+		for _, v := range values {
+			if op == "$addToSet" && matchEq(arr, v) {
+				continue
+			}
+			arr = append(arr, v)
+		}
+		if arr == nil {
+			arr = []any{}
+		}
+		return setPath(doc, path, arr)
+	case "$pop":
+		n, ok := number(arg)
+		if !ok {
+			return errorf("UNSUPPORTED_UPDATE_OPERATION_PARAM", "$pop needs a number for %q", path)
+		}
+		arr, ok := current.([]any)
+		if !exists || len(arr) == 0 {
+			return nil
+		}
+		if !ok {
+			return errorf("UNSUPPORTED_UPDATE_OPERATION_TARGET", "$pop needs an array field at %q", path)
+		}
+		if n < 0 {
+			arr = arr[1:]
+		} else {
+			arr = arr[:len(arr)-1]
+		}
+		return setPath(doc, path, arr)
+	case "$currentDate":
+		return setPath(doc, path, map[string]any{"$date": json.Number(strconv.FormatInt(time.Now().UnixMilli(), 10))})
+	}
+	return errorf("UNSUPPORTED_UPDATE_OPERATION", "unsupported update operator %q", op)
+}
+
+// jsonNumber returns f as a json.Number, without a fraction when f is whole.
+func jsonNumber(f float64) json.Number {
+	return json.Number(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+// deepCopy returns a copy of a JSON value that shares no maps or slices
+// with v.
+func deepCopy[T any](v T) T {
+	return any(copyValue(v)).(T)
+}
+
+func copyValue(v any) any {
+	switch x := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(x))
+		for k, e := range x {
+			m[k] = copyValue(e)
+		}
+		return m
+	case []any:
+		s := make([]any, len(x))
+		for i, e := range x {
+			s[i] = copyValue(e)
+		}
+		return s
+	}
+	return v
+}
+
+// upsertDocument builds the document inserted by an upsert: the equality
+// conditions of filter, then the update applied with $setOnInsert.
+func upsertDocument(filter, update map[string]any) (map[string]any, error) {
+	doc := map[string]any{}
+	for path, cond := range filter {
+		if len(path) > 0 && path[0] == '$' {
+			continue
+		}
+		if ops, ok := cond.(map[string]any); ok && isOperatorObject(ops) {
+			if _, _, isLiteral := literal(cond); !isLiteral {
+				if eq, ok := ops["$eq"]; ok {
+					cond = eq
+				} else {
+					continue
+				}
+			}
+		}
+		if err := setPath(doc, path, deepCopy(cond)); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := applyUpdate(doc, update, true); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
@@ -14,28 +14,108 @@
 
 package astradb
 
-import "github.com/datastax/astra-db-go/options"
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/datastax/astra-db-go/options"
+)
+
+// Settings of the transport shared by the commands of a client.
+const (
+	maxIdleConns        = 100
+	keepAlive           = 30 * time.Second
+	idleConnTimeout     = 90 * time.Second
+	http2PingAfter      = 30 * time.Second
+	http2PingTimeout    = 15 * time.Second
+	expectContinueDelay = time.Second
+)
 
 // DataAPIClient is a client for interacting with an Astra DB database.
 // Construct a new client using [NewClient].
 //
 // Options set on the client are inherited by all databases, collections,
 // tables, and commands created from it, unless overridden at a lower level.
+//
+// The client owns a connection pool shared by everything created from it,
+// so create one client and reuse it. Call [DataAPIClient.Close] to release
+// idle connections when done.
 type DataAPIClient struct {
-	options *options.APIOptions
+	options    *options.APIOptions
+	transport  *http.Transport
+	httpClient *http.Client
 }
 
 // NewClient returns a new DataAPIClient with the given options.
 //
+// Unless an HTTP client is set with [options.WithHTTPClient], requests use
+// a transport tuned for the Data API: HTTP/2 with keep-alive pings, a pool
+// of up to 100 idle connections to the database, and dial and TLS
+// handshake timeouts set by [options.WithConnectionTimeout] (10s by default).
+//
 // Example:
 //
 //	client := astradb.NewClient(
 //	    options.WithToken("AstraCS:..."),
 //	)
 func NewClient(opts ...options.APIOption) *DataAPIClient {
-	return &DataAPIClient{
+	c := &DataAPIClient{
 		options: options.NewAPIOptions(opts...),
 	}
+	c.transport = newTransport(c.options.GetConnectionTimeout())
+	c.httpClient = &http.Client{Transport: c.transport}
+	return c
+}
+
+// newTransport returns the transport shared by the requests of a client.
+func newTransport(connectTimeout time.Duration) *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   connectTimeout,
+		KeepAlive: keepAlive,
+	}
+	return &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          maxIdleConns,
+		MaxIdleConnsPerHost:   maxIdleConns,
+		IdleConnTimeout:       idleConnTimeout,
+		TLSHandshakeTimeout:   connectTimeout,
+		ExpectContinueTimeout: expectContinueDelay,
+		TLSClientConfig:       &tls.Config{MinVersion: tls.VersionTLS12},
+		HTTP2: &http.HTTP2Config{
+			SendPingTimeout: http2PingAfter,
+			PingTimeout:     http2PingTimeout,
+		},
+	}
+}
+
+// Transport returns the transport shared by the client's requests, for
+// inspection. It is not used by requests that have an HTTP client set with
+// [options.WithHTTPClient].
+func (c *DataAPIClient) Transport() *http.Transport {
+	return c.transport
+}
+
+// Close closes the client's idle connections. Connections in use are
+// closed once their requests complete. The client remains usable, and
+// later requests open new connections.
+func (c *DataAPIClient) Close() error {
+	if c.transport != nil {
+		c.transport.CloseIdleConnections()
+	}
+	return nil
+}
+
+// httpClientFor returns the HTTP client for requests with the resolved
+// options opts: the one set in opts, otherwise the client's shared one.
+func (c *DataAPIClient) httpClientFor(opts *options.APIOptions) *http.Client {
+	if opts.HTTPClient == nil && c != nil && c.httpClient != nil {
+		return c.httpClient
+	}
+	return opts.GetHTTPClient()
 }
 
 // Options returns the client's options (or an empty options if nil).
@@ -56,9 +136,10 @@ func (c *DataAPIClient) Options() *options.APIOptions {
 //	    options.WithKeyspace("my_keyspace"),
 //	)
 func (c *DataAPIClient) Database(endpoint string, opts ...options.APIOption) *Db {
-	return &Db{
+	d := &Db{
 		endpoint: endpoint,
 		client:   c,
-		options:  options.NewAPIOptions(opts...),
 	}
+	d.options.Store(options.NewAPIOptions(opts...))
+	return d
 }
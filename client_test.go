@@ -0,0 +1,102 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package astradb
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/datastax/astra-db-go/options"
+)
+
+func TestClientTransport(t *testing.T) {
+	client := NewClient(options.WithConnectionTimeout(3 * time.Second))
+	tr := client.Transport()
+	if tr == nil {
+		t.Fatal("expected client to own a transport")
+	}
+	if !tr.ForceAttemptHTTP2 {
+		t.Error("expected HTTP/2 to be attempted")
+	}
+	if tr.MaxIdleConnsPerHost != maxIdleConns {
+		t.Errorf("expected %d idle connections per host, got %d", maxIdleConns, tr.MaxIdleConnsPerHost)
+	}
+	if tr.TLSHandshakeTimeout != 3*time.Second {
+		t.Errorf("expected TLS handshake timeout from the connection timeout, got %v", tr.TLSHandshakeTimeout)
+	}
+
+	// Default timeout
+	if tr := NewClient().Transport(); tr.TLSHandshakeTimeout != 10*time.Second {
+		t.Errorf("expected default TLS handshake timeout 10s, got %v", tr.TLSHandshakeTimeout)
+	}
+}
+
+func TestClientConnectionReuse(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		states = make(map[http.ConnState]int)
+	)
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":{"ok":1}}`))
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		mu.Lock()
+		defer mu.Unlock()
+		states[state]++
+	}
+	srv.Start()
+	defer srv.Close()
+	count := func(state http.ConnState) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return states[state]
+	}
+
+	client := NewClient()
+	for _, db := range []*Db{client.Database(srv.URL), client.Database(srv.URL, options.WithKeyspace("other"))} {
+		for i := 0; i < 3; i++ {
+			cmd := newCmd(db, "findCollections", struct{}{})
+			if _, _, err := cmd.Execute(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if n := count(http.StateNew); n != 1 {
+		t.Errorf("expected all commands to share one connection, got %d", n)
+	}
+
+	if err := client.Close(); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for count(http.StateClosed) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if count(http.StateClosed) != 1 {
+		t.Error("expected Close to close the idle connection")
+	}
+
+	// A custom HTTP client bypasses the shared transport
+	custom := &http.Client{Transport: &http.Transport{}}
+	cmd := newCmd(client.Database(srv.URL, options.WithHTTPClient(custom)), "findCollections", struct{}{})
+	if got := client.httpClientFor(cmd.resolveOptions()); got != custom {
+		t.Error("expected the custom HTTP client to be used")
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package codec defines how command payloads are encoded and documents
+// decoded, so that a JSON library other than encoding/json can be plugged
+// in with options.WithCodec.
+//
+// A Codec only handles values that belong to the caller: the payloads of
+// commands, and the documents and rows decoded by results.SingleResult
+// and cursor.Cursor. The client's own parts of requests and responses,
+// such as errors and warnings, are always handled by encoding/json.
+//
+// To convert types that can't implement [json.Marshaler] and
+// [json.Unmarshaler], register converters with a library that supports
+// them and adapt it to Codec. For example, with encoding/json/v2:
+//
+//	type v2Codec struct{ opts jsonv2.Options }
+//
+//	func (c v2Codec) Encode(w io.Writer, v any) error { return jsonv2.MarshalWrite(w, v, c.opts) }
+//	func (c v2Codec) Decode(data []byte, v any) error { return jsonv2.Unmarshal(data, v, c.opts) }
+//
+//	codec := v2Codec{opts: jsonv2.JoinOptions(
+//		jsonv2.WithMarshalers(jsonv2.MarshalFunc(func(d decimal.Decimal) ([]byte, error) {
+//			return []byte(d.String()), nil
+//		})),
+//		jsonv2.WithUnmarshalers(jsonv2.UnmarshalFunc(func(b []byte, d *decimal.Decimal) error {
+//			return d.UnmarshalText(b)
+//		})),
+//	)}
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// Codec encodes command payloads to JSON and decodes documents from JSON.
+// Implementations must be safe for concurrent use.
+type Codec interface {
+	// Encode writes the JSON encoding of v to w. A trailing newline, as
+	// written by json.Encoder, is allowed.
+	Encode(w io.Writer, v any) error
+
+	// Decode parses the JSON document data and stores the result in v,
+	// which is a non-nil pointer. data must not be retained.
+	Decode(data []byte, v any) error
+}
+
+// ErrTrailingData is returned by [JSON.Decode] when data holds more than
+// one JSON value.
+var ErrTrailingData = errors.New("codec: invalid data after top-level value")
+
+// JSON is the default Codec, based on encoding/json. The zero value
+// behaves like json.Marshal and json.Unmarshal.
+type JSON struct {
+	// UseNumber decodes numbers into interface values, such as the fields
+	// of a map[string]any, as [json.Number] instead of float64, so that
+	// integers beyond 2^53 keep their precision.
+	UseNumber bool
+
+	// DisallowUnknownFields makes decoding into a struct fail when the
+	// document has a field that doesn't match a struct field.
+	DisallowUnknownFields bool
+}
+
+// Encode implements [Codec].
+func (c JSON) Encode(w io.Writer, v any) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+// Decode implements [Codec].
+func (c JSON) Decode(data []byte, v any) error {
+	if !c.UseNumber && !c.DisallowUnknownFields {
+		return json.Unmarshal(data, v)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if c.UseNumber {
+		dec.UseNumber()
+	}
+	if c.DisallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return ErrTrailingData
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codec_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/datastax/astra-db-go/codec"
+)
+
+func TestJSON_Encode(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (codec.JSON{}).Encode(&buf, map[string]any{"name": "<Bo>"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != "{\"name\":\"\\u003cBo\\u003e\"}\n" {
+		t.Errorf("expected json.Encoder output, got %q", got)
+	}
+}
+
+func TestJSON_Decode(t *testing.T) {
+	doc := []byte(`{"id":9007199254740993,"name":"Bo"}`)
+
+	var m map[string]any
+	if err := (codec.JSON{}).Decode(doc, &m); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := m["id"].(float64); !ok {
+		t.Errorf("expected float64 by default, got %T", m["id"])
+	}
+	if err := (codec.JSON{UseNumber: true}).Decode(doc, &m); err != nil {
+		t.Fatal(err)
+	}
+	if n, ok := m["id"].(json.Number); !ok || n.String() != "9007199254740993" {
+		t.Errorf("expected exact json.Number, got %T %v", m["id"], m["id"])
+	}
+
+	var named struct {
+		Name string `json:"name"`
+	}
+	if err := (codec.JSON{}).Decode(doc, &named); err != nil || named.Name != "Bo" {
+		t.Errorf("expected unknown fields to be ignored, got %+v, %v", named, err)
+	}
+	if err := (codec.JSON{DisallowUnknownFields: true}).Decode(doc, &named); err == nil {
+		t.Error("expected unknown field id to be rejected")
+	}
+
+	strict := codec.JSON{DisallowUnknownFields: true}
+	if err := strict.Decode([]byte(`{"name":"Bo"} {}`), &named); !errors.Is(err, codec.ErrTrailingData) {
+		t.Errorf("expected ErrTrailingData, got %v", err)
+	}
+	if err := strict.Decode([]byte(`{"name":"Bo"}`+"\n"), &named); err != nil {
+		t.Errorf("expected trailing whitespace to be allowed, got %v", err)
+	}
+}
@@ -30,9 +30,11 @@ import (
 // Options set on the collection are inherited by all commands
 // executed on it, unless overridden at the command level.
 type Collection struct {
-	db      *Db
-	name    string
-	options *options.APIOptions
+	db       *Db
+	name     string
+	options  *options.APIOptions
+	warnings results.Warnings
+	cache    optionsCache // Merged client, database and collection options
 }
 
 // Name returns the collection name.
@@ -40,7 +42,9 @@ func (c *Collection) Name() string {
 	return c.name
 }
 
-// Options returns the collection's options (or empty options if nil).
+// Options returns the collection's options (or empty options if nil). They
+// must not be modified: they are merged with the database's options and
+// cached when the first command runs.
 func (c *Collection) Options() *options.APIOptions {
 	if c.options == nil {
 		return &options.APIOptions{}
@@ -53,8 +57,16 @@ func (c *Collection) Database() *Db {
 	return c.db
 }
 
+// Warnings returns any warnings from the createCollection command if this
+// handle was returned by [Db.CreateCollection]. Returns nil otherwise.
+func (c *Collection) Warnings() results.Warnings {
+	return c.warnings
+}
+
 func (c *Collection) newCmd(name string, payload any, opts ...options.APIOption) command {
-	return newCmdWithOptions(c.db, c.name, name, payload, c.options, opts...)
+	cmd := newCmdWithOptions(c.db, c.name, name, payload, c.options, opts...)
+	cmd.cache = &c.cache
+	return cmd
 }
 
 // insertManyPayload is the payload for insertMany commands.
@@ -67,25 +79,32 @@ type insertOnePayload struct {
 	Document any `json:"document"`
 }
 
-// documentsInsertResponse is the response from insert operations.
-type documentsInsertResponse struct {
-	Status status `json:"status"`
+// CollectionInsertResponse is the response from insert operations on collections.
+type CollectionInsertResponse struct {
+	Status struct {
+		// InsertedIds contains the _id values of inserted documents.
+		InsertedIds []any `json:"insertedIds"`
+	} `json:"status"`
+
+	warnings results.Warnings
 }
 
-type status struct {
-	InsertedIds []any `json:"insertedIds"`
+// Warnings returns any warnings from the API response.
+// Returns nil if there were no warnings.
+func (r CollectionInsertResponse) Warnings() results.Warnings {
+	return r.warnings
 }
 
 // InsertOne inserts a single document into the collection.
 //
 // Options passed here override those set on the collection.
-// Note: Warnings are accessible via the WarningHandler option callback only.
-func (c *Collection) InsertOne(ctx context.Context, payload any, opts ...options.APIOption) (documentsInsertResponse, error) {
-	var resp documentsInsertResponse
+func (c *Collection) InsertOne(ctx context.Context, payload any, opts ...options.APIOption) (CollectionInsertResponse, error) {
+	var resp CollectionInsertResponse
 	cmd := c.newCmd("insertOne", insertOnePayload{
 		Document: payload,
 	}, opts...)
-	b, _, err := cmd.Execute(ctx)
+	b, warnings, err := cmd.Execute(ctx)
+	resp.warnings = warnings
 	if err != nil {
 		return resp, err
 	}
@@ -96,9 +115,8 @@ func (c *Collection) InsertOne(ctx context.Context, payload any, opts ...options
 // InsertMany inserts documents into the collection. Param documents must be a non-empty slice.
 //
 // Options passed here override those set on the collection.
-// Note: Warnings are accessible via the WarningHandler option callback only.
-func (c *Collection) InsertMany(ctx context.Context, documents any, opts ...options.APIOption) (documentsInsertResponse, error) {
-	var resp documentsInsertResponse
+func (c *Collection) InsertMany(ctx context.Context, documents any, opts ...options.APIOption) (CollectionInsertResponse, error) {
+	var resp CollectionInsertResponse
 
 	// Ensure we have a slice with documents
 	err := ensureNonEmptySlice(documents)
@@ -108,7 +126,8 @@ func (c *Collection) InsertMany(ctx context.Context, documents any, opts ...opti
 	cmd := c.newCmd("insertMany", insertManyPayload{
 		Documents: documents,
 	}, opts...)
-	b, _, err := cmd.Execute(ctx)
+	b, warnings, err := cmd.Execute(ctx)
+	resp.warnings = warnings
 	if err != nil {
 		return resp, err
 	}
@@ -118,6 +137,9 @@ func (c *Collection) InsertMany(ctx context.Context, documents any, opts ...opti
 
 // FindOne finds a single document matching the filter.
 //
+// If no document matches, the result's Decode and Err methods return
+// [ErrNotFound].
+//
 // Options passed here override those set on the collection.
 func (c *Collection) FindOne(ctx context.Context, f any, opts ...options.APIOption) *results.SingleResult {
 	switch f.(type) {
@@ -127,15 +149,16 @@ func (c *Collection) FindOne(ctx context.Context, f any, opts ...options.APIOpti
 		return results.NewSingleResult(nil, nil, fmt.Errorf("invalid filter type: %T", f))
 	}
 	cmd := c.newCmd("findOne", filterWrapper{Filters: f}, opts...)
-	b, warnings, err := cmd.Execute(ctx)
-	return results.NewSingleResult(b, warnings, err)
+	cmdOpts := cmd.resolveOptions()
+	b, warnings, err := cmd.execute(ctx, cmdOpts)
+	return results.NewSingleResultWithCodec(b, warnings, err, cmdOpts.GetCodec())
 }
 
 // collectionFindPayload is the payload for the find command on collections
 type collectionFindPayload struct {
 	Filter     any                    `json:"filter,omitempty"`
-	Sort       map[string]any         `json:"sort,omitempty"`
-	Projection map[string]any         `json:"projection,omitempty"`
+	Sort       any                    `json:"sort,omitempty"`       // map[string]any or *sort.Sort
+	Projection any                    `json:"projection,omitempty"` // map[string]any or *projection.Projection
 	Options    *collectionFindOptions `json:"options,omitempty"`
 }
 
@@ -148,14 +171,6 @@ type collectionFindOptions struct {
 	PageState         *string `json:"pageState,omitempty"`
 }
 
-// collectionFindResponse is the response from the find command
-type collectionFindResponse struct {
-	Data struct {
-		Documents     []json.RawMessage `json:"documents"`
-		NextPageState *string           `json:"nextPageState"`
-	} `json:"data"`
-}
-
 // Find returns a cursor for iterating over documents matching the filter.
 //
 // The cursor automatically handles pagination, fetching new pages as needed.
@@ -184,17 +199,18 @@ type collectionFindResponse struct {
 //	    return err
 //	}
 //
-// Example with sort and limit:
+// Example with sort, projection and limit:
 //
 //	cursor := coll.Find(ctx, filter.F{"status": "active"},
-//	    options.WithCollectionSort(map[string]any{"created": -1}),
+//	    options.WithCollectionSort(sort.Desc("created").Asc("title")),
+//	    options.WithCollectionProjection(projection.Include("title", "created")),
 //	    options.WithCollectionLimit(10),
 //	)
 //
 // Example with vector search:
 //
 //	cursor := coll.Find(ctx, filter.F{},
-//	    options.WithCollectionSort(map[string]any{"$vector": []float32{0.1, 0.2, 0.3}}),
+//	    options.WithCollectionSort(sort.Vector(sort.FieldVector, []float32{0.1, 0.2, 0.3})),
 //	    options.WithCollectionIncludeSimilarity(true),
 //	)
 func (c *Collection) Find(ctx context.Context, f any, opts ...options.CollectionFindOption) *cursor.Cursor {
@@ -210,6 +226,8 @@ func (c *Collection) Find(ctx context.Context, f any, opts ...options.Collection
 	findOpts := options.NewCollectionFindOptions(opts...)
 
 	// Create a page fetcher that captures the collection, filter, and options
+	cursorInfo := &options.CursorInfo{Context: ctx}
+	codec := c.cache.resolve(c.db, c.options).GetCodec()
 	fetcher := func(fetchCtx context.Context, pageState *string) ([]json.RawMessage, *string, results.Warnings, error) {
 		payload := collectionFindPayload{
 			Filter:     f,
@@ -250,21 +268,25 @@ func (c *Collection) Find(ctx context.Context, f any, opts ...options.Collection
 			payload.Options = payloadOpts
 		}
 
+		cursorInfo.Page++
 		cmd := c.newCmd("find", payload)
+		cmd.cursor = cursorInfo
 		b, warnings, err := cmd.Execute(fetchCtx)
 		if err != nil {
 			return nil, nil, warnings, err
 		}
 
-		var resp collectionFindResponse
-		if err := json.Unmarshal(b, &resp); err != nil {
+		docs, nextPageState, err := decodePage(codec, b)
+		if err != nil {
 			return nil, nil, warnings, err
 		}
 
-		return resp.Data.Documents, resp.Data.NextPageState, warnings, nil
+		return docs, nextPageState, warnings, nil
 	}
 
-	return cursor.New(fetcher)
+	cur := cursor.NewWithCodec(fetcher, codec)
+	cur.OnClose(cursorInfo.Close)
+	return cur
 }
 
 func newCmdPayload(filter any) cmdPayload {
@@ -2,9 +2,16 @@ package astradb_test
 
 import (
 	"context"
+	"encoding/json"
+	"io"
+	"sync/atomic"
 	"testing"
 
 	astradb "github.com/datastax/astra-db-go"
+	"github.com/datastax/astra-db-go/astradbtest"
+	"github.com/datastax/astra-db-go/codec"
+	"github.com/datastax/astra-db-go/filter"
+	"github.com/datastax/astra-db-go/options"
 )
 
 // Example response from insertMany
@@ -21,3 +28,79 @@ func TestNullDB(t *testing.T) {
 		t.Errorf("Expected error. Got %v", err)
 	}
 }
+
+// countingCodec counts the values a JSON codec encodes and decodes.
+type countingCodec struct {
+	codec.JSON
+	encoded, decoded atomic.Int32
+}
+
+func (c *countingCodec) Encode(w io.Writer, v any) error {
+	c.encoded.Add(1)
+	return c.JSON.Encode(w, v)
+}
+
+func (c *countingCodec) Decode(data []byte, v any) error {
+	c.decoded.Add(1)
+	return c.JSON.Decode(data, v)
+}
+
+func TestCollectionCodec(t *testing.T) {
+	fake := astradbtest.New(t, astradbtest.WithPageSize(2))
+	ctx := context.Background()
+	counter := &countingCodec{}
+	db := astradb.NewClient(options.WithToken("token"), options.WithCodec(counter)).Database(fake.URL)
+
+	coll, err := db.CreateCollection(ctx, "ledger", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	docs := []map[string]any{
+		{"_id": "a", "amount": json.Number("9007199254740993")},
+		{"_id": "b", "amount": 1},
+		{"_id": "c", "amount": 2},
+	}
+	if _, err := coll.InsertMany(ctx, docs); err != nil {
+		t.Fatal(err)
+	}
+	encoded := counter.encoded.Load()
+	if encoded < 2 {
+		t.Errorf("expected payloads to be encoded with the codec, got %d", encoded)
+	}
+
+	var doc map[string]any
+	if err := coll.FindOne(ctx, filter.Eq("_id", "a")).Decode(&doc); err != nil {
+		t.Fatal(err)
+	}
+	var found []map[string]any
+	if err := coll.Find(ctx, filter.F{}).All(ctx, &found); err != nil {
+		t.Fatal(err)
+	}
+	cur := coll.Find(ctx, filter.F{})
+	for cur.Next(ctx) {
+		if err := cur.Decode(&doc); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// One document from FindOne, and three documents and two pages from
+	// each cursor
+	if got := counter.decoded.Load(); got != 11 {
+		t.Errorf("expected 7 documents and 4 pages decoded with the codec, got %d", got)
+	}
+
+	// Serdes options on a collection apply to its documents
+	numbers := db.Collection("ledger", options.WithSerdes(options.SerdesOptions{UseNumber: true}))
+	if err := numbers.FindOne(ctx, filter.Eq("_id", "a")).Decode(&doc); err != nil {
+		t.Fatal(err)
+	}
+	if n, ok := doc["amount"].(json.Number); !ok || n.String() != "9007199254740993" {
+		t.Errorf("expected exact json.Number, got %T %v", doc["amount"], doc["amount"])
+	}
+	var strict []struct {
+		ID string `json:"_id"`
+	}
+	cur = db.Collection("ledger", options.WithSerdes(options.SerdesOptions{DisallowUnknownFields: true})).Find(ctx, filter.F{})
+	if err := cur.All(ctx, &strict); err == nil {
+		t.Error("expected the unknown amount field to be rejected")
+	}
+}
@@ -20,9 +20,9 @@ import (
 	"encoding/json"
 	"errors"
 	"io"
-	"log/slog"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/datastax/astra-db-go/options"
 	"github.com/datastax/astra-db-go/results"
@@ -36,8 +36,11 @@ type command struct {
 	keyspace        string
 	apiVersion      string
 	resourceName    string
+	noKeyspace      bool                // Database-level command sent without a keyspace
 	resourceOptions *options.APIOptions // Options from the collection/table level
 	commandOptions  *options.APIOptions // Options for this specific command
+	cursor          *options.CursorInfo // Set when fetching a page for a cursor
+	cache           *optionsCache       // Resource options cache, if any
 }
 
 // newCmd creates a new command from the given DB
@@ -49,6 +52,17 @@ func newCmd(d *Db, name string, payload any) command {
 	}
 }
 
+// newDbCmd creates a database-level command such as createKeyspace, which
+// is sent to the API root rather than to a keyspace
+func newDbCmd(d *Db, name string, payload any) command {
+	return command{
+		db:         d,
+		name:       name,
+		payload:    payload,
+		noKeyspace: true,
+	}
+}
+
 // newCmdResource creates a new command from the given DB with a resource
 func newCmdResource(d *Db, resource, name string, payload any) command {
 	return command{
@@ -78,52 +92,76 @@ func newCmdWithOptions(d *Db, resource, name string, payload any, resourceOpts *
 
 // resolveOptions merges all option layers and returns the final resolved options.
 // Merge order: Defaults -> Client -> Database -> Resource (Collection/Table) -> Command
+//
+// Commands of a collection or table reuse its cached options, so the result
+// must not be modified.
 func (c *command) resolveOptions() *options.APIOptions {
-	var clientOpts, dbOpts *options.APIOptions
-
-	if c.db != nil {
-		dbOpts = c.db.options
-		if c.db.client != nil {
-			clientOpts = c.db.client.options
+	if c.db == nil {
+		return options.Merge(c.resourceOptions, c.commandOptions)
+	}
+	var base *options.APIOptions
+	if c.cache != nil {
+		base = c.cache.resolve(c.db, c.resourceOptions)
+	} else {
+		base, _ = c.db.resolveOptions()
+		if c.resourceOptions != nil {
+			base = options.Merge(base, c.resourceOptions)
 		}
 	}
-
-	return options.Merge(
-		clientOpts,        // Client level
-		dbOpts,            // Database level
-		c.resourceOptions, // Collection/Table level
-		c.commandOptions,  // Command level
-	)
+	if c.commandOptions == nil {
+		return base
+	}
+	return options.Merge(base, c.commandOptions)
 }
 
 // Keyspace returns the keyspace to use for this command.
 // If explicitly set on the command, that value is used.
 // Otherwise, it falls back to the resolved options.
 func (c *command) Keyspace() string {
+	return c.keyspaceFor(c.resolveOptions())
+}
+
+func (c *command) keyspaceFor(opts *options.APIOptions) string {
 	if len(c.keyspace) > 0 {
 		return c.keyspace
 	}
-	return c.resolveOptions().GetKeyspace()
+	return opts.GetKeyspace()
 }
 
 // ApiVersion returns the API version to use for this command.
 // If explicitly set on the command, that value is used.
 // Otherwise, it falls back to the resolved options.
 func (c *command) ApiVersion() string {
+	return c.apiVersionFor(c.resolveOptions())
+}
+
+func (c *command) apiVersionFor(opts *options.APIOptions) string {
 	if len(c.apiVersion) > 0 {
 		return c.apiVersion
 	}
-	return c.resolveOptions().GetAPIVersion()
+	return opts.GetAPIVersion()
 }
 
 func (c *command) url() (string, error) {
+	return c.urlFor(c.resolveOptions())
+}
+
+// urlFor returns the URL of the command given its resolved options.
+func (c *command) urlFor(opts *options.APIOptions) (string, error) {
 	if c.db == nil {
 		return "", errors.New("nil Db")
 	}
 	if len(c.db.Endpoint()) == 0 {
 		return "", errors.New("empty API endpoint")
 	}
-	return url.JoinPath(c.db.Endpoint(), "/api/json", c.ApiVersion(), c.Keyspace(), c.resourceName)
+	if c.noKeyspace {
+		return url.JoinPath(c.db.Endpoint(), "/api/json", c.apiVersionFor(opts))
+	}
+	keyspace := c.keyspaceFor(opts)
+	if keyspace == "" {
+		return "", ErrNoKeyspace
+	}
+	return url.JoinPath(c.db.Endpoint(), "/api/json", c.apiVersionFor(opts), keyspace, c.resourceName)
 }
 
 // This is similar to the [.NET client]. If we have a command name we want to
@@ -143,36 +181,116 @@ func (c command) MarshalJSON() ([]byte, error) {
 	return json.Marshal(c.payload)
 }
 
-// Execute a command against the astra DB web API.
+// Execute a command against the astra DB web API, through any interceptors
+// in the resolved options.
 // Returns the response body, any warnings from the API, and any error that occurred.
 func (c *command) Execute(ctx context.Context) ([]byte, results.Warnings, error) {
+	return c.execute(ctx, c.resolveOptions())
+}
+
+// execute is Execute with the options from c.resolveOptions, for callers
+// that need them after the command has run.
+func (c *command) execute(ctx context.Context, opts *options.APIOptions) ([]byte, results.Warnings, error) {
 	var body []byte
 	if c.db == nil {
 		return body, nil, ErrCmdNilDb
 	}
 
-	// Resolve all options for this command
-	opts := c.resolveOptions()
-
-	b, err := json.Marshal(c)
+	cmdURL, err := c.urlFor(opts)
 	if err != nil {
 		return body, nil, err
 	}
-	cmdURL, err := c.url()
-	if err != nil {
-		return body, nil, err
+	// Interceptors may modify the options, which can be cached
+	info := &options.CommandInfo{
+		Name:     c.name,
+		Resource: c.resourceName,
+		URL:      cmdURL,
+		Payload:  c.payload,
+		Options:  opts.Clone(),
+		Cursor:   c.cursor,
+	}
+	if !c.noKeyspace {
+		info.Keyspace = c.keyspaceFor(opts)
 	}
-	slog.Debug("Running cmd.Execute", "req.url", cmdURL, "req.body", string(b))
+	return opts.Chain(c.invoke)(ctx, info)
+}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", cmdURL, bytes.NewReader(b))
-	if err != nil {
-		return body, nil, err
+// invoke sends the command described by info, retrying once with a fresh
+// token on 401 Unauthorized, and extracts errors and warnings from the
+// response. It is the innermost [options.CommandHandler].
+func (c *command) invoke(ctx context.Context, info *options.CommandInfo) ([]byte, results.Warnings, error) {
+	opts := info.Options
+	log := newCommandLogger(info)
+	log.request(ctx, info)
+
+	var (
+		resp *http.Response
+		body []byte
+	)
+	for attempt := 0; ; attempt++ {
+		token, err := opts.ResolveToken(ctx)
+		if err != nil {
+			return body, nil, err
+		}
+		start := time.Now()
+		resp, body, err = c.send(ctx, opts, info, token)
+		if err != nil {
+			return body, nil, err
+		}
+		log.response(ctx, resp.StatusCode, time.Since(start), body)
+		// A 401 may mean the token was revoked or rotated early, so
+		// invalidate it and retry once with a fresh one
+		if resp.StatusCode != http.StatusUnauthorized || attempt > 0 || !opts.InvalidateToken(token) {
+			break
+		}
+		info.Retries++
+		log.retry(ctx, resp.StatusCode, attempt+1)
+	}
+	body, warnings, err := c.ExtractErrors(resp.StatusCode, body, opts)
+	log.warnings(ctx, warnings)
+	// ExtractErrors only sees the body, so fill in what it can't know.
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		httpErr.Header = resp.Header
 	}
+	var respErr *DataAPIResponseError
+	if errors.As(err, &respErr) {
+		respErr.RawRequest, _ = info.MarshalBody()
+	}
+	return body, warnings, err
+}
 
-	// Set authentication token from resolved options
-	token := opts.GetToken()
+// send posts the command described by info with token and returns the
+// response along with its body, which has already been read and closed.
+// Unless an interceptor set info.Body, the payload is encoded as it is
+// sent rather than marshalled up front.
+func (c *command) send(ctx context.Context, opts *options.APIOptions, info *options.CommandInfo, token string) (*http.Response, []byte, error) {
+	var body io.Reader
+	if info.Body != nil {
+		body = bytes.NewReader(info.Body)
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", info.URL, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if info.Body == nil {
+		// Streamed bodies are sent chunked; GetBody lets the transport
+		// encode the payload again if it needs to resend the request.
+		var streams []*bodyStream
+		defer func() {
+			for _, s := range streams {
+				s.close()
+			}
+		}()
+		req.GetBody = func() (io.ReadCloser, error) {
+			s := newBodyStream(info)
+			streams = append(streams, s)
+			return s, nil
+		}
+		req.Body, _ = req.GetBody()
+	}
 	if token != "" {
-		req.Header.Set("Token", token)
+		req.Header.Set("Token", opts.GetEnvironment().FormatToken(token))
 	}
 	req.Header.Set("Content-Type", "application/json")
 
@@ -181,63 +299,152 @@ func (c *command) Execute(ctx context.Context) ([]byte, results.Warnings, error)
 		req.Header.Set(key, value)
 	}
 
-	// Use HTTP client from resolved options
-	httpClient := opts.GetHTTPClient()
-	resp, err := httpClient.Do(req)
+	resp, err := c.db.client.httpClientFor(opts).Do(req)
 	if err != nil {
-		return body, nil, err
+		var encErr *encodeError
+		if errors.As(err, &encErr) {
+			return nil, nil, encErr.err
+		}
+		return nil, nil, err
 	}
 	defer resp.Body.Close()
 
-	body, err = io.ReadAll(resp.Body)
-	slog.Debug("cmd.Execute response", "resp.StatusCode", resp.StatusCode, "resp.Status", resp.Status, "resp.body", string(body))
-	if err != nil {
-		return body, nil, err
+	b, err := readBody(resp)
+	return resp, b, err
+}
+
+// bodyStream encodes the body of a command into a pipe as it is read.
+type bodyStream struct {
+	*io.PipeReader
+	done chan struct{}
+}
+
+func newBodyStream(info *options.CommandInfo) *bodyStream {
+	pr, pw := io.Pipe()
+	s := &bodyStream{PipeReader: pr, done: make(chan struct{})}
+	go func() {
+		defer close(s.done)
+		if err := info.EncodeBody(pw); err != nil {
+			pw.CloseWithError(&encodeError{err: err})
+			return
+		}
+		pw.Close()
+	}()
+	return s
+}
+
+// close stops the encoder if the transport didn't read the whole body and
+// waits for it to return, so the payload isn't read after send returns.
+func (s *bodyStream) close() {
+	s.PipeReader.Close()
+	<-s.done
+}
+
+// encodeError is an error encoding a streamed request body, which the
+// HTTP client reports as an error reading the body.
+type encodeError struct {
+	err error
+}
+
+func (e *encodeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *encodeError) Unwrap() error {
+	return e.err
+}
+
+// readBody reads the body of resp, into a buffer of the right size when the
+// length is known.
+func readBody(resp *http.Response) ([]byte, error) {
+	if resp.ContentLength <= 0 {
+		return io.ReadAll(resp.Body)
 	}
-	return c.ExtractErrors(resp.StatusCode, body, opts)
+	b := make([]byte, resp.ContentLength)
+	n, err := io.ReadFull(resp.Body, b)
+	return b[:n], err
 }
 
-// apiResponse captures both errors and warnings from API responses
+// apiResponse captures errors and warnings from API responses. It leaves
+// out data, which is only copied when there are errors, so decoding large
+// responses doesn't allocate.
 type apiResponse struct {
 	Errors DataAPIErrors `json:"errors"`
-	Status struct {
-		Warnings results.Warnings `json:"warnings"`
-	} `json:"status"`
+	Status apiStatus     `json:"status"`
+}
+
+// apiErrorResponse captures the status and partial results of responses
+// with errors.
+type apiErrorResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Status json.RawMessage `json:"status"`
+}
+
+// apiStatus captures the warnings in the status object of API responses
+type apiStatus struct {
+	Warnings results.Warnings `json:"warnings"`
 }
 
 // ExtractErrors will extract errors and warnings from body. For example, it will
-// turn this response into an error:
+// turn this response into an [*HTTPError]:
 //
 //	{"message":"Your database is resuming from hibernation and will be available in the next few minutes."}
 //
+// Errors in the response's "errors" array are returned as a [*DataAPIResponseError].
+// If strict warnings are enabled, matching warnings are returned as a [*WarningError].
+//
 // Will call WarningHandler if appropriate.
 func (c *command) ExtractErrors(statusCode int, body []byte, opts *options.APIOptions) ([]byte, results.Warnings, error) {
 	if statusCode >= 400 {
 		// We have a transport/server-level error so let's try to extract the message.
 		var transportErr DataAPIError
 		json.Unmarshal(body, &transportErr)
-		if len(transportErr.Message) > 0 {
-			return body, nil, errors.New(transportErr.Message)
+		return body, nil, &HTTPError{
+			StatusCode: statusCode,
+			Body:       body,
+			Message:    transportErr.Message,
 		}
-		// We can't find a message; just return the body
-		return body, nil, errors.New(string(body))
 	}
 
-	// Parse the full response to get both errors and warnings
+	// Parse the response to get both errors and warnings
 	var resp apiResponse
 	json.Unmarshal(body, &resp)
+	status := resp.Status
 
 	// Invoke warning handler for each warning if configured
-	if opts != nil && opts.WarningHandler != nil && len(resp.Status.Warnings) > 0 {
-		for _, w := range resp.Status.Warnings {
+	if opts != nil && opts.WarningHandler != nil && len(status.Warnings) > 0 {
+		for _, w := range status.Warnings {
 			opts.WarningHandler(w)
 		}
 	}
 
 	// Return error if present
 	if len(resp.Errors) > 0 {
-		return body, resp.Status.Warnings, &resp.Errors
+		var partial apiErrorResponse
+		json.Unmarshal(body, &partial)
+		return body, status.Warnings, &DataAPIResponseError{
+			Command:     c.name,
+			Errors:      resp.Errors,
+			RawResponse: body,
+			Status:      partial.Status,
+			Data:        partial.Data,
+		}
+	}
+
+	// Promote warnings to an error if strict warnings are enabled
+	var strict results.Warnings
+	for _, w := range status.Warnings {
+		if opts.IsStrictWarning(w.ErrorCode) {
+			strict = append(strict, w)
+		}
+	}
+	if len(strict) > 0 {
+		return body, status.Warnings, &WarningError{
+			Command:     c.name,
+			Warnings:    strict,
+			RawResponse: body,
+		}
 	}
 
-	return body, resp.Status.Warnings, nil
+	return body, status.Warnings, nil
 }
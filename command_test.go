@@ -15,7 +15,21 @@
 package astradb
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/datastax/astra-db-go/filter"
+	"github.com/datastax/astra-db-go/options"
+	"github.com/datastax/astra-db-go/results"
 )
 
 // Example response when your application is resuming
@@ -25,7 +39,38 @@ func TestCommandDBResuming(t *testing.T) {
 	cmd := command{}
 	_, _, err := cmd.ExtractErrors(503, []byte(resumingResponse), nil)
 	if err == nil {
-		t.Error("Expected error but got none")
+		t.Fatal("Expected error but got none")
+	}
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		t.Fatalf("Expected *HTTPError. Got %T", err)
+	}
+	if httpErr.StatusCode != 503 {
+		t.Errorf("Expected status 503. Got %d", httpErr.StatusCode)
+	}
+	if !httpErr.IsHibernating() || !IsHibernating(err) {
+		t.Error("Expected error to report hibernating")
+	}
+	if !httpErr.IsRetryable() || !IsRetryable(err) {
+		t.Error("Expected error to be retryable")
+	}
+}
+
+func TestCommandHTTPErrorWithoutMessage(t *testing.T) {
+	cmd := command{}
+	_, _, err := cmd.ExtractErrors(401, []byte("Unauthorized"), nil)
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		t.Fatalf("Expected *HTTPError. Got %T", err)
+	}
+	if string(httpErr.Body) != "Unauthorized" {
+		t.Errorf("Expected body to be kept. Got %q", httpErr.Body)
+	}
+	if httpErr.IsRetryable() || httpErr.IsHibernating() {
+		t.Error("Expected 401 to be neither retryable nor hibernating")
+	}
+	if err.Error() != "Unauthorized (status: 401)" {
+		t.Errorf("Unexpected message: %s", err)
 	}
 }
 
@@ -33,11 +78,49 @@ func TestCommandDBResuming(t *testing.T) {
 const createAlreadyExistsResponse = "{\"status\":{\"insertedIds\":[]},\"errors\":[{\"message\":\"Document already exists with the given _id\",\"errorCode\":\"DOCUMENT_ALREADY_EXISTS\",\"id\":\"4055f085-68d8-4c2d-8d91-90a0722b5fef\",\"title\":\"Document already exists with the given _id\",\"family\":\"REQUEST\",\"scope\":\"DOCUMENT\"}]}"
 
 func TestCommandAlreadyExistsErr(t *testing.T) {
-	cmd := command{}
+	cmd := command{name: "insertOne"}
 	_, _, err := cmd.ExtractErrors(200, []byte(createAlreadyExistsResponse), nil)
 	t.Logf("err value:\n%s", err)
 	if err == nil {
-		t.Error("Expected error but got none")
+		t.Fatal("Expected error but got none")
+	}
+	var respErr *DataAPIResponseError
+	if !errors.As(err, &respErr) {
+		t.Fatalf("Expected *DataAPIResponseError. Got %T", err)
+	}
+	if respErr.Command != "insertOne" {
+		t.Errorf("Expected command insertOne. Got %q", respErr.Command)
+	}
+	if string(respErr.Status) != `{"insertedIds":[]}` {
+		t.Errorf("Expected partial status to be kept. Got %s", respErr.Status)
+	}
+	if !errors.Is(err, ErrDocumentAlreadyExists) {
+		t.Error("Expected errors.Is to match ErrDocumentAlreadyExists")
+	}
+	if errors.Is(err, ErrCollectionNotExist) {
+		t.Error("Did not expect errors.Is to match ErrCollectionNotExist")
+	}
+	if IsRetryable(err) {
+		t.Error("Did not expect duplicate document to be retryable")
+	}
+	// The flat error slice is still reachable
+	var errs *DataAPIErrors
+	if !errors.As(err, &errs) || len(*errs) != 1 {
+		t.Error("Expected errors.As to find DataAPIErrors")
+	}
+}
+
+// Example response when a read times out
+const readTimeoutResponse = "{\"errors\":[{\"message\":\"Database read timed out\",\"errorCode\":\"SERVER_READ_TIMEOUT\",\"family\":\"SERVER\",\"scope\":\"DATABASE\"}]}"
+
+func TestCommandRetryableErr(t *testing.T) {
+	cmd := command{name: "find"}
+	_, _, err := cmd.ExtractErrors(200, []byte(readTimeoutResponse), nil)
+	if !IsRetryable(err) {
+		t.Errorf("Expected %v to be retryable", err)
+	}
+	if !errors.Is(err, ErrServerReadTimeout) {
+		t.Error("Expected errors.Is to match ErrServerReadTimeout")
 	}
 }
 
@@ -55,3 +138,386 @@ func TestCommandWarnings(t *testing.T) {
 		t.Errorf("Expected %d warnings but got: %d", expected, len(warnings))
 	}
 }
+
+func TestCommandStrictWarnings(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     *options.APIOptions
+		expected int // number of promoted warnings, 0 for no error
+	}{
+		{name: "disabled", opts: nil, expected: 0},
+		{name: "any warning", opts: options.NewAPIOptions(options.WithStrictWarnings()), expected: 2},
+		{name: "listed warning", opts: options.NewAPIOptions(options.WithStrictWarnings(results.WarningZeroFilterOperations)), expected: 1},
+		{name: "unlisted warning", opts: options.NewAPIOptions(options.WithStrictWarnings(results.WarningMissingIndex)), expected: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := command{name: "find"}
+			body, warnings, err := cmd.ExtractErrors(200, []byte(warningsResponse), tt.opts)
+			if len(body) == 0 {
+				t.Error("Expected body to be returned")
+			}
+			if len(warnings) != 2 {
+				t.Errorf("Expected 2 warnings but got: %d", len(warnings))
+			}
+			if tt.expected == 0 {
+				if err != nil {
+					t.Errorf("Did not expect error but got: %v", err)
+				}
+				return
+			}
+			var warnErr *WarningError
+			if !errors.As(err, &warnErr) {
+				t.Fatalf("Expected *WarningError. Got %T", err)
+			}
+			if len(warnErr.Warnings) != tt.expected {
+				t.Errorf("Expected %d promoted warnings but got: %d", tt.expected, len(warnErr.Warnings))
+			}
+			if !errors.Is(err, ErrorCode(results.WarningZeroFilterOperations)) {
+				t.Error("Expected errors.Is to match ZERO_FILTER_OPERATIONS")
+			}
+		})
+	}
+}
+
+func TestCommandRetriesAfterUnauthorized(t *testing.T) {
+	var tokens []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokens = append(tokens, r.Header.Get("Token"))
+		if r.Header.Get("Token") != "token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"message":"invalid token"}`))
+			return
+		}
+		w.Write([]byte(`{"status":{"ok":1}}`))
+	}))
+	defer srv.Close()
+
+	refreshes := 0
+	provider := options.NewCachingTokenProvider(func(context.Context) (string, time.Time, error) {
+		refreshes++
+		return fmt.Sprintf("token-%d", refreshes), time.Time{}, nil
+	}, 0)
+	retries := 0
+	db := NewClient(
+		options.WithTokenProvider(provider),
+		options.WithCommandInterceptor(func(ctx context.Context, cmd *options.CommandInfo, next options.CommandHandler) ([]byte, results.Warnings, error) {
+			body, warnings, err := next(ctx, cmd)
+			retries = cmd.Retries
+			return body, warnings, err
+		}),
+	).Database(srv.URL)
+
+	cmd := newCmd(db, "findCollections", struct{}{})
+	if _, _, err := cmd.Execute(context.Background()); err != nil {
+		t.Fatalf("Expected retry with a fresh token to succeed. Got %v", err)
+	}
+	if len(tokens) != 2 || tokens[0] != "token-1" || tokens[1] != "token-2" {
+		t.Errorf("Expected one retry with a refreshed token. Got %v", tokens)
+	}
+	if retries != 1 {
+		t.Errorf("Expected interceptor to see 1 retry. Got %d", retries)
+	}
+
+	// Static tokens can't be refreshed, so there is no retry
+	tokens = nil
+	db = NewClient(options.WithToken("bad")).Database(srv.URL)
+	cmd = newCmd(db, "findCollections", struct{}{})
+	_, _, err := cmd.Execute(context.Background())
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("Expected 401 *HTTPError. Got %v", err)
+	}
+	if len(tokens) != 1 {
+		t.Errorf("Expected no retry for a static token. Got %d requests", len(tokens))
+	}
+}
+
+func TestCommandInterceptors(t *testing.T) {
+	var tags []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tags = append(tags, r.Header.Get("X-Request-Tag"))
+		w.Write([]byte(`{"status":{"warnings":[{"errorCode":"MISSING_INDEX","message":"missing"}]}}`))
+	}))
+	defer srv.Close()
+
+	var seen *options.CommandInfo
+	var seenWarnings results.Warnings
+	client := NewClient(options.WithCommandInterceptor(func(ctx context.Context, cmd *options.CommandInfo, next options.CommandHandler) ([]byte, results.Warnings, error) {
+		seen = cmd
+		cmd.Options.Headers["X-Request-Tag"] = "tagged"
+		body, warnings, err := next(ctx, cmd)
+		seenWarnings = warnings
+		return body, warnings, err
+	}))
+	db := client.Database(srv.URL, options.WithKeyspace("ks"))
+	coll := db.Collection("books", options.WithCommandInterceptor(func(ctx context.Context, cmd *options.CommandInfo, next options.CommandHandler) ([]byte, results.Warnings, error) {
+		if cmd.Name == "deleteMany" {
+			return nil, nil, errors.New("deleteMany is not allowed")
+		}
+		return next(ctx, cmd)
+	}))
+
+	cmd := newCmdWithOptions(db, "books", "findOne", map[string]any{"filter": map[string]any{}}, coll.options)
+	if _, _, err := cmd.Execute(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if seen == nil || seen.Name != "findOne" || seen.Keyspace != "ks" || seen.Resource != "books" {
+		t.Fatalf("unexpected command info %+v", seen)
+	}
+	if body, err := seen.MarshalBody(); err != nil || string(body) != `{"findOne":{"filter":{}}}` {
+		t.Errorf("unexpected body %s (%v)", body, err)
+	}
+	if len(tags) != 1 || tags[0] != "tagged" {
+		t.Errorf("expected the interceptor's header to be sent, got %v", tags)
+	}
+	if !seenWarnings.Has(results.WarningMissingIndex) {
+		t.Errorf("expected interceptor to see warnings, got %v", seenWarnings)
+	}
+
+	// Inner interceptors can short-circuit the command
+	cmd = newCmdWithOptions(db, "books", "deleteMany", map[string]any{}, coll.options)
+	if _, _, err := cmd.Execute(context.Background()); err == nil || err.Error() != "deleteMany is not allowed" {
+		t.Errorf("expected deleteMany to be rejected, got %v", err)
+	}
+	if len(tags) != 1 {
+		t.Errorf("expected rejected command not to be sent, got %d requests", len(tags))
+	}
+}
+
+func TestCommandStreamsBody(t *testing.T) {
+	type request struct {
+		body    string
+		length  int64
+		chunked bool
+	}
+	var requests []request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		requests = append(requests, request{string(b), r.ContentLength, len(r.TransferEncoding) > 0})
+		w.Write([]byte(`{"errors":[{"errorCode":"INVALID_FILTER","message":"bad"}]}`))
+	}))
+	defer srv.Close()
+
+	db := NewClient().Database(srv.URL, options.WithKeyspace("ks"))
+	payload := map[string]any{"filter": map[string]any{"name": "<Bo>"}}
+
+	// Payloads are encoded as json.Marshal would, and sent chunked
+	cmd := newCmdResource(db, "books", "findOne", payload)
+	_, _, err := cmd.Execute(context.Background())
+	want := `{"findOne":{"filter":{"name":"\u003cBo\u003e"}}}`
+	if len(requests) != 1 || requests[0].body != want || !requests[0].chunked {
+		t.Fatalf("expected chunked body %s, got %+v", want, requests)
+	}
+	var respErr *DataAPIResponseError
+	if !errors.As(err, &respErr) || string(respErr.RawRequest) != want {
+		t.Errorf("expected error with raw request %s, got %v", want, err)
+	}
+
+	// A Body set by an interceptor replaces the payload
+	replace := options.WithCommandInterceptor(func(ctx context.Context, cmd *options.CommandInfo, next options.CommandHandler) ([]byte, results.Warnings, error) {
+		cmd.Body = []byte(`{"findOne":{}}`)
+		return next(ctx, cmd)
+	})
+	cmd = newCmdWithOptions(db, "books", "findOne", payload, nil, replace)
+	cmd.Execute(context.Background())
+	if len(requests) != 2 || requests[1].body != `{"findOne":{}}` || requests[1].length != 14 {
+		t.Errorf("expected the interceptor's body to be sent, got %+v", requests[1:])
+	}
+
+	// Encoding errors are returned as is
+	cmd = newCmdResource(db, "books", "findOne", map[string]any{"filter": make(chan int)})
+	_, _, err = cmd.Execute(context.Background())
+	var typeErr *json.UnsupportedTypeError
+	if !errors.As(err, &typeErr) {
+		t.Errorf("expected *json.UnsupportedTypeError, got %T: %v", err, err)
+	}
+}
+
+func TestCommandOptionsCache(t *testing.T) {
+	var headers []string
+	db := NewClient(options.WithCommandInterceptor(func(ctx context.Context, cmd *options.CommandInfo, next options.CommandHandler) ([]byte, results.Warnings, error) {
+		headers = append(headers, cmd.Options.Headers["X-Request-Tag"])
+		cmd.Options.Headers["X-Request-Tag"] = "tagged"
+		return nil, nil, nil
+	})).Database("https://example.com", options.WithKeyspace("ks1"))
+	coll := db.Collection("books")
+
+	cmd, other := coll.newCmd("findOne", nil), coll.newCmd("findOne", nil)
+	if cmd.resolveOptions() != other.resolveOptions() {
+		t.Error("expected commands of a collection to share resolved options")
+	}
+	if got := cmd.Keyspace(); got != "ks1" {
+		t.Errorf("expected keyspace ks1, got %s", got)
+	}
+
+	// Changing the database keyspace invalidates the collection's options
+	db.UseKeyspace("ks2")
+	cmd = coll.newCmd("findOne", nil)
+	if got := cmd.Keyspace(); got != "ks2" {
+		t.Errorf("expected keyspace ks2 after UseKeyspace, got %s", got)
+	}
+	cmd = coll.newCmd("findOne", nil, options.WithKeyspace("ks3"))
+	if got := cmd.Keyspace(); got != "ks3" {
+		t.Errorf("expected command keyspace ks3, got %s", got)
+	}
+
+	// Interceptors get a copy, so their changes aren't cached
+	for range 2 {
+		cmd := coll.newCmd("findOne", nil)
+		if _, _, err := cmd.Execute(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(headers) != 2 || headers[0] != "" || headers[1] != "" {
+		t.Errorf("expected interceptor changes not to persist, got %q", headers)
+	}
+}
+
+func TestUseKeyspaceConcurrentWithCommands(t *testing.T) {
+	db := NewClient().Database("https://example.com", options.WithKeyspace("ks0"))
+	coll := db.Collection("books")
+	before := db.Options()
+
+	var wg sync.WaitGroup
+	for i := range 4 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			db.UseKeyspace(fmt.Sprintf("ks%d", i+1))
+		}()
+		go func() {
+			defer wg.Done()
+			cmd := coll.newCmd("findOne", nil)
+			cmd.Keyspace()
+		}()
+	}
+	wg.Wait()
+
+	if got := before.GetKeyspace(); got != "ks0" {
+		t.Errorf("expected earlier options to keep keyspace ks0, got %s", got)
+	}
+	cmd := coll.newCmd("findOne", nil)
+	if got, want := cmd.Keyspace(), db.Options().GetKeyspace(); got != want {
+		t.Errorf("expected keyspace %s, got %s", want, got)
+	}
+}
+
+// stubTransport answers every request with body, without a network.
+type stubTransport struct {
+	body []byte
+}
+
+func (s stubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		io.Copy(io.Discard, req.Body)
+		req.Body.Close()
+	}
+	return &http.Response{
+		StatusCode:    http.StatusOK,
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(s.body)),
+		ContentLength: int64(len(s.body)),
+		Request:       req,
+	}, nil
+}
+
+// benchmarkCollection returns a collection whose commands are answered
+// with body by a stub, so benchmarks measure the client's own overhead.
+func benchmarkCollection(body string) *Collection {
+	client := NewClient(
+		options.WithToken("token"),
+		options.WithHTTPClient(&http.Client{Transport: stubTransport{body: []byte(body)}}),
+		options.WithHeader("x-embedding-api-key", "key"),
+	)
+	db := client.Database("https://db.example.com", options.WithKeyspace("ks"))
+	return db.Collection("books", options.WithTimeout(5*time.Second))
+}
+
+// benchmarkDoc is a document of a typical size for benchmarks.
+type benchmarkDoc struct {
+	ID     string   `json:"_id"`
+	Title  string   `json:"title"`
+	Author string   `json:"author"`
+	Year   int      `json:"year"`
+	Tags   []string `json:"tags"`
+}
+
+// benchmarkDocs returns n documents for benchmarks.
+func benchmarkDocs(n int) []benchmarkDoc {
+	docs := make([]benchmarkDoc, n)
+	for i := range docs {
+		docs[i] = benchmarkDoc{
+			ID:     fmt.Sprint(i),
+			Title:  fmt.Sprintf("Book %d", i),
+			Author: "Ursula K. Le Guin",
+			Year:   1969,
+			Tags:   []string{"fiction", "classic"},
+		}
+	}
+	return docs
+}
+
+func BenchmarkCommandResolveOptions(b *testing.B) {
+	coll := benchmarkCollection(`{"data":{"document":{"_id":"1"}}}`)
+	b.Run("resource", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			cmd := coll.newCmd("findOne", nil)
+			cmd.resolveOptions()
+		}
+	})
+	b.Run("command", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			cmd := coll.newCmd("findOne", nil, options.WithTimeout(time.Second))
+			cmd.resolveOptions()
+		}
+	})
+}
+
+func BenchmarkCommandExecute(b *testing.B) {
+	coll := benchmarkCollection(`{"data":{"document":{"_id":"1"}}}`)
+	ctx := context.Background()
+	b.ReportAllocs()
+	for b.Loop() {
+		cmd := coll.newCmd("findOne", map[string]any{"filter": map[string]any{"_id": "1"}})
+		if _, _, err := cmd.Execute(ctx); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkCollectionInsertMany sends 1000 documents in one command.
+func BenchmarkCollectionInsertMany(b *testing.B) {
+	coll := benchmarkCollection(`{"status":{"insertedIds":[]}}`)
+	docs := benchmarkDocs(1000)
+	ctx := context.Background()
+	b.ReportAllocs()
+	for b.Loop() {
+		cmd := coll.newCmd("insertMany", insertManyPayload{Documents: docs})
+		if _, _, err := cmd.Execute(ctx); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkCollectionFindAll decodes a page of 1000 documents with All.
+func BenchmarkCollectionFindAll(b *testing.B) {
+	docs, err := json.Marshal(benchmarkDocs(1000))
+	if err != nil {
+		b.Fatal(err)
+	}
+	coll := benchmarkCollection(`{"data":{"documents":` + string(docs) + `,"nextPageState":null}}`)
+	ctx := context.Background()
+	b.ReportAllocs()
+	for b.Loop() {
+		var found []benchmarkDoc
+		if err := coll.Find(ctx, filter.F{}).All(ctx, &found); err != nil {
+			b.Fatal(err)
+		}
+		if len(found) != 1000 {
+			b.Fatalf("expected 1000 documents, got %d", len(found))
+		}
+	}
+}
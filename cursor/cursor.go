@@ -20,8 +20,10 @@ import (
 	"encoding/json"
 	"errors"
 	"io"
+	"reflect"
 	"sync"
 
+	"github.com/datastax/astra-db-go/codec"
 	"github.com/datastax/astra-db-go/results"
 )
 
@@ -107,15 +109,31 @@ type Cursor struct {
 
 	// Accumulated warnings from all fetched pages
 	warnings results.Warnings
+
+	// Codec that documents are decoded with
+	codec codec.Codec
+
+	// Functions to call when the cursor is closed
+	onClose []func()
 }
 
 // New creates a new Cursor with the given page fetcher function.
 func New(fetcher PageFetcher) *Cursor {
+	return NewWithCodec(fetcher, nil)
+}
+
+// NewWithCodec creates a new Cursor with the given page fetcher function,
+// which decodes documents with c. A nil c uses [codec.JSON].
+func NewWithCodec(fetcher PageFetcher, c codec.Codec) *Cursor {
+	if c == nil {
+		c = codec.JSON{}
+	}
 	return &Cursor{
 		fetcher:  fetcher,
 		state:    CursorStateIdle,
 		buffer:   nil,
 		position: -1,
+		codec:    c,
 	}
 }
 
@@ -125,6 +143,7 @@ func NewWithError(err error) *Cursor {
 	return &Cursor{
 		state: CursorStateExhausted,
 		err:   err,
+		codec: codec.JSON{},
 	}
 }
 
@@ -144,6 +163,7 @@ func NewWithInitialData(documents []json.RawMessage, nextPageState *string, warn
 		nextPageState: nextPageState,
 		initialized:   true,
 		warnings:      warnings,
+		codec:         codec.JSON{},
 	}
 }
 
@@ -260,7 +280,7 @@ func (c *Cursor) Decode(v any) error {
 		return ErrNoCurrentDocument
 	}
 
-	return json.Unmarshal(c.buffer[c.position], v)
+	return c.codec.Decode(c.buffer[c.position], v)
 }
 
 // TryDecode is like Decode but returns (value, error) instead of taking a pointer.
@@ -275,6 +295,9 @@ func TryDecode[T any](c *Cursor) (T, error) {
 // The slice must be a pointer to a slice type.
 // After All returns, the cursor will be exhausted.
 //
+// Each page is decoded straight into the slice as it is fetched, so pages
+// are not held in memory until the end.
+//
 // Example:
 //
 //	var docs []MyDocument
@@ -288,7 +311,72 @@ func (c *Cursor) All(ctx context.Context, results any) error {
 	if c.state == CursorStateClosed {
 		return ErrCursorClosed
 	}
+	if c.state == CursorStateExhausted && c.err != nil {
+		return c.err
+	}
+
+	rv := reflect.ValueOf(results)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return c.allJSON(ctx, results)
+	}
+	slice := rv.Elem()
+	if slice.IsNil() {
+		// Like decoding an empty JSON array, leave an empty slice
+		slice.Set(reflect.MakeSlice(slice.Type(), 0, 0))
+	}
+	slice.SetLen(0)
+
+	// If we haven't initialized, fetch first page
+	if !c.initialized {
+		if err := c.fetchPageLocked(ctx, nil); err != nil {
+			c.err = err
+			return err
+		}
+		c.initialized = true
+	}
+
+	// Decode any remaining documents from current buffer
+	if err := c.decodeInto(slice, c.buffer[c.position+1:]); err != nil {
+		return err
+	}
+	c.position = len(c.buffer) - 1
+
+	// Fetch and decode remaining pages
+	for c.nextPageState != nil && *c.nextPageState != "" {
+		if err := c.fetchPageLocked(ctx, c.nextPageState); err != nil {
+			c.err = err
+			return err
+		}
+		if err := c.decodeInto(slice, c.buffer); err != nil {
+			return err
+		}
+		c.position = len(c.buffer) - 1
+	}
 
+	c.state = CursorStateExhausted
+	return nil
+}
+
+// decodeInto appends docs, decoded, to slice.
+func (c *Cursor) decodeInto(slice reflect.Value, docs []json.RawMessage) error {
+	n := slice.Len()
+	slice.Grow(len(docs))
+	slice.SetLen(n + len(docs))
+	for i, doc := range docs {
+		elem := slice.Index(n + i)
+		elem.SetZero()
+		if err := c.codec.Decode(doc, elem.Addr().Interface()); err != nil {
+			slice.SetLen(n + i)
+			return err
+		}
+	}
+	return nil
+}
+
+// allJSON is All for results other than pointers to slices, such as
+// pointers to arrays or interfaces: it decodes all remaining documents as
+// one JSON array.
+func (c *Cursor) allJSON(ctx context.Context, results any) error {
 	// Collect all raw documents
 	var allDocs []json.RawMessage
 
@@ -302,11 +390,7 @@ func (c *Cursor) All(ctx context.Context, results any) error {
 	}
 
 	// Add any remaining documents from current buffer
-	if c.position < 0 {
-		allDocs = append(allDocs, c.buffer...)
-	} else if c.position+1 < len(c.buffer) {
-		allDocs = append(allDocs, c.buffer[c.position+1:]...)
-	}
+	allDocs = append(allDocs, c.buffer[c.position+1:]...)
 
 	// Fetch remaining pages
 	for c.nextPageState != nil && *c.nextPageState != "" {
@@ -322,7 +406,7 @@ func (c *Cursor) All(ctx context.Context, results any) error {
 	// Marshal collected documents to JSON array and unmarshal into results
 	if len(allDocs) == 0 {
 		// Return empty but don't error
-		return json.Unmarshal([]byte("[]"), results)
+		return c.codec.Decode([]byte("[]"), results)
 	}
 
 	// Build JSON array from raw messages
@@ -331,7 +415,7 @@ func (c *Cursor) All(ctx context.Context, results any) error {
 		return err
 	}
 
-	return json.Unmarshal(arrayJSON, results)
+	return c.codec.Decode(arrayJSON, results)
 }
 
 // Err returns any error that occurred during iteration.
@@ -364,9 +448,23 @@ func (c *Cursor) Close(ctx context.Context) error {
 	c.buffer = nil
 	c.nextPageState = nil
 
+	for _, fn := range c.onClose {
+		fn()
+	}
+	c.onClose = nil
+
 	return nil
 }
 
+// OnClose registers fn to be called when the cursor is closed, e.g. to
+// release resources held for the whole query. It is called once, by the
+// first call to Close.
+func (c *Cursor) OnClose(fn func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onClose = append(c.onClose, fn)
+}
+
 // RemainingBatchLength returns the number of documents remaining in the current batch.
 func (c *Cursor) RemainingBatchLength() int {
 	c.mu.Lock()
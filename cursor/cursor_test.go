@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"testing"
 
@@ -161,6 +162,102 @@ func TestCursor_All(t *testing.T) {
 	}
 }
 
+func TestCursor_AllDecoding(t *testing.T) {
+	page1 := []testDoc{{ID: 1, Name: "Alice"}, {ID: 2, Name: "Bob"}}
+	page2 := []testDoc{{ID: 3, Name: "Charlie"}}
+	pageState1 := "page2"
+	fetcher := func(ctx context.Context, pageState *string) ([]json.RawMessage, *string, results.Warnings, error) {
+		if pageState == nil {
+			return makeRawMessages(page1), &pageState1, nil, nil
+		}
+		return makeRawMessages(page2), nil, nil, nil
+	}
+	ctx := context.Background()
+
+	t.Run("replaces slice contents", func(t *testing.T) {
+		docs := []testDoc{{ID: 9, Name: "Old"}, {ID: 8, Name: "Older"}, {ID: 7, Name: "Oldest"}, {ID: 6}}
+		if err := cursor.New(fetcher).All(ctx, &docs); err != nil {
+			t.Fatal(err)
+		}
+		if len(docs) != 3 || docs[0].Name != "Alice" || docs[2].Name != "Charlie" {
+			t.Errorf("unexpected documents %+v", docs)
+		}
+	})
+
+	t.Run("after Next", func(t *testing.T) {
+		c := cursor.New(fetcher)
+		c.Next(ctx)
+		var docs []testDoc
+		if err := c.All(ctx, &docs); err != nil {
+			t.Fatal(err)
+		}
+		if len(docs) != 2 || docs[0].Name != "Bob" || docs[1].Name != "Charlie" {
+			t.Errorf("expected remaining documents, got %+v", docs)
+		}
+	})
+
+	t.Run("maps", func(t *testing.T) {
+		var docs []map[string]any
+		if err := cursor.New(fetcher).All(ctx, &docs); err != nil {
+			t.Fatal(err)
+		}
+		if len(docs) != 3 || docs[1]["name"] != "Bob" {
+			t.Errorf("unexpected documents %+v", docs)
+		}
+	})
+
+	t.Run("arrays and interfaces", func(t *testing.T) {
+		var arr [2]testDoc
+		if err := cursor.New(fetcher).All(ctx, &arr); err != nil {
+			t.Fatal(err)
+		}
+		if arr[1].Name != "Bob" {
+			t.Errorf("unexpected documents %+v", arr)
+		}
+		var v any
+		if err := cursor.New(fetcher).All(ctx, &v); err != nil {
+			t.Fatal(err)
+		}
+		if docs, ok := v.([]any); !ok || len(docs) != 3 {
+			t.Errorf("unexpected documents %+v", v)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		empty := func(ctx context.Context, pageState *string) ([]json.RawMessage, *string, results.Warnings, error) {
+			return nil, nil, nil, nil
+		}
+		var docs []testDoc
+		if err := cursor.New(empty).All(ctx, &docs); err != nil {
+			t.Fatal(err)
+		}
+		if docs == nil || len(docs) != 0 {
+			t.Errorf("expected an empty slice, got %#v", docs)
+		}
+	})
+
+	t.Run("type error", func(t *testing.T) {
+		var docs []struct {
+			ID string `json:"id"`
+		}
+		var typeErr *json.UnmarshalTypeError
+		if err := cursor.New(fetcher).All(ctx, &docs); !errors.As(err, &typeErr) {
+			t.Errorf("expected *json.UnmarshalTypeError, got %v", err)
+		}
+		if len(docs) != 0 {
+			t.Errorf("expected no decoded documents, got %+v", docs)
+		}
+	})
+
+	t.Run("cursor with error", func(t *testing.T) {
+		want := errors.New("invalid filter")
+		var docs []testDoc
+		if err := cursor.NewWithError(want).All(ctx, &docs); err != want {
+			t.Errorf("expected %v, got %v", want, err)
+		}
+	})
+}
+
 func TestCursor_EmptyResults(t *testing.T) {
 	fetcher := func(ctx context.Context, pageState *string) ([]json.RawMessage, *string, results.Warnings, error) {
 		return []json.RawMessage{}, nil, nil, nil
@@ -211,6 +308,8 @@ func TestCursor_Close(t *testing.T) {
 	}
 
 	c := cursor.New(fetcher)
+	closed := 0
+	c.OnClose(func() { closed++ })
 
 	// Close the cursor
 	if err := c.Close(context.Background()); err != nil {
@@ -234,6 +333,9 @@ func TestCursor_Close(t *testing.T) {
 	if err := c.Close(context.Background()); err != nil {
 		t.Errorf("double Close failed: %v", err)
 	}
+	if closed != 1 {
+		t.Errorf("expected OnClose function to run once, ran %d times", closed)
+	}
 }
 
 func TestCursor_DecodeWithoutNext(t *testing.T) {
@@ -466,3 +568,41 @@ func TestCursor_HasNextPage(t *testing.T) {
 		t.Error("expected HasNextPage() false after last page")
 	}
 }
+
+// benchmarkDoc is a document of a typical size for cursor benchmarks.
+type benchmarkDoc struct {
+	ID     string   `json:"_id"`
+	Title  string   `json:"title"`
+	Author string   `json:"author"`
+	Year   int      `json:"year"`
+	Tags   []string `json:"tags"`
+}
+
+// BenchmarkCursorAll decodes two pages of 1000 documents with All.
+func BenchmarkCursorAll(b *testing.B) {
+	page := make([]json.RawMessage, 1000)
+	for i := range page {
+		page[i], _ = json.Marshal(benchmarkDoc{
+			ID:     fmt.Sprint(i),
+			Title:  fmt.Sprintf("Book %d", i),
+			Author: "Ursula K. Le Guin",
+			Year:   1969,
+			Tags:   []string{"fiction", "classic"},
+		})
+	}
+	next := "page2"
+	fetcher := func(ctx context.Context, pageState *string) ([]json.RawMessage, *string, results.Warnings, error) {
+		if pageState == nil {
+			return page, &next, nil, nil
+		}
+		return page, nil, nil, nil
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		var docs []benchmarkDoc
+		if err := cursor.New(fetcher).All(context.Background(), &docs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package astradb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+
+	"github.com/datastax/astra-db-go/options"
+)
+
+// DatabaseAdmin manages the keyspaces of a single database.
+// Obtain one with [Db.Admin].
+//
+// Against Astra, keyspaces are managed through the DevOps API. Against
+// self-hosted Data API deployments (see [options.WithEnvironment]), the
+// createKeyspace, dropKeyspace and findKeyspaces Data API commands are
+// used instead.
+type DatabaseAdmin struct {
+	db      *Db
+	options *options.APIOptions
+}
+
+// Admin returns a DatabaseAdmin for managing the database's keyspaces.
+//
+// Options set here override those set on the database.
+//
+// Example:
+//
+//	err := db.Admin().CreateKeyspace(ctx, "tenant_42",
+//		options.CreateKeyspace().SetUpdateDBKeyspace(true))
+func (d *Db) Admin(opts ...options.APIOption) *DatabaseAdmin {
+	return &DatabaseAdmin{
+		db:      d,
+		options: options.NewAPIOptions(opts...),
+	}
+}
+
+// UseKeyspace sets the working keyspace of the database. Collections and
+// tables obtained from d use it unless they set their own keyspace.
+//
+// It is safe to call concurrently with commands on d: commands already
+// running keep the keyspace they started with.
+func (d *Db) UseKeyspace(keyspace string) {
+	opts := d.options.Load().Clone()
+	options.WithKeyspace(keyspace)(opts)
+	d.options.Store(opts)
+	d.gen.Add(1)
+}
+
+// resolveOptions merges client, database and admin options.
+func (a *DatabaseAdmin) resolveOptions() *options.APIOptions {
+	dbOpts, _ := a.db.resolveOptions()
+	return options.Merge(dbOpts, a.options)
+}
+
+// astra returns a DevOps admin and the database ID when the database is
+// an Astra database; ok is false for self-hosted deployments and for
+// endpoints that are not Astra endpoints.
+func (a *DatabaseAdmin) astra() (admin *AstraAdmin, id string, ok bool) {
+	adminOpts := a.resolveOptions()
+	if !adminOpts.GetEnvironment().Supports(options.FeatureDevOpsAPI) {
+		return nil, "", false
+	}
+	id, env, ok := parseAstraEndpoint(a.db.Endpoint())
+	if !ok {
+		return nil, "", false
+	}
+	// The DevOps admin shares the client's transport, and merges the same
+	// layers of options as a's commands
+	ownOpts := a.options.Clone()
+	if adminOpts.AstraEnvironment == nil {
+		ownOpts.AstraEnvironment = &env
+	}
+	return &AstraAdmin{
+		client:    a.db.client,
+		dbOptions: a.db.options.Load(),
+		options:   ownOpts,
+	}, id, true
+}
+
+// dataAPICmd returns a database-level Data API command that carries the
+// admin's options.
+func (a *DatabaseAdmin) dataAPICmd(name string, payload any) command {
+	cmd := newDbCmd(a.db, name, payload)
+	cmd.commandOptions = a.options
+	return cmd
+}
+
+// ListKeyspaces returns the names of the keyspaces in the database.
+func (a *DatabaseAdmin) ListKeyspaces(ctx context.Context) ([]string, error) {
+	if admin, id, ok := a.astra(); ok {
+		info, err := admin.DescribeDatabase(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return databaseKeyspaces(info), nil
+	}
+
+	cmd := a.dataAPICmd("findKeyspaces", struct{}{})
+	body, _, err := cmd.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Status struct {
+			Keyspaces []string `json:"keyspaces"`
+		} `json:"status"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Status.Keyspaces, nil
+}
+
+// createKeyspacePayload is the payload of the createKeyspace Data API command
+type createKeyspacePayload struct {
+	Name    string `json:"name"`
+	Options *struct {
+		Replication *options.KeyspaceReplication `json:"replication,omitempty"`
+	} `json:"options,omitempty"`
+}
+
+// CreateKeyspace creates a keyspace in the database.
+//
+// By default this blocks until the keyspace is usable, within the
+// [options.AdminTimeouts] Keyspace budget. Set UpdateDBKeyspace to switch
+// the database's working keyspace once the keyspace exists.
+func (a *DatabaseAdmin) CreateKeyspace(ctx context.Context, name string, opts ...options.Builder[options.CreateKeyspaceOptions]) error {
+	if name == "" {
+		return errors.New("keyspace name cannot be empty")
+	}
+	ksOpts, err := options.MergeOptions(opts...)
+	if err != nil {
+		return err
+	}
+	adminOpts := ksOpts.AdminOptions()
+
+	if admin, id, ok := a.astra(); ok {
+		if ksOpts.Replication != nil {
+			return fmt.Errorf("%w: replication cannot be set for Astra keyspaces", ErrUnsupportedEnvironment)
+		}
+		_, err := admin.do(ctx, http.MethodPost, path.Join("/databases", id, "keyspaces", name), nil, nil, nil)
+		if err != nil {
+			return err
+		}
+		if adminOpts.IsBlocking() {
+			if err := a.waitForKeyspace(ctx, admin, id, name, true, adminOpts); err != nil {
+				return err
+			}
+		}
+	} else {
+		payload := createKeyspacePayload{Name: name}
+		if ksOpts.Replication != nil {
+			payload.Options = &struct {
+				Replication *options.KeyspaceReplication `json:"replication,omitempty"`
+			}{Replication: ksOpts.Replication}
+		}
+		ctx, cancel := context.WithTimeout(ctx, adminOpts.GetTimeout(a.resolveOptions().GetKeyspaceAdminTimeout()))
+		defer cancel()
+		cmd := a.dataAPICmd("createKeyspace", payload)
+		if _, _, err := cmd.Execute(ctx); err != nil {
+			return err
+		}
+	}
+
+	if ksOpts.UpdateDBKeyspace != nil && *ksOpts.UpdateDBKeyspace {
+		a.db.UseKeyspace(name)
+	}
+	return nil
+}
+
+// DropKeyspace drops a keyspace and all its data from the database.
+//
+// By default this blocks until the keyspace is gone, within the
+// [options.AdminTimeouts] Keyspace budget.
+func (a *DatabaseAdmin) DropKeyspace(ctx context.Context, name string, opts ...options.Builder[options.AdminOptions]) error {
+	if name == "" {
+		return errors.New("keyspace name cannot be empty")
+	}
+	adminOpts, err := options.MergeOptions(opts...)
+	if err != nil {
+		return err
+	}
+
+	if admin, id, ok := a.astra(); ok {
+		_, err := admin.do(ctx, http.MethodDelete, path.Join("/databases", id, "keyspaces", name), nil, nil, nil)
+		if err != nil {
+			return err
+		}
+		if !adminOpts.IsBlocking() {
+			return nil
+		}
+		return a.waitForKeyspace(ctx, admin, id, name, false, adminOpts)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, adminOpts.GetTimeout(a.resolveOptions().GetKeyspaceAdminTimeout()))
+	defer cancel()
+	cmd := a.dataAPICmd("dropKeyspace", struct {
+		Name string `json:"name"`
+	}{Name: name})
+	_, _, err = cmd.Execute(ctx)
+	return err
+}
+
+// waitForKeyspace polls an Astra database until it is ACTIVE and the
+// keyspace exists (or no longer exists when exists is false).
+func (a *DatabaseAdmin) waitForKeyspace(ctx context.Context, admin *AstraAdmin, id, name string, exists bool, adminOpts *options.AdminOptions) error {
+	what := "create keyspace " + name
+	if !exists {
+		what = "drop keyspace " + name
+	}
+	timeout := adminOpts.GetTimeout(a.resolveOptions().GetKeyspaceAdminTimeout())
+	_, err := admin.waitUntil(ctx, id, what, timeout, adminOpts, func(info *DatabaseInfo) bool {
+		return info != nil && info.Status == DatabaseStatusActive &&
+			slices.Contains(databaseKeyspaces(info), name) == exists
+	})
+	return err
+}
+
+// databaseKeyspaces returns all keyspaces of an Astra database.
+func databaseKeyspaces(info *DatabaseInfo) []string {
+	if len(info.Info.Keyspaces) > 0 {
+		return info.Info.Keyspaces
+	}
+	var keyspaces []string
+	if info.Info.Keyspace != "" {
+		keyspaces = append(keyspaces, info.Info.Keyspace)
+	}
+	return append(keyspaces, info.Info.AdditionalKeyspaces...)
+}
@@ -0,0 +1,287 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package astradb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/datastax/astra-db-go/options"
+	"github.com/datastax/astra-db-go/table"
+)
+
+const testDatabaseID = "01234567-89ab-cdef-0123-456789abcdef"
+
+func TestParseAstraEndpoint(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		id       string
+		env      options.DBEnvironment
+		ok       bool
+	}{
+		{"https://" + testDatabaseID + "-us-east1.apps.astra.datastax.com", testDatabaseID, options.EnvironmentProduction, true},
+		{"https://" + testDatabaseID + "-eu-west-1.apps.astra-dev.datastax.com/", testDatabaseID, options.EnvironmentDev, true},
+		{"http://localhost:8181", "", 0, false},
+		{"https://short-us-east1.apps.astra.datastax.com", "", 0, false},
+	}
+	for _, tt := range tests {
+		id, env, ok := parseAstraEndpoint(tt.endpoint)
+		if id != tt.id || env != tt.env || ok != tt.ok {
+			t.Errorf("parseAstraEndpoint(%q) = %q, %v, %v; want %q, %v, %v", tt.endpoint, id, env, ok, tt.id, tt.env, tt.ok)
+		}
+	}
+}
+
+func TestDatabaseAdminDataAPIKeyspaces(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		paths    []string
+		commands []map[string]json.RawMessage
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		var cmd map[string]json.RawMessage
+		json.NewDecoder(r.Body).Decode(&cmd)
+		paths = append(paths, r.URL.Path)
+		commands = append(commands, cmd)
+		if _, ok := cmd["findKeyspaces"]; ok {
+			w.Write([]byte(`{"status":{"keyspaces":["default_keyspace","tenant_1"]}}`))
+			return
+		}
+		w.Write([]byte(`{"status":{"ok":1}}`))
+	}))
+	defer srv.Close()
+
+	db := NewClient(options.WithToken("test-token")).Database(srv.URL)
+	admin := db.Admin()
+	ctx := context.Background()
+
+	err := admin.CreateKeyspace(ctx, "tenant_1", options.CreateKeyspace().
+		SetReplication(options.SimpleStrategy(3)).
+		SetUpdateDBKeyspace(true))
+	if err != nil {
+		t.Fatalf("CreateKeyspace failed: %v", err)
+	}
+	if got := db.Options().GetKeyspace(); got != "tenant_1" {
+		t.Errorf("expected working keyspace tenant_1, got %q", got)
+	}
+	keyspaces, err := admin.ListKeyspaces(ctx)
+	if err != nil {
+		t.Fatalf("ListKeyspaces failed: %v", err)
+	}
+	if !slices.Equal(keyspaces, []string{"default_keyspace", "tenant_1"}) {
+		t.Errorf("unexpected keyspaces %v", keyspaces)
+	}
+	if err := admin.DropKeyspace(ctx, "tenant_1"); err != nil {
+		t.Fatalf("DropKeyspace failed: %v", err)
+	}
+
+	for _, p := range paths {
+		if p != "/api/json/v1" {
+			t.Errorf("expected keyspace commands at /api/json/v1, got %s", p)
+		}
+	}
+	want := `{"name":"tenant_1","options":{"replication":{"class":"SimpleStrategy","replication_factor":3}}}`
+	if got := string(commands[0]["createKeyspace"]); got != want {
+		t.Errorf("createKeyspace payload = %s, want %s", got, want)
+	}
+	if got := string(commands[2]["dropKeyspace"]); got != `{"name":"tenant_1"}` {
+		t.Errorf("unexpected dropKeyspace payload %s", got)
+	}
+}
+
+func TestDatabaseAdminAstraKeyspaces(t *testing.T) {
+	var (
+		mu        sync.Mutex
+		keyspaces = []string{"default_keyspace"}
+		pending   []string
+		status    = DatabaseStatusActive
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		ksPath := "/databases/" + testDatabaseID + "/keyspaces/tenant_1"
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == ksPath:
+			// The keyspace appears after one describe in MAINTENANCE
+			status = DatabaseStatusMaintenance
+			pending = append(slices.Clone(keyspaces), "tenant_1")
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodDelete && r.URL.Path == ksPath:
+			status = DatabaseStatusMaintenance
+			pending = []string{"default_keyspace"}
+			w.WriteHeader(http.StatusAccepted)
+		case r.Method == http.MethodGet && r.URL.Path == "/databases/"+testDatabaseID:
+			info := DatabaseInfo{ID: testDatabaseID, Status: status, Info: DatabaseDetails{Keyspaces: keyspaces}}
+			if status == DatabaseStatusMaintenance {
+				status, keyspaces = DatabaseStatusActive, pending
+			}
+			json.NewEncoder(w).Encode(info)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	db := NewClient(options.WithToken("test-token"), options.WithAdminEndpoint(srv.URL)).
+		Database("https://" + testDatabaseID + "-us-east1.apps.astra.datastax.com")
+	admin := db.Admin()
+	ctx := context.Background()
+	poll := time.Millisecond
+
+	if err := admin.CreateKeyspace(ctx, "tenant_1", &options.CreateKeyspaceOptions{PollInterval: &poll}); err != nil {
+		t.Fatalf("CreateKeyspace failed: %v", err)
+	}
+	got, err := admin.ListKeyspaces(ctx)
+	if err != nil {
+		t.Fatalf("ListKeyspaces failed: %v", err)
+	}
+	if !slices.Contains(got, "tenant_1") {
+		t.Errorf("expected tenant_1 in %v", got)
+	}
+	if err := admin.DropKeyspace(ctx, "tenant_1", options.Admin().SetPollInterval(poll)); err != nil {
+		t.Fatalf("DropKeyspace failed: %v", err)
+	}
+	got, _ = admin.ListKeyspaces(ctx)
+	if slices.Contains(got, "tenant_1") {
+		t.Errorf("expected tenant_1 to be dropped, got %v", got)
+	}
+
+	err = admin.CreateKeyspace(ctx, "tenant_2", options.CreateKeyspace().SetReplication(options.SimpleStrategy(1)))
+	if err == nil {
+		t.Error("expected error setting replication on Astra")
+	}
+
+	// DevOps calls share the client's transport
+	astra, _, ok := admin.astra()
+	if !ok {
+		t.Fatal("expected an Astra admin")
+	}
+	if got := astra.client.httpClientFor(astra.resolveOptions()); got != db.client.httpClient {
+		t.Error("expected DevOps calls to use the client's shared HTTP client")
+	}
+}
+
+func TestSelfHostedEnvironment(t *testing.T) {
+	var gotToken, gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotToken = r.Header.Get("Token")
+		gotPath = r.URL.Path
+		w.Write([]byte(`{"status":{"keyspaces":["ks"]}}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(
+		options.WithEnvironment(options.EnvironmentHCD),
+		options.WithTokenProvider(options.NewUsernamePasswordTokenProvider("cassandra", "cassandra")),
+	)
+	db := client.Database(srv.URL)
+	ctx := context.Background()
+
+	if _, err := db.Admin().ListKeyspaces(ctx); err != nil {
+		t.Fatalf("ListKeyspaces failed: %v", err)
+	}
+	if gotToken != "Cassandra:Y2Fzc2FuZHJh:Y2Fzc2FuZHJh" {
+		t.Errorf("unexpected token %q", gotToken)
+	}
+	if gotPath != "/api/json/v1" {
+		t.Errorf("unexpected path %q", gotPath)
+	}
+
+	cmd := newCmd(db, "findOne", struct{}{})
+	if _, _, err := cmd.Execute(ctx); !errors.Is(err, ErrNoKeyspace) {
+		t.Errorf("expected ErrNoKeyspace without a keyspace, got %v", err)
+	}
+
+	_, err := client.Admin().ListDatabases(ctx)
+	if !errors.Is(err, ErrUnsupportedEnvironment) {
+		t.Errorf("expected ErrUnsupportedEnvironment from DevOps API, got %v", err)
+	}
+
+	if _, err := db.Admin().FindEmbeddingProviders(ctx); !errors.Is(err, ErrUnsupportedEnvironment) {
+		t.Errorf("expected ErrUnsupportedEnvironment from FindEmbeddingProviders, got %v", err)
+	}
+	if _, err := db.Admin().FindRerankingProviders(ctx); !errors.Is(err, ErrUnsupportedEnvironment) {
+		t.Errorf("expected ErrUnsupportedEnvironment from FindRerankingProviders, got %v", err)
+	}
+
+	ksDb := client.Database(srv.URL, options.WithKeyspace("ks"))
+	collOpts := &options.CollectionOptions{Vector: &options.VectorOptions{
+		Service: &options.VectorServiceOptions{Provider: "openai", ModelName: "text-embedding-3-small"},
+	}}
+	if _, err := ksDb.CreateCollection(ctx, "docs", collOpts); !errors.Is(err, ErrUnsupportedEnvironment) {
+		t.Errorf("expected ErrUnsupportedEnvironment from vectorize collection, got %v", err)
+	}
+	def := table.Definition{Columns: map[string]table.Column{
+		"v": table.VectorWithService(1536, &table.VectorService{Provider: "openai", ModelName: "text-embedding-3-small"}),
+	}}
+	if _, err := ksDb.CreateTable(ctx, "docs", def); !errors.Is(err, ErrUnsupportedEnvironment) {
+		t.Errorf("expected ErrUnsupportedEnvironment from vectorize table, got %v", err)
+	}
+}
+
+func TestSelfHostedUsernamePasswordToken(t *testing.T) {
+	var gotToken string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotToken = r.Header.Get("Token")
+		w.Write([]byte(`{"status":{"keyspaces":["ks"]}}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(options.WithEnvironment(options.EnvironmentHCD), options.WithToken("cassandra:cassandra"))
+	if _, err := client.Database(srv.URL).Admin().ListKeyspaces(context.Background()); err != nil {
+		t.Fatalf("ListKeyspaces failed: %v", err)
+	}
+	if gotToken != "Cassandra:Y2Fzc2FuZHJh:Y2Fzc2FuZHJh" {
+		t.Errorf("unexpected token %q", gotToken)
+	}
+}
+
+func TestSelfHostedUnauthorizedRetry(t *testing.T) {
+	var gotTokens []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get("Token")
+		gotTokens = append(gotTokens, token)
+		if token != "Cassandra:Y2Fzc2FuZHJh:bmV3" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"status":{"keyspaces":["ks"]}}`))
+	}))
+	defer srv.Close()
+
+	passwords := []string{"old", "new"}
+	provider := options.NewCachingTokenProvider(func(context.Context) (string, time.Time, error) {
+		password := passwords[0]
+		passwords = passwords[1:]
+		return "cassandra:" + password, time.Now().Add(time.Hour), nil
+	}, 0)
+	client := NewClient(options.WithEnvironment(options.EnvironmentHCD), options.WithTokenProvider(provider))
+	if _, err := client.Database(srv.URL).Admin().ListKeyspaces(context.Background()); err != nil {
+		t.Fatalf("ListKeyspaces failed: %v", err)
+	}
+	want := []string{"Cassandra:Y2Fzc2FuZHJh:b2xk", "Cassandra:Y2Fzc2FuZHJh:bmV3"}
+	if !slices.Equal(gotTokens, want) {
+		t.Errorf("expected the rejected token to be replaced, got %q", gotTokens)
+	}
+}
@@ -17,8 +17,11 @@ package astradb
 
 import (
 	"context"
+	"encoding/json"
+	"sync/atomic"
 
 	"github.com/datastax/astra-db-go/options"
+	"github.com/datastax/astra-db-go/results"
 )
 
 // Db represents a connection to a specific Astra DB database.
@@ -28,7 +31,9 @@ import (
 type Db struct {
 	endpoint string
 	client   *DataAPIClient
-	options  *options.APIOptions
+	options  atomic.Pointer[options.APIOptions] // Replaced, never modified, when options change
+	gen      atomic.Uint64                      // Bumped when options change
+	resolved atomic.Pointer[resolvedOptions]    // Cached client and database options
 }
 
 func (d *Db) newCmd(name string, payload any) command {
@@ -40,12 +45,14 @@ func (d *Db) Endpoint() string {
 	return d.endpoint
 }
 
-// Options returns the database's options (or empty options if nil).
+// Options returns the database's options (or empty options if nil). They
+// must not be modified: options are cached when commands run, so changes
+// may not take effect. Use [Db.UseKeyspace] to change the keyspace.
 func (d *Db) Options() *options.APIOptions {
-	if d.options == nil {
-		return &options.APIOptions{}
+	if opts := d.options.Load(); opts != nil {
+		return opts
 	}
-	return d.options
+	return &options.APIOptions{}
 }
 
 // Client returns the parent DataAPIClient.
@@ -71,8 +78,13 @@ func (d *Db) Collection(name string, opts ...options.APIOption) *Collection {
 }
 
 // CreateCollection creates a collection in the database.
-// Note: Warnings are accessible via the WarningHandler option callback only.
-func (d *Db) CreateCollection(ctx context.Context, name string, collOpts *options.CollectionOptions) (*Collection, error) {
+//
+// With [options.WithCollectionProviderValidation], a vectorize service is
+// checked with [EmbeddingProviders.ValidateVectorOptions] first.
+//
+// Any warnings from the command are available from the returned
+// collection's [Collection.Warnings] method.
+func (d *Db) CreateCollection(ctx context.Context, name string, collOpts *options.CollectionOptions, opts ...options.CreateCollectionOption) (*Collection, error) {
 	payload := struct {
 		Name    string                     `json:"name"`
 		Options *options.CollectionOptions `json:"options,omitempty"`
@@ -81,25 +93,68 @@ func (d *Db) CreateCollection(ctx context.Context, name string, collOpts *option
 		Options: collOpts,
 	}
 	cmd := d.newCmd("createCollection", payload)
-	_, _, err := cmd.Execute(ctx)
+	if collOpts != nil && collOpts.Vector != nil && collOpts.Vector.Service != nil {
+		if err := requireFeature(cmd.resolveOptions(), options.FeatureVectorize); err != nil {
+			return nil, err
+		}
+		if options.NewCreateCollectionOptions(opts...).ValidateProviders {
+			if err := d.validateProviders(ctx, func(p EmbeddingProviders) error {
+				return p.ValidateVectorOptions(collOpts.Vector)
+			}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	_, warnings, err := cmd.Execute(ctx)
 	if err != nil {
 		return nil, err
 	}
 	return &Collection{
-		db:   d,
-		name: name,
+		db:       d,
+		name:     name,
+		warnings: warnings,
 	}, nil
 }
 
 // DropCollection drops a collection from the database.
-// Note: Warnings are accessible via the WarningHandler option callback only.
-func (d *Db) DropCollection(ctx context.Context, name string) error {
+func (d *Db) DropCollection(ctx context.Context, name string) (*results.CommandResult, error) {
 	payload := struct {
 		Name string `json:"name"`
 	}{
 		Name: name,
 	}
 	cmd := newCmd(d, "deleteCollection", payload)
-	_, _, err := cmd.Execute(ctx)
-	return err
+	b, warnings, err := cmd.Execute(ctx)
+	return results.NewCommandResult(b, warnings), err
+}
+
+// CollectionDescriptor describes a collection as returned by [Db.ListCollections].
+type CollectionDescriptor struct {
+	Name    string                    `json:"name"`
+	Options options.CollectionOptions `json:"options"`
+}
+
+// ListCollections returns the collections in the database's keyspace with
+// their options.
+func (d *Db) ListCollections(ctx context.Context) ([]CollectionDescriptor, error) {
+	payload := struct {
+		Options struct {
+			Explain bool `json:"explain"`
+		} `json:"options"`
+	}{}
+	payload.Options.Explain = true
+	cmd := d.newCmd("findCollections", payload)
+	b, _, err := cmd.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Status struct {
+			Collections []CollectionDescriptor `json:"collections"`
+		} `json:"status"`
+	}
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Status.Collections, nil
 }
@@ -1,14 +1,20 @@
 package astradb
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"reflect"
 	"strings"
+
+	"github.com/datastax/astra-db-go/options"
+	"github.com/datastax/astra-db-go/results"
 )
 
-// ErrNotFound is returned when a document is not found.
-var ErrNotFound error = errors.New("not found")
+// ErrNotFound is returned when a document is not found, e.g. by
+// [results.SingleResult.Decode] after a FindOne that matched nothing.
+var ErrNotFound error = results.ErrNotFound
 
 // ErrNil is returned when an argument is nil.
 var ErrNil error = errors.New("must be non-nil")
@@ -22,6 +28,24 @@ var ErrEmptySlice error = errors.New("must be non-empty")
 // ErrCmdNilDb is returned when a command tries to execute with a nil db
 var ErrCmdNilDb error = errors.New("command cannot execute with nil Db")
 
+// ErrNoKeyspace is returned when a command needs a keyspace but none is set.
+// Self-hosted environments have no default keyspace; set one with
+// [options.WithKeyspace].
+var ErrNoKeyspace error = errors.New("no keyspace set")
+
+// ErrUnsupportedEnvironment is returned when an operation is not available
+// in the configured [options.Environment], e.g. DevOps API calls outside Astra.
+var ErrUnsupportedEnvironment error = errors.New("operation not supported in this environment")
+
+// requireFeature returns an error wrapping [ErrUnsupportedEnvironment] if
+// the environment of opts lacks f.
+func requireFeature(opts *options.APIOptions, f options.Feature) error {
+	if env := opts.GetEnvironment(); !env.Supports(f) {
+		return fmt.Errorf("%w: %s requires Astra, not %s", ErrUnsupportedEnvironment, f, env)
+	}
+	return nil
+}
+
 // ensureNonEmptySlice returns an error if v is anything other than a non-empty slice.
 func ensureNonEmptySlice(v any) error {
 	rval := reflect.ValueOf(v)
@@ -86,6 +110,20 @@ func (e DataAPIErrors) Unwrap() []error {
 	return errs
 }
 
+// Is reports whether target is an [ErrorCode] matching this error's code.
+// This allows matching well-known codes with [errors.Is]:
+//
+//	if errors.Is(err, astradb.ErrDocumentAlreadyExists) {
+//		// handle duplicate
+//	}
+func (a *DataAPIError) Is(target error) bool {
+	if a == nil {
+		return false
+	}
+	code, ok := target.(ErrorCode)
+	return ok && string(code) == a.ErrorCode
+}
+
 // Error implements the [error] interface.
 //
 // [error]: https://pkg.go.dev/builtin#error
@@ -117,3 +155,209 @@ func (a *DataAPIError) Error() string {
 
 	return msg
 }
+
+// ErrorCode is an errorCode value returned by the Data API. ErrorCode
+// implements [error] so the constants below can be used as sentinels with
+// [errors.Is].
+type ErrorCode string
+
+// Error implements the [error] interface.
+func (e ErrorCode) Error() string {
+	return string(e)
+}
+
+// Well-known error codes returned by the Data API.
+const (
+	ErrDocumentAlreadyExists               ErrorCode = "DOCUMENT_ALREADY_EXISTS"
+	ErrCollectionNotExist                  ErrorCode = "COLLECTION_NOT_EXIST"
+	ErrExistingCollectionDifferentSettings ErrorCode = "EXISTING_COLLECTION_DIFFERENT_SETTINGS"
+	ErrTooManyCollections                  ErrorCode = "TOO_MANY_COLLECTIONS"
+	ErrTableAlreadyExists                  ErrorCode = "TABLE_ALREADY_EXISTS"
+	ErrTableNotExist                       ErrorCode = "TABLE_NOT_EXIST"
+	ErrIndexAlreadyExists                  ErrorCode = "INDEX_ALREADY_EXISTS"
+	ErrKeyspaceDoesNotExist                ErrorCode = "KEYSPACE_DOES_NOT_EXIST"
+	ErrServerReadTimeout                   ErrorCode = "SERVER_READ_TIMEOUT"
+	ErrServerWriteTimeout                  ErrorCode = "SERVER_WRITE_TIMEOUT"
+	ErrServerUnavailable                   ErrorCode = "SERVER_UNAVAILABLE"
+)
+
+// retryableCodes are error codes for transient server-side failures.
+var retryableCodes = map[string]bool{
+	string(ErrServerReadTimeout):  true,
+	string(ErrServerWriteTimeout): true,
+	string(ErrServerUnavailable):  true,
+}
+
+// HTTPError is returned when the Data API responds with an HTTP status
+// of 400 or above. Such responses come from the transport or gateway
+// rather than from command execution, so they carry no [DataAPIError]s.
+type HTTPError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Header contains the response headers.
+	Header http.Header
+	// Body is the raw response body.
+	Body []byte
+	// Message is the "message" field of the body, if present.
+	Message string
+}
+
+// Error implements the [error] interface.
+func (e *HTTPError) Error() string {
+	if e == nil {
+		return "<nil> HTTPError"
+	}
+	msg := e.Message
+	if msg == "" {
+		msg = strings.TrimSpace(string(e.Body))
+	}
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	return fmt.Sprintf("%s (status: %d)", msg, e.StatusCode)
+}
+
+// IsRetryable reports whether the request may succeed if sent again,
+// e.g. for rate limiting or temporary unavailability.
+func (e *HTTPError) IsRetryable() bool {
+	if e == nil {
+		return false
+	}
+	switch e.StatusCode {
+	case http.StatusRequestTimeout,
+		http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// IsHibernating reports whether the database is hibernated or resuming
+// from hibernation. Requests will succeed once the database is active.
+func (e *HTTPError) IsHibernating() bool {
+	if e == nil {
+		return false
+	}
+	return e.StatusCode == http.StatusServiceUnavailable &&
+		strings.Contains(strings.ToLower(e.Message), "hibernat")
+}
+
+// DataAPIResponseError is returned when a command completes with one or
+// more errors in the response's "errors" array. Some commands (such as
+// insertMany) can partially succeed; the "status" and "data" objects are
+// kept so callers can inspect what was applied.
+type DataAPIResponseError struct {
+	// Command is the name of the command that failed (e.g. "insertMany").
+	Command string
+	// Errors are the errors reported by the Data API.
+	Errors DataAPIErrors
+	// RawRequest is the JSON request body that was sent.
+	RawRequest []byte
+	// RawResponse is the full JSON response body.
+	RawResponse []byte
+	// Status is the raw "status" object of the response, if any.
+	Status json.RawMessage
+	// Data is the raw "data" object of the response, if any.
+	Data json.RawMessage
+}
+
+// Error implements the [error] interface.
+func (e *DataAPIResponseError) Error() string {
+	if e == nil {
+		return "<nil> DataAPIResponseError"
+	}
+	if e.Command == "" {
+		return e.Errors.Error()
+	}
+	return fmt.Sprintf("%s: %s", e.Command, e.Errors.Error())
+}
+
+// Unwrap returns the underlying [DataAPIErrors] so [errors.As] and
+// [errors.Is] can reach each [DataAPIError].
+func (e *DataAPIResponseError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return &e.Errors
+}
+
+// IsRetryable reports whether every error in the response is a transient
+// server-side failure.
+func (e *DataAPIResponseError) IsRetryable() bool {
+	if e == nil || len(e.Errors) == 0 {
+		return false
+	}
+	for _, apiErr := range e.Errors {
+		if !retryableCodes[apiErr.ErrorCode] {
+			return false
+		}
+	}
+	return true
+}
+
+// IsHibernating always returns false: a hibernating database is reported
+// at the HTTP level as an [HTTPError].
+func (e *DataAPIResponseError) IsHibernating() bool {
+	return false
+}
+
+// IsRetryable reports whether err, or any error it wraps, is an
+// [HTTPError] or [DataAPIResponseError] that may succeed on retry.
+func IsRetryable(err error) bool {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.IsRetryable()
+	}
+	var respErr *DataAPIResponseError
+	if errors.As(err, &respErr) {
+		return respErr.IsRetryable()
+	}
+	return false
+}
+
+// IsHibernating reports whether err, or any error it wraps, indicates
+// the database is hibernated or resuming.
+func IsHibernating(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.IsHibernating()
+}
+
+// WarningError is returned when strict warnings are enabled with
+// [options.WithStrictWarnings] and the response contains a matching warning.
+// The command's response body is still returned alongside the error.
+type WarningError struct {
+	// Command is the name of the command that produced the warnings.
+	Command string
+	// Warnings are the warnings that were promoted to errors.
+	Warnings results.Warnings
+	// RawResponse is the full JSON response body.
+	RawResponse []byte
+}
+
+// Error implements the [error] interface.
+func (e *WarningError) Error() string {
+	if e == nil {
+		return "<nil> WarningError"
+	}
+	msgs := make([]string, len(e.Warnings))
+	for i := range e.Warnings {
+		msgs[i] = e.Warnings[i].String()
+	}
+	msg := "strict warnings: " + strings.Join(msgs, "; ")
+	if e.Command == "" {
+		return msg
+	}
+	return fmt.Sprintf("%s: %s", e.Command, msg)
+}
+
+// Is reports whether target is an [ErrorCode] matching one of the
+// promoted warnings' codes.
+func (e *WarningError) Is(target error) bool {
+	if e == nil {
+		return false
+	}
+	code, ok := target.(ErrorCode)
+	return ok && e.Warnings.Has(string(code))
+}
@@ -0,0 +1,617 @@
+package integrationtests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	astradb "github.com/datastax/astra-db-go"
+	"github.com/datastax/astra-db-go/filter"
+	"github.com/datastax/astra-db-go/internal/integrationtests/harness"
+	"github.com/datastax/astra-db-go/options"
+	"github.com/datastax/astra-db-go/results"
+	"github.com/datastax/astra-db-go/sort"
+)
+
+func getSimpleObjects(rows int) []SimpleObject {
+	// Round(0) strips the monotonic clock reading so decoded documents
+	// compare equal to the originals
+	now := time.Now().UTC().Round(0)
+	data := make([]SimpleObject, rows)
+	for i := 0; i < rows; i++ {
+		name := fmt.Sprintf("Object #%v", i)
+		data[i] = SimpleObject{
+			Name: name,
+			Properties: Properties{
+				PropertyOne: fmt.Sprintf("I'm number %v! What about extended characters? ☠️き", i),
+				PropertyTwo: `Bet you didn't see this newline coming....
+	did you?`,
+				IntProperty:         i,
+				StringArrayProperty: []string{"Test1", "test2"},
+				BoolProperty:        true,
+				TimeProperty:        now.AddDate(i, i, i),
+				UTCTime:             now,
+			},
+		}
+	}
+	return data
+}
+
+// simpleCollection creates a collection holding rows simple objects.
+func simpleCollection(t *testing.T, e *harness.Env, rows int) *astradb.Collection {
+	t.Helper()
+	c := e.Collection(nil)
+	if _, err := c.InsertMany(context.Background(), getSimpleObjects(rows)); err != nil {
+		t.Fatalf("InsertMany failed: %v", err)
+	}
+	return c
+}
+
+func TestCollectionCreate(t *testing.T) {
+	e := harness.New(t)
+	c := e.Collection(nil)
+
+	colls, err := e.DB.ListCollections(context.Background())
+	if err != nil {
+		t.Fatalf("ListCollections failed: %v", err)
+	}
+	if !slices.ContainsFunc(colls, func(d astradb.CollectionDescriptor) bool { return d.Name == c.Name() }) {
+		t.Errorf("expected %s in %+v", c.Name(), colls)
+	}
+}
+
+func TestCollectionInsertMany(t *testing.T) {
+	e := harness.New(t)
+	resp, err := e.Collection(nil).InsertMany(context.Background(), getSimpleObjects(30))
+	if err != nil {
+		t.Fatalf("InsertMany failed: %v", err)
+	}
+	if len(resp.Status.InsertedIds) != 30 {
+		t.Errorf("expected 30 inserted IDs, got %d", len(resp.Status.InsertedIds))
+	}
+}
+
+func TestCollectionItemAlreadyExists(t *testing.T) {
+	e := harness.New(t)
+	ctx := context.Background()
+	c := e.Collection(nil)
+	// Generate test data and insert a document
+	item := getSimpleObjects(1)[0]
+	resp, err := c.InsertOne(ctx, item)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Now set inserted ID to existing one and insert again
+	item.ID = resp.Status.InsertedIds[0]
+	_, err = c.InsertOne(ctx, item)
+	if err == nil {
+		t.Fatal("expecting duplicate insert error. Got nil")
+	}
+	if !errors.Is(err, astradb.ErrDocumentAlreadyExists) {
+		t.Errorf("expecting errors.Is(err, ErrDocumentAlreadyExists). Got %s", err)
+	}
+	var errs *astradb.DataAPIErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expecting error of type astradb.DataAPIErrors. Got %s", err)
+	}
+	if len(*errs) != 1 {
+		t.Fatalf("expecting len(errs) to = 1. Got %d", len(*errs))
+	}
+	if code := (*errs)[0].ErrorCode; code != "DOCUMENT_ALREADY_EXISTS" {
+		t.Errorf("expecting Code DOCUMENT_ALREADY_EXISTS. got %v", code)
+	}
+}
+
+// TestCollectionRead runs read-only queries against a shared collection.
+func TestCollectionRead(t *testing.T) {
+	e := harness.New(t)
+	c := simpleCollection(t, e, 30)
+
+	t.Run("Count", func(t *testing.T) {
+		t.Parallel()
+		count, err := c.CountDocuments(context.Background(), filter.Gte("properties.intProperty", 13), 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != 17 {
+			t.Errorf("expected 17 documents, got %d", count)
+		}
+	})
+
+	t.Run("CountUpperBound", func(t *testing.T) {
+		t.Parallel()
+		_, err := c.CountDocuments(context.Background(), nil, 1)
+		if err != results.ErrTooManyDocumentsToCount {
+			t.Errorf("expecting err:%v. Got: %v", results.ErrTooManyDocumentsToCount, err)
+		}
+	})
+
+	t.Run("Find", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		cursor := c.Find(ctx, filter.Gte("properties.intProperty", 20))
+		defer cursor.Close(ctx)
+
+		var documents []SimpleObject
+		if err := cursor.All(ctx, &documents); err != nil {
+			t.Fatal(err)
+		}
+		if len(documents) != 10 {
+			t.Errorf("expected 10 documents with intProperty >= 20, got %d", len(documents))
+		}
+	})
+
+	t.Run("FindOneNotFound", func(t *testing.T) {
+		t.Parallel()
+		result := c.FindOne(context.Background(), filter.F{"_id": "does-not-exist"})
+		if !errors.Is(result.Err(), astradb.ErrNotFound) {
+			t.Errorf("expecting ErrNotFound from Err(). Got %v", result.Err())
+		}
+		var document SimpleObject
+		if err := result.Decode(&document); !errors.Is(err, astradb.ErrNotFound) {
+			t.Errorf("expecting ErrNotFound from Decode(). Got %v", err)
+		}
+	})
+}
+
+func TestCollectionFindOne(t *testing.T) {
+	e := harness.New(t)
+	ctx := context.Background()
+	c := e.Collection(nil)
+	// Generate test data and insert a document
+	original := getSimpleObjects(1)[0]
+	resp, err := c.InsertOne(ctx, original)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Get inserted ID and use it to then find our newly-inserted record
+	insertedID := resp.Status.InsertedIds[0]
+	var document SimpleObject
+	if err := c.FindOne(ctx, filter.F{"_id": insertedID}).Decode(&document); err != nil {
+		t.Fatal(err)
+	}
+	// Before deep equal, set ID on original doc so deep equal succeeds
+	original.ID = insertedID
+	if !reflect.DeepEqual(original, document) {
+		t.Errorf("original != what was selected from DB.\noriginal: %+v\nreturned: %+v", original, document)
+	}
+}
+
+// TestCollectionCursorPagination tests server-side cursor pagination by inserting
+// enough documents to span multiple pages and iterating through them all.
+//
+// The Data API typically returns ~20 documents per page, so we insert 50+
+// documents to ensure we get multiple pages.
+func TestCollectionCursorPagination(t *testing.T) {
+	e := harness.New(t)
+	ctx := context.Background()
+	c := e.Collection(nil)
+
+	// Insert 50 documents
+	const totalDocs = 50
+	docs := make([]map[string]any, totalDocs)
+	for i := 0; i < totalDocs; i++ {
+		docs[i] = map[string]any{
+			"index":     i,
+			"batchId":   "pagination-test",
+			"name":      fmt.Sprintf("Document %d", i),
+			"timestamp": time.Now().UnixNano(),
+		}
+	}
+
+	// Insert in batches
+	batchSize := 20
+	for i := 0; i < len(docs); i += batchSize {
+		end := min(i+batchSize, len(docs))
+		if _, err := c.InsertMany(ctx, docs[i:end]); err != nil {
+			t.Fatalf("failed to insert batch starting at %d: %v", i, err)
+		}
+	}
+
+	// Now use the cursor to iterate through ALL documents
+	cursor := c.Find(ctx, filter.Eq("batchId", "pagination-test"))
+	defer cursor.Close(ctx)
+
+	// Track pagination stats
+	var fetchedDocs []map[string]any
+	pagesFetched := 0
+	docsInCurrentPage := 0
+
+	for cursor.Next(ctx) {
+		var doc map[string]any
+		if err := cursor.Decode(&doc); err != nil {
+			t.Fatalf("failed to decode document: %v", err)
+		}
+		fetchedDocs = append(fetchedDocs, doc)
+		docsInCurrentPage++
+
+		// Check if we just finished a page (remaining batch length is 0 and there's a next page)
+		if cursor.RemainingBatchLength() == 0 {
+			t.Logf("Fetched page %d: %d documents, %d so far, hasNextPage=%v",
+				pagesFetched+1, docsInCurrentPage, len(fetchedDocs), cursor.HasNextPage())
+			pagesFetched++
+			docsInCurrentPage = 0
+		}
+	}
+
+	if err := cursor.Err(); err != nil {
+		t.Fatalf("cursor error: %v", err)
+	}
+
+	// Verify we got all documents
+	if len(fetchedDocs) != totalDocs {
+		t.Fatalf("expected %d documents, got %d", totalDocs, len(fetchedDocs))
+	}
+
+	// Verify we actually had to paginate (more than 1 page)
+	if pagesFetched < 2 {
+		t.Logf("Pagination test may not have tested multiple pages: %d pages for %d documents", pagesFetched, totalDocs)
+	}
+
+	// Verify document indices are all present (no duplicates or missing)
+	seen := make(map[int]bool)
+	for _, doc := range fetchedDocs {
+		idx, ok := doc["index"].(float64) // JSON numbers are float64
+		if !ok {
+			t.Fatalf("document missing or invalid index field: %v", doc)
+		}
+		intIdx := int(idx)
+		if seen[intIdx] {
+			t.Fatalf("duplicate document with index %d", intIdx)
+		}
+		seen[intIdx] = true
+	}
+
+	if len(seen) != totalDocs {
+		t.Errorf("expected %d unique indices, got %d", totalDocs, len(seen))
+	}
+}
+
+func TestCollectionDrop(t *testing.T) {
+	e := harness.New(t)
+	ctx := context.Background()
+	c := e.Collection(nil)
+	if _, err := e.DB.DropCollection(ctx, c.Name()); err != nil {
+		t.Fatal(err)
+	}
+	colls, err := e.DB.ListCollections(ctx)
+	if err != nil {
+		t.Fatalf("ListCollections failed: %v", err)
+	}
+	if slices.ContainsFunc(colls, func(d astradb.CollectionDescriptor) bool { return d.Name == c.Name() }) {
+		t.Errorf("expected %s to be dropped", c.Name())
+	}
+}
+
+// #region Vector Search Integration Tests
+// Based on AstraPy examples from:
+// https://docs.datastax.com/en/astra-db-serverless/api-reference/document-methods/find-many.html
+
+const vectorDimension = 3
+
+// VectorDocument represents a document with vector embeddings
+type VectorDocument struct {
+	ID            string    `json:"_id,omitempty"`
+	Title         string    `json:"title"`
+	Rating        int       `json:"rating"`
+	IsCheckedOut  bool      `json:"is_checked_out"`
+	NumberOfPages int       `json:"number_of_pages"`
+	Vector        []float32 `json:"$vector,omitempty"`
+	Metadata      Metadata  `json:"metadata"`
+}
+
+type Metadata struct {
+	Language string `json:"language"`
+	Genre    string `json:"genre"`
+}
+
+// vectorCollection creates a vector-enabled collection holding test
+// documents with 3-dimensional vectors.
+func vectorCollection(t *testing.T, e *harness.Env) *astradb.Collection {
+	t.Helper()
+	c := e.Collection(&options.CollectionOptions{
+		Vector: &options.VectorOptions{
+			Dimension: vectorDimension,
+			Metric:    "cosine",
+		},
+	})
+
+	// These vectors are designed to have different similarities for testing
+	docs := []VectorDocument{
+		{
+			Title:         "The Great Gatsby",
+			Rating:        5,
+			IsCheckedOut:  false,
+			NumberOfPages: 180,
+			Vector:        []float32{0.1, 0.2, 0.3},
+			Metadata:      Metadata{Language: "English", Genre: "Fiction"},
+		},
+		{
+			Title:         "To Kill a Mockingbird",
+			Rating:        5,
+			IsCheckedOut:  true,
+			NumberOfPages: 281,
+			Vector:        []float32{0.15, 0.25, 0.35}, // Similar to first
+			Metadata:      Metadata{Language: "English", Genre: "Fiction"},
+		},
+		{
+			Title:         "1984",
+			Rating:        4,
+			IsCheckedOut:  false,
+			NumberOfPages: 328,
+			Vector:        []float32{0.9, 0.1, 0.05}, // Different direction
+			Metadata:      Metadata{Language: "English", Genre: "Dystopian"},
+		},
+		{
+			Title:         "Pride and Prejudice",
+			Rating:        4,
+			IsCheckedOut:  false,
+			NumberOfPages: 279,
+			Vector:        []float32{0.12, 0.22, 0.32}, // Very similar to first
+			Metadata:      Metadata{Language: "English", Genre: "Romance"},
+		},
+		{
+			Title:         "The Catcher in the Rye",
+			Rating:        3,
+			IsCheckedOut:  true,
+			NumberOfPages: 234,
+			Vector:        []float32{0.5, 0.5, 0.5}, // Middle ground
+			Metadata:      Metadata{Language: "English", Genre: "Fiction"},
+		},
+		{
+			Title:         "Don Quixote",
+			Rating:        5,
+			IsCheckedOut:  false,
+			NumberOfPages: 863,
+			Vector:        []float32{0.8, 0.2, 0.1}, // Different
+			Metadata:      Metadata{Language: "Spanish", Genre: "Adventure"},
+		},
+	}
+	if _, err := c.InsertMany(context.Background(), docs); err != nil {
+		t.Fatalf("failed to insert vector documents: %v", err)
+	}
+	return c
+}
+
+// TestCollectionVector runs read-only vector search, sort, projection and
+// paging queries against a shared vector collection.
+func TestCollectionVector(t *testing.T) {
+	e := harness.New(t)
+	c := vectorCollection(t, e)
+
+	// Based on: https://docs.datastax.com/en/astra-db-serverless/api-reference/document-methods/find-many.html#example-vector
+	t.Run("Search", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		// Search for documents similar to [0.1, 0.2, 0.3]
+		// This should return "The Great Gatsby" first as it has the exact vector
+		cursor := c.Find(ctx, filter.F{},
+			options.WithCollectionSort(map[string]any{"$vector": []float32{0.1, 0.2, 0.3}}),
+			options.WithCollectionLimit(3),
+		)
+		defer cursor.Close(ctx)
+
+		var results []VectorDocument
+		if err := cursor.All(ctx, &results); err != nil {
+			t.Fatalf("vector search failed: %v", err)
+		}
+		if len(results) == 0 {
+			t.Fatal("vector search returned no results")
+		}
+		// The first result should be "The Great Gatsby" (exact match)
+		if results[0].Title != "The Great Gatsby" {
+			t.Errorf("expected first result to be 'The Great Gatsby', got '%s'", results[0].Title)
+		}
+	})
+
+	// Based on: https://docs.datastax.com/en/astra-db-serverless/api-reference/document-methods/find-many.html#example-similarity
+	t.Run("SearchWithSimilarity", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		cursor := c.Find(ctx, filter.F{},
+			options.WithCollectionSort(map[string]any{"$vector": []float32{0.1, 0.2, 0.3}}),
+			options.WithCollectionIncludeSimilarity(true),
+			options.WithCollectionLimit(3),
+		)
+		defer cursor.Close(ctx)
+
+		// Use map to capture $similarity field
+		var results []map[string]any
+		if err := cursor.All(ctx, &results); err != nil {
+			t.Fatalf("vector search with similarity failed: %v", err)
+		}
+		if len(results) == 0 {
+			t.Fatal("vector search returned no results")
+		}
+
+		// Check that similarity scores are present
+		for i, doc := range results {
+			simFloat, ok := doc["$similarity"].(float64)
+			if !ok {
+				t.Fatalf("document %d has no numeric $similarity: %v", i, doc["$similarity"])
+			}
+			// Similarity should be between 0 and 1 for cosine
+			if simFloat < 0 || simFloat > 1.0001 { // small epsilon for floating point
+				t.Errorf("$similarity out of range [0,1]: %f", simFloat)
+			}
+		}
+
+		// First result should have highest similarity (close to 1.0 for exact match)
+		if first := results[0]["$similarity"].(float64); first < 0.99 {
+			t.Errorf("expected first result to have similarity close to 1.0, got %f", first)
+		}
+	})
+
+	// Based on: https://docs.datastax.com/en/astra-db-serverless/api-reference/document-methods/find-many.html#example-sort
+	t.Run("FindWithSort", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		// Sort by rating ascending, then title descending
+		cursor := c.Find(ctx, filter.Eq("metadata.language", "English"),
+			options.WithCollectionSort(sort.Asc("rating").Desc("title")),
+		)
+		defer cursor.Close(ctx)
+
+		var results []VectorDocument
+		if err := cursor.All(ctx, &results); err != nil {
+			t.Fatalf("sorted find failed: %v", err)
+		}
+		if len(results) < 2 {
+			t.Fatalf("expected at least 2 results, got %d", len(results))
+		}
+
+		// Verify sorting: ratings should be ascending
+		for i := 1; i < len(results); i++ {
+			if results[i].Rating < results[i-1].Rating {
+				t.Errorf("results not sorted by rating ascending: %d < %d at index %d",
+					results[i].Rating, results[i-1].Rating, i)
+			}
+			// If ratings are equal, titles should be descending
+			if results[i].Rating == results[i-1].Rating && results[i].Title > results[i-1].Title {
+				t.Errorf("results not sorted by title descending when rating equal")
+			}
+		}
+	})
+
+	// Based on: https://docs.datastax.com/en/astra-db-serverless/api-reference/document-methods/find-many.html#example-include
+	t.Run("FindWithProjection", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		// Only include title and is_checked_out fields
+		cursor := c.Find(ctx, filter.Eq("metadata.language", "English"),
+			options.WithCollectionProjection(map[string]any{
+				"title":          true,
+				"is_checked_out": true,
+			}),
+		)
+		defer cursor.Close(ctx)
+
+		var results []map[string]any
+		if err := cursor.All(ctx, &results); err != nil {
+			t.Fatalf("projected find failed: %v", err)
+		}
+		if len(results) == 0 {
+			t.Fatal("projection find returned no results")
+		}
+
+		// Verify projection: should have _id, title, is_checked_out but NOT rating, number_of_pages, etc.
+		for i, doc := range results {
+			// _id is always included unless explicitly excluded
+			for _, field := range []string{"_id", "title", "is_checked_out"} {
+				if _, ok := doc[field]; !ok {
+					t.Errorf("document %d missing %s field", i, field)
+				}
+			}
+			// These should NOT be present
+			for _, field := range []string{"rating", "number_of_pages"} {
+				if _, ok := doc[field]; ok {
+					t.Errorf("document %d should not have %s field", i, field)
+				}
+			}
+		}
+	})
+
+	// Based on: https://docs.datastax.com/en/astra-db-serverless/api-reference/document-methods/find-many.html#example-limit
+	t.Run("FindWithLimit", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		limit := 2
+		cursor := c.Find(ctx, filter.Eq("metadata.language", "English"),
+			options.WithCollectionLimit(limit),
+		)
+		defer cursor.Close(ctx)
+
+		var results []VectorDocument
+		if err := cursor.All(ctx, &results); err != nil {
+			t.Fatalf("limited find failed: %v", err)
+		}
+		if len(results) != limit {
+			t.Errorf("expected %d results, got %d", limit, len(results))
+		}
+	})
+
+	// Based on: https://docs.datastax.com/en/astra-db-serverless/api-reference/document-methods/find-many.html#example-skip
+	t.Run("FindWithSkip", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		// Skip requires an explicit sort criterion
+		// First, get all results sorted by rating
+		var allResults []VectorDocument
+		err := c.Find(ctx, filter.Eq("metadata.language", "English"),
+			options.WithCollectionSort(sort.Asc("rating").Asc("title")),
+		).All(ctx, &allResults)
+		if err != nil {
+			t.Fatalf("failed to get all results: %v", err)
+		}
+		if len(allResults) < 3 {
+			t.Fatalf("need at least 3 documents for skip test, got %d", len(allResults))
+		}
+
+		// Now get results with skip=2
+		skip := 2
+		var skipResults []VectorDocument
+		err = c.Find(ctx, filter.Eq("metadata.language", "English"),
+			options.WithCollectionSort(sort.Asc("rating").Asc("title")),
+			options.WithCollectionSkip(skip),
+		).All(ctx, &skipResults)
+		if err != nil {
+			t.Fatalf("skip find failed: %v", err)
+		}
+
+		// Verify that skipResults starts from index 2 of allResults
+		if expectedCount := len(allResults) - skip; len(skipResults) != expectedCount {
+			t.Fatalf("expected %d results after skip, got %d", expectedCount, len(skipResults))
+		}
+		// First result of skipResults should match third result of allResults
+		if skipResults[0].Title != allResults[skip].Title {
+			t.Errorf("skip results don't match: expected '%s', got '%s'",
+				allResults[skip].Title, skipResults[0].Title)
+		}
+	})
+
+	// Based on: https://docs.datastax.com/en/astra-db-serverless/api-reference/document-methods/find-many.html#use-filter-sort-and-projection-together
+	t.Run("FindCombined", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		// Find books not checked out with less than 300 pages
+		// Sort by rating ascending, title descending
+		// Only include title and is_checked_out
+		// Limit to 3 results
+		cursor := c.Find(ctx,
+			filter.And(
+				filter.Eq("is_checked_out", false),
+				filter.Lt("number_of_pages", 300),
+			),
+			options.WithCollectionSort(sort.Asc("rating").Desc("title")),
+			options.WithCollectionProjection(map[string]any{
+				"title":          true,
+				"is_checked_out": true,
+			}),
+			options.WithCollectionLimit(3),
+		)
+		defer cursor.Close(ctx)
+
+		var results []map[string]any
+		if err := cursor.All(ctx, &results); err != nil {
+			t.Fatalf("combined find failed: %v", err)
+		}
+		if len(results) == 0 || len(results) > 3 {
+			t.Fatalf("expected 1 to 3 results, got %d", len(results))
+		}
+		for i, doc := range results {
+			// Should have limited fields
+			if _, ok := doc["rating"]; ok {
+				t.Errorf("document %d should not have rating (projection)", i)
+			}
+			if _, ok := doc["title"]; !ok {
+				t.Errorf("document %d missing title field", i)
+			}
+		}
+	})
+}
+
+// #endregion
@@ -1,77 +1,238 @@
-// Package harness defines a test harness for running integration tests.
+// Package harness provides the environment for the integration tests: a
+// database handle per test, uniquely named collections, tables and
+// keyspaces that are dropped when the test ends, and JUnit and JSON
+// summaries of the run.
+//
+// The suite targets the in-memory emulator from the astradbtest package by
+// default, or a live database when TEST_TARGET is "astra". See the README
+// for the environment variables.
 package harness
 
 import (
-	"log/slog"
-	"sync"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
 
 	"github.com/DeanPDX/dotconfig"
 	astradb "github.com/datastax/astra-db-go"
+	"github.com/datastax/astra-db-go/astradbtest"
 	"github.com/datastax/astra-db-go/options"
 	"github.com/datastax/astra-db-go/results"
+	"github.com/datastax/astra-db-go/table"
+)
+
+// Target is the Data API the suite runs against.
+type Target string
+
+const (
+	// TargetEmulator runs the suite against an in-memory emulator.
+	TargetEmulator Target = "emulator"
+	// TargetAstra runs the suite against the database at API_ENDPOINT.
+	TargetAstra Target = "astra"
 )
 
-// TestEnv represents our test environment.
-type TestEnv struct {
-	APIEndpoint      string `env:"API_ENDPOINT"`
-	ApplicationToken string `env:"APPLICATION_TOKEN"`
-	TestPrefix       string `env:"TEST_PREFIX"`
+// Config is the suite configuration, read from environment variables or a
+// .env file in the working directory.
+type Config struct {
+	Target           Target `env:"TEST_TARGET" default:"emulator"`
+	APIEndpoint      string `env:"API_ENDPOINT,optional"`
+	ApplicationToken string `env:"APPLICATION_TOKEN,optional"`
+	// Keyspace is the keyspace used on Astra. On the emulator every test
+	// gets its own keyspace.
+	Keyspace string `env:"TEST_KEYSPACE,optional"`
+	// JUnitReport and JSONReport are paths the run summaries are written to.
+	JUnitReport string `env:"TEST_JUNIT_REPORT,optional"`
+	JSONReport  string `env:"TEST_JSON_REPORT,optional"`
 }
 
-// Environment() retrieves a test environment with config based on environment variables.
-func Environment() TestEnv {
-	c, err := dotconfig.FromFileName[TestEnv](".env")
+// config is the configuration of the current run, set by [Main].
+var config Config
+
+// loadConfig reads and validates the configuration.
+func loadConfig() (Config, error) {
+	c, err := dotconfig.FromFileName[Config](".env")
 	if err != nil {
-		slog.Error("dotconfig.FromFileName failed", "error", err)
+		return c, err
 	}
-	return c
+	switch c.Target {
+	case TargetEmulator:
+	case TargetAstra:
+		if c.APIEndpoint == "" || c.ApplicationToken == "" {
+			return c, errors.New("TEST_TARGET=astra needs API_ENDPOINT and APPLICATION_TOKEN")
+		}
+	default:
+		return c, fmt.Errorf("unknown TEST_TARGET %q: use %q or %q", c.Target, TargetEmulator, TargetAstra)
+	}
+	return c, nil
 }
 
-// DefaultDb returns a Db handle configured with the test environment settings.
-func (e *TestEnv) DefaultDb() *astradb.Db {
+// Main runs the suite. Call it from TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		harness.Main(m)
+//	}
+func Main(m *testing.M) {
+	os.Exit(run(m))
+}
+
+// run starts the emulator if needed, runs the tests and writes the reports.
+func run(m *testing.M) int {
+	c, err := loadConfig()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "integration tests:", err)
+		return 1
+	}
+	if c.Target == TargetEmulator {
+		srv := astradbtest.NewServer()
+		defer srv.Close()
+		c.APIEndpoint = srv.URL
+		c.ApplicationToken = "emulator"
+	}
+	config = c
+
+	start := time.Now()
+	code := m.Run()
+	if err := writeReports(c, time.Since(start)); err != nil {
+		fmt.Fprintln(os.Stderr, "integration tests:", err)
+		if code == 0 {
+			code = 1
+		}
+	}
+	return code
+}
+
+// Env is the environment of a single test.
+type Env struct {
+	t *testing.T
+
+	// Target is the Data API the test runs against.
+	Target Target
+	// Keyspace is the keyspace the test's resources are created in.
+	Keyspace string
+	// DB is a database handle using Keyspace.
+	DB *astradb.Db
+}
+
+// New returns the environment for t. The test is run in parallel with
+// other tests and its result is recorded for the run summary.
+//
+// On the emulator the test gets a new keyspace, which is dropped when the
+// test ends. On Astra, creating keyspaces is slow, so tests share
+// TEST_KEYSPACE (default_keyspace if unset) and rely on unique names for
+// isolation.
+func New(t *testing.T) *Env {
+	t.Helper()
+	t.Parallel()
+	track(t)
+
+	e := &Env{t: t, Target: config.Target, Keyspace: config.Keyspace}
 	client := astradb.NewClient(
-		options.WithToken(e.ApplicationToken),
+		options.WithToken(config.ApplicationToken),
 		options.WithWarningHandler(func(w results.Warning) {
-			// Add client handler just to make sure it is properly superseded by
-			// DB level handler.
-			slog.Error("Client handler called and should have been superseded by DB handler")
+			t.Errorf("client warning handler called; it should be superseded by the database handler: %s", w.Message)
 		}),
 	)
-	return client.Database(e.APIEndpoint, options.WithWarningHandler(func(w results.Warning) {
-		// Warn and let logs know this came from DB handler. In our tests we will
-		// make sure that collection/table/command level handlers supersede this.
-		slog.Warn("API warning from DB handler", "code", w.ErrorCode, "message", w.Message)
-	}))
+	t.Cleanup(func() { client.Close() })
+	if e.Target == TargetEmulator {
+		e.Keyspace = e.Name()
+		admin := client.Database(config.APIEndpoint).Admin()
+		if err := admin.CreateKeyspace(context.Background(), e.Keyspace); err != nil {
+			t.Fatalf("creating keyspace %s: %v", e.Keyspace, err)
+		}
+		t.Cleanup(func() {
+			if err := admin.DropKeyspace(context.Background(), e.Keyspace); err != nil {
+				t.Errorf("dropping keyspace %s: %v", e.Keyspace, err)
+			}
+		})
+	}
+
+	dbOpts := []options.APIOption{
+		options.WithWarningHandler(func(w results.Warning) {
+			t.Logf("API warning from database handler: %s: %s", w.ErrorCode, w.Message)
+		}),
+	}
+	if e.Keyspace != "" {
+		dbOpts = append(dbOpts, options.WithKeyspace(e.Keyspace))
+	}
+	e.DB = client.Database(config.APIEndpoint, dbOpts...)
+	return e
 }
 
-// An integration test
-type IntegrationTest struct {
-	Name string
-	Run  func(e *TestEnv) error
+// Name returns a unique name for a collection, table or keyspace, derived
+// from the test name and short enough for the Data API's 48 character
+// limit.
+func (e *Env) Name() string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(e.t.Name()) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	base := strings.TrimPrefix(b.String(), "test")
+	if len(base) > 36 {
+		base = base[:36]
+	}
+	suffix := make([]byte, 4)
+	rand.Read(suffix)
+	return "go" + base + "_" + hex.EncodeToString(suffix)
 }
 
-var (
-	testsMu sync.RWMutex // Guards `tests`.
-	tests   = make([]IntegrationTest, 0)
-)
+// Collection creates a uniquely named collection that is dropped when the
+// test ends.
+func (e *Env) Collection(collOpts *options.CollectionOptions) *astradb.Collection {
+	e.t.Helper()
+	name := e.Name()
+	c, err := e.DB.CreateCollection(context.Background(), name, collOpts)
+	if err != nil {
+		e.t.Fatalf("creating collection %s: %v", name, err)
+	}
+	e.t.Cleanup(func() {
+		if _, err := e.DB.DropCollection(context.Background(), name); err != nil {
+			e.t.Errorf("dropping collection %s: %v", name, err)
+		}
+	})
+	return c
+}
 
-// Register adds a test(s) to our test runner. The approach is similar to
-// how [database/sql] allows you to `Register` SQL drivers.
-//
-// [database/sql]: https://cs.opensource.google/go/go/+/refs/tags/go1.25.4:src/database/sql/sql.go;l=36
-func Register(args ...IntegrationTest) {
-	testsMu.Lock()
-	defer testsMu.Unlock()
-	tests = append(tests, args...)
+// Table creates a uniquely named table that is dropped when the test ends.
+func (e *Env) Table(definition table.Definition) *astradb.Table {
+	e.t.Helper()
+	name := e.Name()
+	tbl, err := e.DB.CreateTable(context.Background(), name, definition)
+	if err != nil {
+		e.t.Fatalf("creating table %s: %v", name, err)
+	}
+	e.t.Cleanup(func() {
+		if _, err := e.DB.DropTable(context.Background(), name); err != nil {
+			e.t.Errorf("dropping table %s: %v", name, err)
+		}
+	})
+	return tbl
 }
 
-// Tests returns all registered tests.
-func Tests() []IntegrationTest {
-	// By the time we are running the tests, nothing is adding to this map.
-	// But - creating a copy and guarding with `testsMu` just to be extra safe
-	// because it's not guaranteed future developers will adhere to this.
-	testsMu.Lock()
-	defer testsMu.Unlock()
-	t := tests
-	return t
+// RequireAstra skips the test unless it runs against a live database.
+func (e *Env) RequireAstra(reason string) {
+	e.t.Helper()
+	if e.Target != TargetAstra {
+		e.t.Skipf("needs a live database: %s", reason)
+	}
+}
+
+// WaitForIndexes gives newly created indexes time to become usable on a
+// live database. Querying right after creating an index can otherwise
+// fail, see https://github.com/datastax/astra-db-go/issues/4. It returns
+// immediately on the emulator.
+func (e *Env) WaitForIndexes() {
+	if e.Target == TargetAstra {
+		time.Sleep(2 * time.Second)
+	}
 }
@@ -0,0 +1,182 @@
+package harness
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Test statuses in the run summary.
+const (
+	StatusPass = "pass"
+	StatusFail = "fail"
+	StatusSkip = "skip"
+)
+
+// Result is the outcome of a single test.
+type Result struct {
+	Name    string  `json:"name"`
+	Status  string  `json:"status"`
+	Elapsed float64 `json:"elapsedSeconds"`
+}
+
+// Summary is the JSON summary of a run.
+type Summary struct {
+	Target  Target   `json:"target"`
+	Tests   int      `json:"tests"`
+	Passed  int      `json:"passed"`
+	Failed  int      `json:"failed"`
+	Skipped int      `json:"skipped"`
+	Elapsed float64  `json:"elapsedSeconds"`
+	Results []Result `json:"results"`
+}
+
+var (
+	trackedMu sync.Mutex // Guards `tracked`.
+	tracked   []Result
+)
+
+// track records the result of t when it finishes. It is registered before
+// any resources are created, so it runs after their teardown and counts
+// teardown failures too.
+func track(t *testing.T) {
+	start := time.Now()
+	t.Cleanup(func() {
+		status := StatusPass
+		switch {
+		case t.Failed():
+			status = StatusFail
+		case t.Skipped():
+			status = StatusSkip
+		}
+		trackedMu.Lock()
+		defer trackedMu.Unlock()
+		tracked = append(tracked, Result{
+			Name:    t.Name(),
+			Status:  status,
+			Elapsed: time.Since(start).Seconds(),
+		})
+	})
+}
+
+// summarize returns the summary of the tests recorded so far, in name order.
+func summarize(target Target, elapsed time.Duration) Summary {
+	trackedMu.Lock()
+	defer trackedMu.Unlock()
+	s := Summary{
+		Target:  target,
+		Tests:   len(tracked),
+		Elapsed: elapsed.Seconds(),
+		Results: slices.SortedFunc(slices.Values(tracked), func(a, b Result) int {
+			return strings.Compare(a.Name, b.Name)
+		}),
+	}
+	for _, r := range tracked {
+		switch r.Status {
+		case StatusPass:
+			s.Passed++
+		case StatusFail:
+			s.Failed++
+		case StatusSkip:
+			s.Skipped++
+		}
+	}
+	return s
+}
+
+// JUnit XML elements, as understood by CI systems.
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Skipped  int          `xml:"skipped,attr"`
+	Time     string       `xml:"time,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Skipped  int         `xml:"skipped,attr"`
+	Time     string      `xml:"time,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+}
+
+// junit converts s to a JUnit report with a single suite.
+func junit(s Summary) junitSuites {
+	suite := junitSuite{
+		Name:     "integrationtests (" + string(s.Target) + ")",
+		Tests:    s.Tests,
+		Failures: s.Failed,
+		Skipped:  s.Skipped,
+		Time:     seconds(s.Elapsed),
+	}
+	for _, r := range s.Results {
+		c := junitCase{ClassName: "integrationtests", Name: r.Name, Time: seconds(r.Elapsed)}
+		switch r.Status {
+		case StatusFail:
+			c.Failure = &junitMessage{Message: "test failed, see the test log"}
+		case StatusSkip:
+			c.Skipped = &junitMessage{Message: "test skipped"}
+		}
+		suite.Cases = append(suite.Cases, c)
+	}
+	return junitSuites{
+		Tests:    s.Tests,
+		Failures: s.Failed,
+		Skipped:  s.Skipped,
+		Time:     suite.Time,
+		Suites:   []junitSuite{suite},
+	}
+}
+
+func seconds(f float64) string {
+	return fmt.Sprintf("%.3f", f)
+}
+
+// writeReports writes the summaries requested by c.
+func writeReports(c Config, elapsed time.Duration) error {
+	if c.JUnitReport == "" && c.JSONReport == "" {
+		return nil
+	}
+	s := summarize(c.Target, elapsed)
+	if c.JSONReport != "" {
+		b, err := json.MarshalIndent(s, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(c.JSONReport, append(b, '\n'), 0o644); err != nil {
+			return fmt.Errorf("writing JSON report: %w", err)
+		}
+	}
+	if c.JUnitReport != "" {
+		b, err := xml.MarshalIndent(junit(s), "", "  ")
+		if err != nil {
+			return err
+		}
+		b = append([]byte(xml.Header), append(b, '\n')...)
+		if err := os.WriteFile(c.JUnitReport, b, 0o644); err != nil {
+			return fmt.Errorf("writing JUnit report: %w", err)
+		}
+	}
+	return nil
+}
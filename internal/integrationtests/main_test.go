@@ -0,0 +1,13 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/datastax/astra-db-go/internal/integrationtests/harness"
+)
+
+// TestMain runs the suite against the target chosen by TEST_TARGET. See
+// the README for the environment variables.
+func TestMain(m *testing.M) {
+	harness.Main(m)
+}
@@ -0,0 +1,480 @@
+package integrationtests
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+
+	astradb "github.com/datastax/astra-db-go"
+	"github.com/datastax/astra-db-go/filter"
+	"github.com/datastax/astra-db-go/internal/integrationtests/harness"
+	"github.com/datastax/astra-db-go/options"
+	"github.com/datastax/astra-db-go/results"
+	"github.com/datastax/astra-db-go/table"
+)
+
+// TestBook represents a book for table tests
+type TestBook struct {
+	Title         string   `json:"title"`
+	Author        string   `json:"author"`
+	NumberOfPages int      `json:"number_of_pages"`
+	Rating        float32  `json:"rating"`
+	IsCheckedOut  bool     `json:"is_checked_out"`
+	Genres        []string `json:"genres"`
+}
+
+var bookDefinition = table.Definition{
+	Columns: map[string]table.Column{
+		"title":           table.Text(),
+		"author":          table.Text(),
+		"number_of_pages": table.Int(),
+		"rating":          table.Float(),
+		"is_checked_out":  table.Boolean(),
+		"genres":          table.List(table.Text()),
+	},
+	PrimaryKey: table.PrimaryKey{
+		PartitionBy: []string{"title"},
+	},
+}
+
+var testBooks = []TestBook{
+	{
+		Title:         "The Great Gatsby",
+		Author:        "F. Scott Fitzgerald",
+		NumberOfPages: 180,
+		Rating:        4.5,
+		IsCheckedOut:  false,
+		Genres:        []string{"Fiction", "Classic"},
+	},
+	{
+		Title:         "1984",
+		Author:        "George Orwell",
+		NumberOfPages: 328,
+		Rating:        4.7,
+		IsCheckedOut:  true,
+		Genres:        []string{"Dystopian", "Science Fiction"},
+	},
+	{
+		Title:         "To Kill a Mockingbird",
+		Author:        "Harper Lee",
+		NumberOfPages: 281,
+		Rating:        4.8,
+		IsCheckedOut:  false,
+		Genres:        []string{"Fiction", "Classic"},
+	},
+	{
+		Title:         "Pride and Prejudice",
+		Author:        "Jane Austen",
+		NumberOfPages: 279,
+		Rating:        4.6,
+		IsCheckedOut:  false,
+		Genres:        []string{"Romance", "Classic"},
+	},
+	{
+		Title:         "The Catcher in the Rye",
+		Author:        "J.D. Salinger",
+		NumberOfPages: 234,
+		Rating:        4.0,
+		IsCheckedOut:  true,
+		Genres:        []string{"Fiction", "Coming-of-age"},
+	},
+	{
+		Title:         "Brave New World",
+		Author:        "Aldous Huxley",
+		NumberOfPages: 311,
+		Rating:        4.5,
+		IsCheckedOut:  false,
+		Genres:        []string{"Dystopian", "Science Fiction"},
+	},
+}
+
+// booksTable creates a table holding [testBooks].
+func booksTable(t *testing.T, e *harness.Env) *astradb.Table {
+	t.Helper()
+	tbl := e.Table(bookDefinition)
+	if _, err := tbl.InsertMany(context.Background(), testBooks); err != nil {
+		t.Fatalf("InsertMany failed: %v", err)
+	}
+	return tbl
+}
+
+func TestTableCreate(t *testing.T) {
+	e := harness.New(t)
+	tbl := e.Table(bookDefinition)
+
+	// Creating it again with IfNotExists is a no-op
+	_, err := e.DB.CreateTable(context.Background(), tbl.Name(), bookDefinition, options.WithIfNotExists(true))
+	if err != nil {
+		t.Fatalf("CreateTable with IfNotExists failed: %v", err)
+	}
+	tables, err := e.DB.ListTables(context.Background())
+	if err != nil {
+		t.Fatalf("ListTables failed: %v", err)
+	}
+	if !slices.ContainsFunc(tables, func(d astradb.TableDescriptor) bool { return d.Name == tbl.Name() }) {
+		t.Errorf("expected %s in %+v", tbl.Name(), tables)
+	}
+}
+
+func TestTableInsertOne(t *testing.T) {
+	e := harness.New(t)
+	tbl := e.Table(bookDefinition)
+	book := testBooks[0]
+
+	resp, err := tbl.InsertOne(context.Background(), book)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Status.InsertedIds) != 1 {
+		t.Fatalf("expected 1 inserted ID, got %d", len(resp.Status.InsertedIds))
+	}
+
+	// The API returns insertedIds as an array of arrays - each ID is an array of primary key values
+	// For a single-column primary key like "title", it returns [["The Great Gatsby"]]
+	pkValues, ok := resp.Status.InsertedIds[0].([]any)
+	if !ok {
+		t.Fatalf("expected inserted ID to be []any, got %T", resp.Status.InsertedIds[0])
+	}
+	if len(pkValues) != 1 {
+		t.Fatalf("expected 1 primary key value, got %d", len(pkValues))
+	}
+	if insertedTitle, ok := pkValues[0].(string); !ok || insertedTitle != book.Title {
+		t.Errorf("expected inserted ID %q, got %v", book.Title, pkValues[0])
+	}
+}
+
+func TestTableInsertMany(t *testing.T) {
+	e := harness.New(t)
+	resp, err := e.Table(bookDefinition).InsertMany(context.Background(), testBooks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Status.InsertedIds) != len(testBooks) {
+		t.Errorf("expected %d inserted IDs, got %d", len(testBooks), len(resp.Status.InsertedIds))
+	}
+}
+
+// TestTableRead runs read-only queries against a shared table.
+func TestTableRead(t *testing.T) {
+	e := harness.New(t)
+	tbl := booksTable(t, e)
+
+	t.Run("FindOne", func(t *testing.T) {
+		t.Parallel()
+		var book TestBook
+		if err := tbl.FindOne(context.Background(), filter.Eq("title", "The Great Gatsby")).Decode(&book); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(book, testBooks[0]) {
+			t.Errorf("expected %+v, got %+v", testBooks[0], book)
+		}
+	})
+
+	// Iterating with the Next/Decode pattern
+	t.Run("FindWithCursor", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		cursor := tbl.Find(ctx, filter.F{})
+		defer cursor.Close(ctx)
+
+		var books []TestBook
+		for cursor.Next(ctx) {
+			var book TestBook
+			if err := cursor.Decode(&book); err != nil {
+				t.Fatalf("decode error: %v", err)
+			}
+			books = append(books, book)
+		}
+		if err := cursor.Err(); err != nil {
+			t.Fatalf("cursor error: %v", err)
+		}
+		if len(books) != len(testBooks) {
+			t.Errorf("expected %d books using cursor iteration, got %d", len(testBooks), len(books))
+		}
+	})
+
+	t.Run("FindWithSort", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		// Find books sorted by rating descending using cursor.All()
+		cursor := tbl.Find(ctx, filter.F{},
+			options.WithSort(map[string]any{"rating": options.SortDescending}),
+			options.WithLimit(3),
+		)
+		defer cursor.Close(ctx)
+
+		var books []TestBook
+		if err := cursor.All(ctx, &books); err != nil {
+			t.Fatal(err)
+		}
+		if len(books) != 3 {
+			t.Fatalf("expected 3 books, got %d", len(books))
+		}
+		// Verify books are sorted by rating descending
+		for i := 1; i < len(books); i++ {
+			if books[i].Rating > books[i-1].Rating {
+				t.Errorf("expected books to be sorted by rating descending, but book %q (%.1f) comes after %q (%.1f)",
+					books[i].Title, books[i].Rating, books[i-1].Title, books[i-1].Rating)
+			}
+		}
+	})
+
+	t.Run("FindWithProjection", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		// Find books with only title and author using cursor.All()
+		cursor := tbl.Find(ctx, filter.F{},
+			options.WithProjection(map[string]bool{"title": true, "author": true}),
+			options.WithLimit(1),
+		)
+		defer cursor.Close(ctx)
+
+		var books []map[string]any
+		if err := cursor.All(ctx, &books); err != nil {
+			t.Fatal(err)
+		}
+		if len(books) == 0 {
+			t.Fatal("expected to find at least one book")
+		}
+
+		// Verify only title and author are present
+		book := books[0]
+		if _, ok := book["title"]; !ok {
+			t.Error("expected title to be in projection")
+		}
+		if _, ok := book["author"]; !ok {
+			t.Error("expected author to be in projection")
+		}
+		// Check that other fields are not present (or are zero/nil)
+		if rating, ok := book["rating"]; ok && rating != nil && !reflect.ValueOf(rating).IsZero() {
+			t.Errorf("expected rating to be excluded from projection, got %v", rating)
+		}
+	})
+}
+
+func TestTableFindWarnings(t *testing.T) {
+	e := harness.New(t)
+	e.RequireAstra("the emulator does not report missing index warnings")
+	ctx := context.Background()
+	name := booksTable(t, e).Name()
+
+	warningHandlerRun := false
+	tbl := e.DB.Table(name, options.WithWarningHandler(func(w results.Warning) {
+		warningHandlerRun = true
+	}))
+
+	// Find all books that are not checked out using cursor.All()
+	cursor := tbl.Find(ctx, filter.Eq("is_checked_out", false))
+	defer cursor.Close(ctx)
+
+	var books []TestBook
+	if err := cursor.All(ctx, &books); err != nil {
+		t.Fatal(err)
+	}
+	if len(books) == 0 {
+		t.Fatal("expected to find at least one book")
+	}
+	// Verify all returned books have is_checked_out = false
+	for _, book := range books {
+		if book.IsCheckedOut {
+			t.Errorf("expected is_checked_out to be false for book %q", book.Title)
+		}
+	}
+	if !warningHandlerRun {
+		t.Error("expected warning handler to run but it did not")
+	}
+
+	// We should have a MISSING_INDEX warning because we filtered by a non-indexed column.
+	// TODO: We could be more specific and check for the exact warning code/message. For now,
+	// just ensure we got some warnings. It's unclear if the code might change in the future.
+	if len(cursor.Warnings()) == 0 {
+		t.Error("expected warnings for filtering on non-indexed column but got none")
+	}
+
+	// Next, create index and verify warnings go away
+	if _, err := tbl.CreateIndex(ctx, tbl.Name()+"_idx", "is_checked_out"); err != nil {
+		t.Fatal(err)
+	}
+	e.WaitForIndexes()
+
+	idxCursor := tbl.Find(ctx, filter.Eq("is_checked_out", false))
+	defer idxCursor.Close(ctx)
+	if err := idxCursor.All(ctx, &books); err != nil {
+		t.Fatal(err)
+	}
+	if len(idxCursor.Warnings()) > 0 {
+		t.Errorf("expected no warnings after index creation. Got: %v", idxCursor.Warnings())
+	}
+
+	// Let's double-create that index and make sure it doesn't error out
+	if _, err := tbl.CreateIndex(ctx, tbl.Name()+"_idx", "is_checked_out", options.CreateIndex().SetIfNotExists(true)); err != nil {
+		t.Fatal(err)
+	}
+	// Finally - drop index
+	if _, err := e.DB.DropTableIndex(ctx, tbl.Name()+"_idx"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTableListIndexes(t *testing.T) {
+	e := harness.New(t)
+	ctx := context.Background()
+	tbl := e.Table(bookDefinition)
+
+	// Create an index for testing
+	indexName := tbl.Name() + "_rating"
+	if _, err := tbl.CreateIndex(ctx, indexName, "rating", options.CreateIndex().SetIfNotExists(true)); err != nil {
+		t.Fatalf("failed to create index: %v", err)
+	}
+
+	// Test listing indexes without explain (names only)
+	indexes, err := tbl.ListIndexes(ctx)
+	if err != nil {
+		t.Fatalf("failed to list indexes: %v", err)
+	}
+	if !slices.ContainsFunc(indexes, func(idx astradb.IndexDescriptor) bool { return idx.Name == indexName }) {
+		t.Fatalf("expected to find index %q in %+v", indexName, indexes)
+	}
+
+	// Test listing indexes with explain (full metadata)
+	indexesExplain, err := tbl.ListIndexes(ctx, options.ListIndexes().SetExplain(true))
+	if err != nil {
+		t.Fatalf("failed to list indexes with explain: %v", err)
+	}
+	if len(indexesExplain) != len(indexes) {
+		t.Fatalf("expected %d indexes with explain, got %d", len(indexes), len(indexesExplain))
+	}
+
+	// Find our index and verify metadata
+	for _, idx := range indexesExplain {
+		if idx.Name != indexName {
+			continue
+		}
+		if idx.Definition == nil {
+			t.Fatal("expected definition to be present with explain=true")
+		}
+		if idx.Definition.Column != "rating" {
+			t.Errorf("expected column 'rating', got %q", idx.Definition.Column)
+		}
+		if idx.IndexType != astradb.IndexTypeRegular {
+			t.Errorf("expected indexType 'regular', got %q", idx.IndexType)
+		}
+	}
+
+	// Clean up the index
+	if _, err := e.DB.DropTableIndex(ctx, indexName); err != nil {
+		t.Fatalf("failed to drop index: %v", err)
+	}
+}
+
+// TestDocument represents a document with vector embeddings for vector index tests
+type TestDocument struct {
+	ID        string    `json:"id"`
+	Content   string    `json:"content"`
+	Embedding []float32 `json:"embedding"`
+}
+
+func TestTableVectorIndex(t *testing.T) {
+	e := harness.New(t)
+	ctx := context.Background()
+
+	// Create a table with a vector column
+	tbl := e.Table(table.Definition{
+		Columns: map[string]table.Column{
+			"id":        table.Text(),
+			"content":   table.Text(),
+			"embedding": table.Vector(3), // 3-dimensional vectors for testing
+		},
+		PrimaryKey: table.PrimaryKey{
+			PartitionBy: []string{"id"},
+		},
+	})
+
+	// Create a vector index
+	indexName := tbl.Name() + "_embedding"
+	_, err := tbl.CreateVectorIndex(ctx, indexName, "embedding",
+		options.CreateVectorIndex().
+			SetMetric(options.MetricCosine).
+			SetIfNotExists(true))
+	if err != nil {
+		t.Fatalf("failed to create vector index: %v", err)
+	}
+	e.WaitForIndexes()
+
+	// Insert test documents with embeddings
+	docs := []TestDocument{
+		{ID: "doc1", Content: "The quick brown fox", Embedding: []float32{1.0, 0.0, 0.0}},
+		{ID: "doc2", Content: "Jumped over the lazy dog", Embedding: []float32{0.0, 1.0, 0.0}},
+		{ID: "doc3", Content: "A quick brown dog", Embedding: []float32{0.9, 0.1, 0.0}}, // Similar to doc1
+	}
+	if _, err := tbl.InsertMany(ctx, docs); err != nil {
+		t.Fatalf("failed to insert documents: %v", err)
+	}
+
+	// Test vector similarity search - find documents similar to [1.0, 0.0, 0.0]
+	cursor := tbl.Find(ctx, filter.F{},
+		options.WithSort(map[string]any{"embedding": []float32{1.0, 0.0, 0.0}}),
+		options.WithIncludeSimilarity(true),
+		options.WithLimit(3),
+	)
+	defer cursor.Close(ctx)
+
+	var results []map[string]any
+	if err := cursor.All(ctx, &results); err != nil {
+		t.Fatalf("failed to execute vector search: %v", err)
+	}
+	if len(results) == 0 {
+		t.Fatal("expected to find documents in vector search")
+	}
+	// doc1 has exact match [1.0, 0.0, 0.0], should be first
+	if firstID, _ := results[0]["id"].(string); firstID != "doc1" {
+		t.Errorf("expected first result to be 'doc1' (exact match), got %v", results[0]["id"])
+	}
+	if _, ok := results[0]["$similarity"]; !ok {
+		t.Error("expected $similarity field in results with includeSimilarity=true")
+	}
+
+	// Verify the index appears in ListIndexes with correct type
+	indexes, err := tbl.ListIndexes(ctx, options.ListIndexes().SetExplain(true))
+	if err != nil {
+		t.Fatalf("failed to list indexes: %v", err)
+	}
+	i := slices.IndexFunc(indexes, func(idx astradb.IndexDescriptor) bool { return idx.Name == indexName })
+	if i < 0 {
+		t.Fatalf("expected to find vector index %q in list", indexName)
+	}
+	idx := indexes[i]
+	if idx.IndexType != astradb.IndexTypeVector {
+		t.Errorf("expected indexType 'vector', got %q", idx.IndexType)
+	}
+	if idx.Definition == nil || idx.Definition.Column != "embedding" {
+		t.Errorf("expected definition on column 'embedding', got %+v", idx.Definition)
+	} else if idx.Definition.Options == nil || idx.Definition.Options.Metric != "cosine" {
+		t.Error("expected metric 'cosine' in index options")
+	}
+
+	if _, err := e.DB.DropTableIndex(ctx, indexName); err != nil {
+		t.Fatalf("failed to drop vector index: %v", err)
+	}
+}
+
+func TestTableDrop(t *testing.T) {
+	e := harness.New(t)
+	ctx := context.Background()
+	// Not created with e.Table, which would drop it again on cleanup
+	tbl, err := e.DB.CreateTable(ctx, e.Name(), bookDefinition)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := e.DB.DropTable(ctx, tbl.Name()); err != nil {
+		t.Fatal(err)
+	}
+	tables, err := e.DB.ListTables(ctx)
+	if err != nil {
+		t.Fatalf("ListTables failed: %v", err)
+	}
+	if slices.ContainsFunc(tables, func(d astradb.TableDescriptor) bool { return d.Name == tbl.Name() }) {
+		t.Errorf("expected %s to be dropped", tbl.Name())
+	}
+}
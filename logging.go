@@ -0,0 +1,224 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package astradb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/datastax/astra-db-go/options"
+	"github.com/datastax/astra-db-go/results"
+)
+
+// redacted replaces the values of redacted fields in logged bodies.
+const redacted = "REDACTED"
+
+// minVectorLength is the length from which arrays of numbers are logged as
+// vectors.
+const minVectorLength = 16
+
+// commandLogger logs the requests, responses, retries and warnings of a
+// command. A nil *commandLogger logs nothing.
+type commandLogger struct {
+	logger  *slog.Logger
+	opts    options.LoggingOptions
+	command string
+	redact  [][]string // Split Redact paths
+}
+
+// newCommandLogger returns a logger for the command described by info, or
+// nil if logging is off.
+func newCommandLogger(info *options.CommandInfo) *commandLogger {
+	if info.Options == nil || info.Options.Logger == nil {
+		return nil
+	}
+	l := &commandLogger{
+		logger:  info.Options.Logger,
+		opts:    info.Options.GetLogging(),
+		command: info.Name,
+	}
+	for _, p := range l.opts.Redact {
+		l.redact = append(l.redact, strings.Split(p, "."))
+	}
+	return l
+}
+
+// request logs the command described by info. Its body is only encoded
+// when it is logged.
+func (l *commandLogger) request(ctx context.Context, info *options.CommandInfo) {
+	if l == nil || !l.logger.Enabled(ctx, l.opts.RequestLevel.Level()) {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("command", l.command),
+		slog.String("url", info.URL),
+	}
+	if !l.opts.OmitBodies {
+		body, err := info.MarshalBody()
+		if err != nil {
+			body = []byte(err.Error())
+		}
+		attrs = append(attrs, slog.String("body", l.body(body)))
+	}
+	l.logger.LogAttrs(ctx, l.opts.RequestLevel.Level(), "Data API request", attrs...)
+}
+
+func (l *commandLogger) response(ctx context.Context, status int, elapsed time.Duration, body []byte) {
+	if l == nil || !l.logger.Enabled(ctx, l.opts.ResponseLevel.Level()) {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("command", l.command),
+		slog.Int("status", status),
+		slog.Duration("elapsed", elapsed),
+	}
+	if !l.opts.OmitBodies {
+		attrs = append(attrs, slog.String("body", l.body(body)))
+	}
+	l.logger.LogAttrs(ctx, l.opts.ResponseLevel.Level(), "Data API response", attrs...)
+}
+
+func (l *commandLogger) retry(ctx context.Context, status, attempt int) {
+	if l == nil {
+		return
+	}
+	l.logger.LogAttrs(ctx, l.opts.RetryLevel.Level(), "Data API retry",
+		slog.String("command", l.command),
+		slog.Int("status", status),
+		slog.Int("attempt", attempt))
+}
+
+func (l *commandLogger) warnings(ctx context.Context, warnings results.Warnings) {
+	if l == nil {
+		return
+	}
+	for _, w := range warnings {
+		l.logger.LogAttrs(ctx, l.opts.WarningLevel.Level(), "Data API warning",
+			slog.String("command", l.command),
+			slog.String("code", w.ErrorCode),
+			slog.String("message", w.Message))
+	}
+}
+
+// body returns b for logging, with redacted fields and vectors replaced
+// and truncated to MaxBodyLength. Bodies that aren't JSON are only
+// truncated.
+func (l *commandLogger) body(b []byte) string {
+	s := string(b)
+	if len(l.redact) > 0 || !l.opts.KeepVectors {
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.UseNumber()
+		var v any
+		if err := dec.Decode(&v); err == nil {
+			var buf bytes.Buffer
+			enc := json.NewEncoder(&buf)
+			enc.SetEscapeHTML(false)
+			if err := enc.Encode(l.sanitize(v, nil)); err == nil {
+				s = strings.TrimSuffix(buf.String(), "\n")
+			}
+		}
+	}
+	if limit := l.opts.MaxBodyLength; limit > 0 && len(s) > limit {
+		s = strings.ToValidUTF8(s[:limit], "") + fmt.Sprintf("...(truncated, %d bytes)", len(s))
+	}
+	return s
+}
+
+// sanitize returns v, found at path, with redacted fields and vectors
+// replaced.
+func (l *commandLogger) sanitize(v any, path []string) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, e := range v {
+			p := append(path[:len(path):len(path)], k)
+			switch {
+			case l.isRedacted(p):
+				out[k] = redacted
+			case k == "$vector" && !l.opts.KeepVectors:
+				out[k] = vectorPlaceholder(e)
+			default:
+				out[k] = l.sanitize(e, p)
+			}
+		}
+		return out
+	case []any:
+		if !l.opts.KeepVectors && isVector(v) {
+			return vectorPlaceholder(v)
+		}
+		out := make([]any, len(v))
+		for i, e := range v {
+			out[i] = l.sanitize(e, path)
+		}
+		return out
+	}
+	return v
+}
+
+// isRedacted reports whether the field at path matches a Redact path.
+func (l *commandLogger) isRedacted(path []string) bool {
+	for _, pattern := range l.redact {
+		if matchPath(pattern, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPath reports whether path matches pattern, where "*" matches any
+// one key and "**" any number of keys.
+func matchPath(pattern, path []string) bool {
+	if len(pattern) == 0 {
+		return len(path) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(path); i++ {
+			if matchPath(pattern[1:], path[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(path) == 0 || (pattern[0] != "*" && pattern[0] != path[0]) {
+		return false
+	}
+	return matchPath(pattern[1:], path[1:])
+}
+
+// isVector reports whether v looks like a vector: a long array of numbers.
+func isVector(v []any) bool {
+	if len(v) < minVectorLength {
+		return false
+	}
+	for _, e := range v {
+		if _, ok := e.(json.Number); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// vectorPlaceholder returns the logged form of the vector v.
+func vectorPlaceholder(v any) string {
+	if a, ok := v.([]any); ok {
+		return fmt.Sprintf("<vector of %d>", len(a))
+	}
+	return "<vector>"
+}
@@ -0,0 +1,149 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package astradb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/datastax/astra-db-go/options"
+)
+
+// logLines returns the JSON log records written to buf.
+func logLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var lines []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var m map[string]any
+		if err := json.Unmarshal([]byte(line), &m); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		lines = append(lines, m)
+	}
+	return lines
+}
+
+func TestCommandLogging(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"documents":[{"_id":"1","email":"a@example.com","$vector":[0.1,0.2]}]},"status":{"warnings":[{"errorCode":"MISSING_INDEX","message":"missing"}]}}`))
+	}))
+	defer srv.Close()
+
+	vector := make([]float32, 32)
+	payload := map[string]any{"document": map[string]any{"email": "b@example.com", "name": "Bo", "embedding": vector}}
+
+	t.Run("silent by default", func(t *testing.T) {
+		var buf bytes.Buffer
+		defer slog.SetDefault(slog.Default())
+		slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+		cmd := newCmd(NewClient().Database(srv.URL), "insertOne", payload)
+		if _, _, err := cmd.Execute(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if buf.Len() > 0 {
+			t.Errorf("expected no logs without a logger, got %s", buf.String())
+		}
+	})
+
+	t.Run("redacted", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		db := NewClient(options.WithLogger(logger, options.LoggingOptions{
+			Redact: []string{"**.email"},
+		})).Database(srv.URL)
+
+		cmd := newCmd(db, "insertOne", payload)
+		if _, _, err := cmd.Execute(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		lines := logLines(t, &buf)
+		if len(lines) != 3 {
+			t.Fatalf("expected request, response and warning logs, got %s", buf.String())
+		}
+		req, resp, warning := lines[0], lines[1], lines[2]
+		if req["msg"] != "Data API request" || req["level"] != "DEBUG" || req["command"] != "insertOne" {
+			t.Errorf("unexpected request log %v", req)
+		}
+		body := req["body"].(string)
+		if strings.Contains(body, "b@example.com") || !strings.Contains(body, `"email":"REDACTED"`) {
+			t.Errorf("expected email to be redacted in %s", body)
+		}
+		if !strings.Contains(body, `"embedding":"<vector of 32>"`) || !strings.Contains(body, `"name":"Bo"`) {
+			t.Errorf("expected vector to be elided and other fields kept in %s", body)
+		}
+		body = resp["body"].(string)
+		if strings.Contains(body, "a@example.com") || !strings.Contains(body, `"$vector":"<vector of 2>"`) {
+			t.Errorf("expected response to be redacted in %s", body)
+		}
+		if warning["level"] != "WARN" || warning["code"] != "MISSING_INDEX" {
+			t.Errorf("unexpected warning log %v", warning)
+		}
+	})
+
+	t.Run("levels and truncation", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buf, nil))
+		db := NewClient(options.WithLogger(logger)).Database(srv.URL)
+		coll := db.Collection("books", options.WithLogging(options.LoggingOptions{
+			ResponseLevel: slog.LevelInfo,
+			MaxBodyLength: 20,
+			KeepVectors:   true,
+		}))
+
+		cmd := newCmdWithOptions(db, "books", "find", payload, coll.options)
+		if _, _, err := cmd.Execute(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		lines := logLines(t, &buf)
+		if len(lines) != 2 || lines[0]["msg"] != "Data API response" {
+			t.Fatalf("expected only response and warning logs at info, got %s", buf.String())
+		}
+		body := lines[0]["body"].(string)
+		if !strings.HasPrefix(body, `{"data":{"documents"`) || !strings.Contains(body, "...(truncated, ") {
+			t.Errorf("expected truncated raw body, got %s", body)
+		}
+	})
+}
+
+func TestMatchPath(t *testing.T) {
+	tests := []struct {
+		pattern, path string
+		want          bool
+	}{
+		{"insertOne.document.email", "insertOne.document.email", true},
+		{"insertOne.document.email", "insertMany.document.email", false},
+		{"*.document.email", "insertOne.document.email", true},
+		{"**.email", "email", true},
+		{"**.email", "data.documents.address.email", true},
+		{"**.email", "data.documents.emails", false},
+		{"data.**", "data.documents", true},
+		{"data.*", "data.documents.email", false},
+	}
+	for _, tt := range tests {
+		if got := matchPath(strings.Split(tt.pattern, "."), strings.Split(tt.path, ".")); got != tt.want {
+			t.Errorf("matchPath(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
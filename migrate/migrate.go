@@ -0,0 +1,516 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package migrate applies declarative table and collection schemas to an
+// Astra database.
+//
+// Register the desired tables and collections, then call [Migrator.Plan]
+// for a dry run or [Migrator.Apply] to bring the database in line. The plan
+// is computed against the live schema, so applying is idempotent and
+// resumes where a failed run stopped. Applied versions are recorded in a
+// metadata table.
+//
+// Migrations only ever add: tables, collections and indexes that are not
+// registered are left alone, and destructive alterations (dropping columns
+// or vectorize services) are skipped unless [WithAllowDestructive] is set.
+//
+// Example:
+//
+//	m := migrate.New(db, migrate.WithVersion("2024-06-01"))
+//	m.Table(migrate.Table{
+//		Name: "books",
+//		Definition: table.NewDefinition().
+//			AddTextColumn("title").
+//			AddFloatColumn("rating").
+//			SetPartitionBy("title").
+//			Build(),
+//		Indexes: []migrate.Index{{Name: "books_rating_idx", Column: "rating"}},
+//	})
+//	plan, err := m.Plan(ctx)
+//	fmt.Print(plan) // dry run
+//	_, err = m.Apply(ctx)
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	astradb "github.com/datastax/astra-db-go"
+	"github.com/datastax/astra-db-go/options"
+	"github.com/datastax/astra-db-go/table"
+)
+
+// DefaultMetadataTable is the table applied versions are recorded in.
+const DefaultMetadataTable = "astra_schema_migrations"
+
+// ErrSchemaConflict is returned when the live schema can't be migrated to
+// the desired one, e.g. a collection exists with a different vector dimension.
+var ErrSchemaConflict = errors.New("schema conflict")
+
+// Index is a regular index on a table column.
+type Index struct {
+	Name string
+	// Column is a column name, or a map such as {"tags": "$keys"} for map
+	// keys, as accepted by [astradb.Table.CreateIndex].
+	Column  any
+	Options *options.CreateIndexOptions
+}
+
+// VectorIndex is a vector index on a table column.
+type VectorIndex struct {
+	Name    string
+	Column  string
+	Options *options.CreateVectorIndexOptions
+}
+
+// TextIndex is a text index on a table column, used for lexical search.
+type TextIndex struct {
+	Name    string
+	Column  string
+	Options *options.CreateTextIndexOptions
+}
+
+// Table is the desired state of a table.
+type Table struct {
+	Name          string
+	Definition    table.Definition
+	Indexes       []Index
+	VectorIndexes []VectorIndex
+	TextIndexes   []TextIndex
+}
+
+// Collection is the desired state of a collection. Collections can't be
+// altered, so only missing collections are created.
+type Collection struct {
+	Name    string
+	Options *options.CollectionOptions
+}
+
+// Migrator plans and applies schema migrations for a database.
+type Migrator struct {
+	db               *astradb.Db
+	version          string
+	metadataTable    string
+	allowDestructive bool
+	tables           []Table
+	collections      []Collection
+}
+
+// Option configures a [Migrator].
+type Option func(*Migrator)
+
+// WithVersion sets the version recorded when the migration is applied.
+// Defaults to a checksum of the registered schema.
+func WithVersion(version string) Option {
+	return func(m *Migrator) {
+		m.version = version
+	}
+}
+
+// WithMetadataTable sets the table applied versions are recorded in.
+// Defaults to [DefaultMetadataTable].
+func WithMetadataTable(name string) Option {
+	return func(m *Migrator) {
+		m.metadataTable = name
+	}
+}
+
+// WithAllowDestructive allows plans to drop columns and vectorize services
+// that are not in the desired definitions.
+func WithAllowDestructive(allow bool) Option {
+	return func(m *Migrator) {
+		m.allowDestructive = allow
+	}
+}
+
+// New returns a Migrator for db.
+func New(db *astradb.Db, opts ...Option) *Migrator {
+	m := &Migrator{
+		db:            db,
+		metadataTable: DefaultMetadataTable,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Table registers the desired state of a table.
+func (m *Migrator) Table(t Table) *Migrator {
+	m.tables = append(m.tables, t)
+	return m
+}
+
+// Collection registers the desired state of a collection.
+func (m *Migrator) Collection(c Collection) *Migrator {
+	m.collections = append(m.collections, c)
+	return m
+}
+
+// Checksum returns a checksum of the registered schema.
+func (m *Migrator) Checksum() (string, error) {
+	b, err := json.Marshal(struct {
+		Tables      []Table      `json:"tables"`
+		Collections []Collection `json:"collections"`
+	}{m.tables, m.collections})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+// StepKind is the kind of a migration step.
+type StepKind string
+
+// Migration step kinds.
+const (
+	StepCreateCollection  StepKind = "createCollection"
+	StepCreateTable       StepKind = "createTable"
+	StepAlterTable        StepKind = "alterTable"
+	StepCreateIndex       StepKind = "createIndex"
+	StepCreateVectorIndex StepKind = "createVectorIndex"
+	StepCreateTextIndex   StepKind = "createTextIndex"
+)
+
+// Step is a single change in a [Plan].
+type Step struct {
+	Kind StepKind
+	// Target is the table or collection the step changes.
+	Target string
+	// Description is a human-readable summary of the step.
+	Description string
+	// Skipped is true for destructive steps when [WithAllowDestructive]
+	// is not set. Skipped steps are reported but not applied.
+	Skipped bool
+
+	apply func(ctx context.Context) error
+}
+
+// Plan is the list of steps that migrate the live schema to the desired one.
+type Plan struct {
+	Version  string
+	Checksum string
+	Steps    []Step
+}
+
+// Pending returns the steps that will be applied.
+func (p *Plan) Pending() []Step {
+	var pending []Step
+	for _, s := range p.Steps {
+		if !s.Skipped {
+			pending = append(pending, s)
+		}
+	}
+	return pending
+}
+
+// String formats the plan for a dry run.
+func (p *Plan) String() string {
+	var b strings.Builder
+	if len(p.Steps) == 0 {
+		fmt.Fprintf(&b, "Schema is up to date (version %s)\n", p.Version)
+		return b.String()
+	}
+	fmt.Fprintf(&b, "Schema migration plan (version %s):\n", p.Version)
+	for i, s := range p.Steps {
+		prefix := ""
+		if s.Skipped {
+			prefix = "skipped (destructive): "
+		}
+		fmt.Fprintf(&b, "  %d. %s%s\n", i+1, prefix, s.Description)
+	}
+	return b.String()
+}
+
+// Plan computes the steps needed to migrate the live schema to the
+// registered one without changing anything.
+func (m *Migrator) Plan(ctx context.Context) (*Plan, error) {
+	checksum, err := m.Checksum()
+	if err != nil {
+		return nil, err
+	}
+	plan := &Plan{Version: m.version, Checksum: checksum}
+	if plan.Version == "" {
+		plan.Version = checksum
+	}
+
+	if len(m.collections) > 0 {
+		live, err := m.db.ListCollections(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing collections: %w", err)
+		}
+		for _, c := range m.collections {
+			steps, err := m.planCollection(c, live)
+			if err != nil {
+				return nil, err
+			}
+			plan.Steps = append(plan.Steps, steps...)
+		}
+	}
+
+	if len(m.tables) > 0 {
+		live, err := m.db.ListTables(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing tables: %w", err)
+		}
+		for _, t := range m.tables {
+			steps, err := m.planTable(ctx, t, live)
+			if err != nil {
+				return nil, err
+			}
+			plan.Steps = append(plan.Steps, steps...)
+		}
+	}
+	return plan, nil
+}
+
+// planCollection returns the steps for a single collection.
+func (m *Migrator) planCollection(c Collection, live []astradb.CollectionDescriptor) ([]Step, error) {
+	i := slices.IndexFunc(live, func(d astradb.CollectionDescriptor) bool { return d.Name == c.Name })
+	if i < 0 {
+		return []Step{{
+			Kind:        StepCreateCollection,
+			Target:      c.Name,
+			Description: fmt.Sprintf("create collection %q", c.Name),
+			apply: func(ctx context.Context) error {
+				_, err := m.db.CreateCollection(ctx, c.Name, c.Options)
+				return err
+			},
+		}}, nil
+	}
+	if c.Options == nil || c.Options.Vector == nil {
+		return nil, nil
+	}
+	want, have := c.Options.Vector, live[i].Options.Vector
+	switch {
+	case have == nil:
+		return nil, fmt.Errorf("%w: collection %s exists without vector options", ErrSchemaConflict, c.Name)
+	case want.Dimension != 0 && want.Dimension != have.Dimension:
+		return nil, fmt.Errorf("%w: collection %s has vector dimension %d, want %d", ErrSchemaConflict, c.Name, have.Dimension, want.Dimension)
+	case want.Metric != "" && want.Metric != have.Metric:
+		return nil, fmt.Errorf("%w: collection %s has metric %s, want %s", ErrSchemaConflict, c.Name, have.Metric, want.Metric)
+	}
+	return nil, nil
+}
+
+// planTable returns the steps for a single table and its indexes.
+func (m *Migrator) planTable(ctx context.Context, t Table, live []astradb.TableDescriptor) ([]Step, error) {
+	tbl := m.db.Table(t.Name)
+	var steps []Step
+	existing := map[string]bool{}
+
+	i := slices.IndexFunc(live, func(d astradb.TableDescriptor) bool { return d.Name == t.Name })
+	if i < 0 {
+		steps = append(steps, Step{
+			Kind:        StepCreateTable,
+			Target:      t.Name,
+			Description: fmt.Sprintf("create table %q (%d columns)", t.Name, len(t.Definition.Columns)),
+			apply: func(ctx context.Context) error {
+				_, err := m.db.CreateTable(ctx, t.Name, t.Definition)
+				return err
+			},
+		})
+	} else {
+		ops, err := table.DiffDefinitions(live[i].Definition, t.Definition)
+		if err != nil {
+			return nil, fmt.Errorf("%w: table %s: %w", ErrSchemaConflict, t.Name, err)
+		}
+		for _, op := range ops {
+			steps = append(steps, Step{
+				Kind:        StepAlterTable,
+				Target:      t.Name,
+				Description: fmt.Sprintf("alter table %q: %s", t.Name, describeAlter(op)),
+				Skipped:     isDestructive(op) && !m.allowDestructive,
+				apply: func(ctx context.Context) error {
+					_, err := tbl.Alter(ctx, op)
+					return err
+				},
+			})
+		}
+
+		indexes, err := tbl.ListIndexes(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing indexes of %s: %w", t.Name, err)
+		}
+		for _, idx := range indexes {
+			existing[idx.Name] = true
+		}
+	}
+
+	for _, idx := range t.Indexes {
+		if existing[idx.Name] {
+			continue
+		}
+		steps = append(steps, Step{
+			Kind:        StepCreateIndex,
+			Target:      t.Name,
+			Description: fmt.Sprintf("create index %q on %s(%v)", idx.Name, t.Name, idx.Column),
+			apply: func(ctx context.Context) error {
+				var opts []options.Builder[options.CreateIndexOptions]
+				if idx.Options != nil {
+					opts = append(opts, idx.Options)
+				}
+				_, err := tbl.CreateIndex(ctx, idx.Name, idx.Column, opts...)
+				return err
+			},
+		})
+	}
+	for _, idx := range t.VectorIndexes {
+		if existing[idx.Name] {
+			continue
+		}
+		steps = append(steps, Step{
+			Kind:        StepCreateVectorIndex,
+			Target:      t.Name,
+			Description: fmt.Sprintf("create vector index %q on %s(%s)", idx.Name, t.Name, idx.Column),
+			apply: func(ctx context.Context) error {
+				var opts []options.Builder[options.CreateVectorIndexOptions]
+				if idx.Options != nil {
+					opts = append(opts, idx.Options)
+				}
+				_, err := tbl.CreateVectorIndex(ctx, idx.Name, idx.Column, opts...)
+				return err
+			},
+		})
+	}
+	for _, idx := range t.TextIndexes {
+		if existing[idx.Name] {
+			continue
+		}
+		steps = append(steps, Step{
+			Kind:        StepCreateTextIndex,
+			Target:      t.Name,
+			Description: fmt.Sprintf("create text index %q on %s(%s)", idx.Name, t.Name, idx.Column),
+			apply: func(ctx context.Context) error {
+				var opts []options.Builder[options.CreateTextIndexOptions]
+				if idx.Options != nil {
+					opts = append(opts, idx.Options)
+				}
+				_, err := tbl.CreateTextIndex(ctx, idx.Name, idx.Column, opts...)
+				return err
+			},
+		})
+	}
+	return steps, nil
+}
+
+// isDestructive reports whether op removes columns or services.
+func isDestructive(op table.AlterOperation) bool {
+	switch op.(type) {
+	case table.AlterDrop, table.AlterDropVectorize:
+		return true
+	}
+	return false
+}
+
+// describeAlter summarizes an alter operation.
+func describeAlter(op table.AlterOperation) string {
+	switch op := op.(type) {
+	case table.AlterAdd:
+		return "add columns " + strings.Join(slices.Sorted(maps.Keys(op.Columns)), ", ")
+	case table.AlterDrop:
+		return "drop columns " + strings.Join(op.Columns, ", ")
+	case table.AlterAddVectorize:
+		return "add vectorize to " + strings.Join(slices.Sorted(maps.Keys(op.Columns)), ", ")
+	case table.AlterDropVectorize:
+		return "drop vectorize from " + strings.Join(op.Columns, ", ")
+	}
+	return op.OperationName()
+}
+
+// AppliedVersion is a row of the metadata table.
+type AppliedVersion struct {
+	Version   string    `json:"version"`
+	Checksum  string    `json:"checksum"`
+	AppliedAt time.Time `json:"applied_at"`
+	Steps     int       `json:"steps"`
+}
+
+// metadataDefinition is the definition of the metadata table.
+var metadataDefinition = table.NewDefinition().
+	AddTextColumn("version").
+	AddTextColumn("checksum").
+	AddTimestampColumn("applied_at").
+	AddIntColumn("steps").
+	SetPartitionBy("version").
+	Build()
+
+// Apply computes a plan and applies its pending steps in order, then
+// records the version in the metadata table. Applying a plan with no
+// pending steps for a version that is already recorded does nothing.
+//
+// The plan is returned even on error; steps before the failing one have
+// been applied, and running Apply again resumes from the live state.
+func (m *Migrator) Apply(ctx context.Context) (*Plan, error) {
+	plan, err := m.Plan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := m.db.CreateTable(ctx, m.metadataTable, metadataDefinition, options.WithIfNotExists(true)); err != nil {
+		return plan, fmt.Errorf("creating metadata table: %w", err)
+	}
+
+	pending := plan.Pending()
+	for i, s := range pending {
+		if err := s.apply(ctx); err != nil {
+			return plan, fmt.Errorf("step %d (%s): %w", i+1, s.Description, err)
+		}
+	}
+
+	if len(pending) == 0 {
+		applied, err := m.isApplied(ctx, plan.Version)
+		if err != nil || applied {
+			return plan, err
+		}
+	}
+	_, err = m.db.Table(m.metadataTable).InsertOne(ctx, AppliedVersion{
+		Version:   plan.Version,
+		Checksum:  plan.Checksum,
+		AppliedAt: time.Now().UTC(),
+		Steps:     len(pending),
+	})
+	if err != nil {
+		return plan, fmt.Errorf("recording version %s: %w", plan.Version, err)
+	}
+	return plan, nil
+}
+
+// isApplied reports whether version is recorded in the metadata table.
+func (m *Migrator) isApplied(ctx context.Context, version string) (bool, error) {
+	err := m.db.Table(m.metadataTable).FindOne(ctx, map[string]any{"version": version}).Err()
+	if errors.Is(err, astradb.ErrNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// AppliedVersions returns the versions recorded in the metadata table.
+func (m *Migrator) AppliedVersions(ctx context.Context) ([]AppliedVersion, error) {
+	var versions []AppliedVersion
+	err := m.db.Table(m.metadataTable).Find(ctx, nil).All(ctx, &versions)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(versions, func(a, b AppliedVersion) int { return a.AppliedAt.Compare(b.AppliedAt) })
+	return versions, nil
+}
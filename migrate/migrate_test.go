@@ -0,0 +1,287 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	astradb "github.com/datastax/astra-db-go"
+	"github.com/datastax/astra-db-go/migrate"
+	"github.com/datastax/astra-db-go/options"
+	"github.com/datastax/astra-db-go/table"
+)
+
+// fakeSchema is a minimal Data API that tracks schema state in memory.
+type fakeSchema struct {
+	mu          sync.Mutex
+	tables      map[string]table.Definition
+	indexes     map[string][]string
+	collections map[string]options.CollectionOptions
+	rows        []json.RawMessage
+	commands    []string
+}
+
+func (f *fakeSchema) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var body map[string]json.RawMessage
+	json.NewDecoder(r.Body).Decode(&body)
+	var name string
+	var payload json.RawMessage
+	for name, payload = range body {
+	}
+	f.commands = append(f.commands, name)
+	resource := path.Base(r.URL.Path)
+
+	var args struct {
+		Name       string                    `json:"name"`
+		Definition table.Definition          `json:"definition"`
+		Options    options.CollectionOptions `json:"options"`
+		Operation  map[string]struct {
+			Columns json.RawMessage `json:"columns"`
+		} `json:"operation"`
+		Document json.RawMessage `json:"document"`
+	}
+	json.Unmarshal(payload, &args)
+
+	switch name {
+	case "findCollections":
+		var colls []astradb.CollectionDescriptor
+		for n, o := range f.collections {
+			colls = append(colls, astradb.CollectionDescriptor{Name: n, Options: o})
+		}
+		writeStatus(w, map[string]any{"collections": colls})
+	case "createCollection":
+		f.collections[args.Name] = args.Options
+		writeStatus(w, map[string]any{"ok": 1})
+	case "listTables":
+		var tables []astradb.TableDescriptor
+		for n, d := range f.tables {
+			tables = append(tables, astradb.TableDescriptor{Name: n, Definition: d})
+		}
+		writeStatus(w, map[string]any{"tables": tables})
+	case "createTable":
+		if _, ok := f.tables[args.Name]; !ok {
+			f.tables[args.Name] = args.Definition
+		}
+		writeStatus(w, map[string]any{"ok": 1})
+	case "alterTable":
+		def := f.tables[resource]
+		for op, v := range args.Operation {
+			switch op {
+			case "add":
+				var cols map[string]table.Column
+				json.Unmarshal(v.Columns, &cols)
+				for n, c := range cols {
+					def.Columns[n] = c
+				}
+			case "drop":
+				var cols []string
+				json.Unmarshal(v.Columns, &cols)
+				for _, n := range cols {
+					delete(def.Columns, n)
+				}
+			}
+		}
+		writeStatus(w, map[string]any{"ok": 1})
+	case "listIndexes":
+		writeStatus(w, map[string]any{"indexes": f.indexes[resource]})
+	case "createIndex", "createVectorIndex", "createTextIndex":
+		f.indexes[resource] = append(f.indexes[resource], args.Name)
+		writeStatus(w, map[string]any{"ok": 1})
+	case "insertOne":
+		f.rows = append(f.rows, args.Document)
+		writeStatus(w, map[string]any{"primaryKeySchema": map[string]any{}, "insertedIds": [][]any{{"x"}}})
+	case "findOne":
+		var doc json.RawMessage
+		if len(f.rows) > 0 {
+			doc = f.rows[0]
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"document": doc}})
+	case "find":
+		json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"documents": f.rows, "nextPageState": nil}})
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func writeStatus(w http.ResponseWriter, status map[string]any) {
+	json.NewEncoder(w).Encode(map[string]any{"status": status})
+}
+
+func newFakeDb(t *testing.T, f *fakeSchema) *astradb.Db {
+	t.Helper()
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	return astradb.NewClient(options.WithToken("test-token")).Database(srv.URL)
+}
+
+func booksMigrator(db *astradb.Db, opts ...migrate.Option) *migrate.Migrator {
+	return migrate.New(db, opts...).
+		Collection(migrate.Collection{
+			Name:    "docs",
+			Options: &options.CollectionOptions{Vector: &options.VectorOptions{Dimension: 3}},
+		}).
+		Table(migrate.Table{
+			Name: "books",
+			Definition: table.NewDefinition().
+				AddTextColumn("id").
+				AddTextColumn("title").
+				AddFloatColumn("rating").
+				SetPartitionBy("id").
+				Build(),
+			Indexes:     []migrate.Index{{Name: "books_rating_idx", Column: "rating"}},
+			TextIndexes: []migrate.TextIndex{{Name: "books_title_text", Column: "title"}},
+		})
+}
+
+func TestMigratorPlanAndApply(t *testing.T) {
+	f := &fakeSchema{
+		tables: map[string]table.Definition{
+			"books": table.NewDefinition().
+				AddTextColumn("id").
+				AddTextColumn("title").
+				AddIntColumn("old").
+				SetPartitionBy("id").
+				Build(),
+		},
+		indexes:     map[string][]string{},
+		collections: map[string]options.CollectionOptions{},
+	}
+	db := newFakeDb(t, f)
+	ctx := context.Background()
+	m := booksMigrator(db, migrate.WithVersion("v1"))
+
+	plan, err := m.Plan(ctx)
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	want := `Schema migration plan (version v1):
+  1. create collection "docs"
+  2. alter table "books": add columns rating
+  3. skipped (destructive): alter table "books": drop columns old
+  4. create index "books_rating_idx" on books(rating)
+  5. create text index "books_title_text" on books(title)
+`
+	if plan.String() != want {
+		t.Errorf("unexpected plan:\n%s\nwant:\n%s", plan, want)
+	}
+	if len(plan.Pending()) != 4 {
+		t.Errorf("expected 4 pending steps, got %d", len(plan.Pending()))
+	}
+	if slices.Contains(f.commands, "createCollection") {
+		t.Error("Plan must not change the schema")
+	}
+
+	if _, err := m.Apply(ctx); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if _, ok := f.collections["docs"]; !ok {
+		t.Error("expected docs collection to be created")
+	}
+	if _, ok := f.tables["books"].Columns["rating"]; !ok {
+		t.Error("expected rating column to be added")
+	}
+	if _, ok := f.tables["books"].Columns["old"]; !ok {
+		t.Error("expected destructive drop to be skipped")
+	}
+	if !slices.Contains(f.indexes["books"], "books_rating_idx") || !slices.Contains(f.indexes["books"], "books_title_text") {
+		t.Error("expected indexes to be created")
+	}
+	if _, ok := f.tables[migrate.DefaultMetadataTable]; !ok {
+		t.Error("expected metadata table to be created")
+	}
+	if len(f.rows) != 1 || !strings.Contains(string(f.rows[0]), `"version":"v1"`) {
+		t.Errorf("expected version v1 to be recorded, got %s", f.rows)
+	}
+
+	// Applying again is a no-op
+	f.commands = nil
+	plan, err = m.Apply(ctx)
+	if err != nil {
+		t.Fatalf("second Apply failed: %v", err)
+	}
+	if len(plan.Pending()) != 0 {
+		t.Errorf("expected no pending steps, got %v", plan.Pending())
+	}
+	if len(f.rows) != 1 {
+		t.Errorf("expected version to be recorded once, got %d rows", len(f.rows))
+	}
+	versions, err := m.AppliedVersions(ctx)
+	if err != nil || len(versions) != 1 || versions[0].Version != "v1" || versions[0].Steps != 4 {
+		t.Errorf("unexpected applied versions %+v, %v", versions, err)
+	}
+
+	// Destructive steps run when allowed
+	if _, err := booksMigrator(db, migrate.WithVersion("v2"), migrate.WithAllowDestructive(true)).Apply(ctx); err != nil {
+		t.Fatalf("destructive Apply failed: %v", err)
+	}
+	if _, ok := f.tables["books"].Columns["old"]; ok {
+		t.Error("expected old column to be dropped")
+	}
+}
+
+func TestMigratorConflicts(t *testing.T) {
+	f := &fakeSchema{
+		tables: map[string]table.Definition{
+			"books": table.NewDefinition().AddTextColumn("id").AddIntColumn("title").SetPartitionBy("id").Build(),
+		},
+		indexes: map[string][]string{},
+		collections: map[string]options.CollectionOptions{
+			"docs": {Vector: &options.VectorOptions{Dimension: 3, Metric: "cosine"}},
+		},
+	}
+	db := newFakeDb(t, f)
+	ctx := context.Background()
+
+	_, err := booksMigrator(db).Plan(ctx)
+	if !errors.Is(err, migrate.ErrSchemaConflict) || !errors.Is(err, table.ErrUnsupportedAlteration) {
+		t.Errorf("expected column type conflict, got %v", err)
+	}
+
+	m := migrate.New(db).Collection(migrate.Collection{
+		Name:    "docs",
+		Options: &options.CollectionOptions{Vector: &options.VectorOptions{Dimension: 5}},
+	})
+	if _, err := m.Plan(ctx); !errors.Is(err, migrate.ErrSchemaConflict) {
+		t.Errorf("expected dimension conflict, got %v", err)
+	}
+}
+
+func TestMigratorDefaultVersionIsChecksum(t *testing.T) {
+	f := &fakeSchema{tables: map[string]table.Definition{}, indexes: map[string][]string{}, collections: map[string]options.CollectionOptions{}}
+	m := booksMigrator(newFakeDb(t, f))
+	plan, err := m.Plan(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	checksum, _ := m.Checksum()
+	if plan.Version != checksum || len(checksum) != 16 {
+		t.Errorf("expected checksum version, got %q (checksum %q)", plan.Version, checksum)
+	}
+	if plan.Steps[1].Kind != migrate.StepCreateTable || plan.Steps[2].Kind != migrate.StepCreateIndex {
+		t.Errorf("unexpected steps %+v", plan.Steps)
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"errors"
+	"time"
+)
+
+// AdminOptions configures long-running admin operations such as creating
+// a database or keyspace.
+type AdminOptions struct {
+	// Blocking if true (default), waits until the operation completes, e.g.
+	// until a new database is ACTIVE. If false, returns once the request
+	// has been accepted.
+	Blocking *bool
+
+	// PollInterval is the time between status checks while blocking.
+	PollInterval *time.Duration
+
+	// Timeout overrides the [AdminTimeouts] budget for this operation.
+	Timeout *time.Duration
+}
+
+// List implements Builder[AdminOptions] allowing the raw struct to be
+// passed directly to methods that accept ...Builder[AdminOptions].
+func (o *AdminOptions) List() []func(*AdminOptions) {
+	return NoopBuilder(o)
+}
+
+// Validate implements Validator for AdminOptions.
+func (o AdminOptions) Validate() error {
+	if o.PollInterval != nil && *o.PollInterval <= 0 {
+		return errors.New("poll interval must be positive")
+	}
+	return nil
+}
+
+// IsBlocking returns whether the operation should block (default true).
+func (o *AdminOptions) IsBlocking() bool {
+	return o == nil || o.Blocking == nil || *o.Blocking
+}
+
+// GetPollInterval returns the poll interval or def if not set.
+func (o *AdminOptions) GetPollInterval(def time.Duration) time.Duration {
+	if o == nil || o.PollInterval == nil {
+		return def
+	}
+	return *o.PollInterval
+}
+
+// GetTimeout returns the timeout or def if not set.
+func (o *AdminOptions) GetTimeout(def time.Duration) time.Duration {
+	if o == nil || o.Timeout == nil {
+		return def
+	}
+	return *o.Timeout
+}
+
+// AdminOptionsBuilder is a builder for AdminOptions that implements
+// Builder[AdminOptions] following the MongoDB Go driver pattern.
+type AdminOptionsBuilder struct {
+	Opts []func(*AdminOptions)
+}
+
+// Admin creates a new AdminOptionsBuilder.
+func Admin() *AdminOptionsBuilder {
+	return &AdminOptionsBuilder{}
+}
+
+// List implements Builder[AdminOptions].
+func (b *AdminOptionsBuilder) List() []func(*AdminOptions) {
+	return b.Opts
+}
+
+// SetBlocking sets whether the operation waits for completion.
+func (b *AdminOptionsBuilder) SetBlocking(v bool) *AdminOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *AdminOptions) {
+		o.Blocking = &v
+	})
+	return b
+}
+
+// SetPollInterval sets the time between status checks while blocking.
+func (b *AdminOptionsBuilder) SetPollInterval(d time.Duration) *AdminOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *AdminOptions) {
+		o.PollInterval = &d
+	})
+	return b
+}
+
+// SetTimeout sets the total time budget for a blocking operation.
+func (b *AdminOptionsBuilder) SetTimeout(d time.Duration) *AdminOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *AdminOptions) {
+		o.Timeout = &d
+	})
+	return b
+}
+
+// CreateDatabaseOptions describes a database to create with the DevOps API.
+type CreateDatabaseOptions struct {
+	// Name is the database name. Required.
+	Name string `json:"name"`
+
+	// CloudProvider is one of "AWS", "GCP" or "AZURE". Required.
+	CloudProvider string `json:"cloudProvider"`
+
+	// Region is the cloud region, e.g. "us-east1". Required.
+	Region string `json:"region"`
+
+	// Keyspace is the initial keyspace. Defaults to "default_keyspace".
+	Keyspace string `json:"keyspace,omitempty"`
+
+	// Tier defaults to "serverless".
+	Tier string `json:"tier,omitempty"`
+
+	// CapacityUnits defaults to 1.
+	CapacityUnits int `json:"capacityUnits,omitempty"`
+
+	// DBType is "vector" for vector-enabled databases (the default) or
+	// empty for a classic serverless database.
+	DBType string `json:"dbType,omitempty"`
+}
+
+// Validate checks that required fields are set.
+func (o CreateDatabaseOptions) Validate() error {
+	if o.Name == "" {
+		return errors.New("database name cannot be empty")
+	}
+	if o.CloudProvider == "" {
+		return errors.New("cloud provider cannot be empty")
+	}
+	if o.Region == "" {
+		return errors.New("region cannot be empty")
+	}
+	return nil
+}
+
+// ListDatabasesOptions represents options for listing databases.
+type ListDatabasesOptions struct {
+	// Include filters by status: "nonterminated" (default), "all", "active",
+	// "pending", "preparing", "prepared", "initializing", "parking", "parked",
+	// "unparking", "terminating", "terminated", "resizing", "error" or "maintenance".
+	Include *string
+
+	// Provider filters by cloud provider: "ALL" (default), "AWS", "GCP" or "AZURE".
+	Provider *string
+
+	// Limit is the maximum number of databases to return.
+	Limit *int
+}
+
+// List implements Builder[ListDatabasesOptions] allowing the raw struct to be
+// passed directly to methods that accept ...Builder[ListDatabasesOptions].
+func (o *ListDatabasesOptions) List() []func(*ListDatabasesOptions) {
+	return NoopBuilder(o)
+}
+
+// Validate implements Validator for ListDatabasesOptions.
+func (o ListDatabasesOptions) Validate() error {
+	if o.Limit != nil && *o.Limit <= 0 {
+		return errors.New("limit must be positive")
+	}
+	return nil
+}
+
+// ListDatabasesOptionsBuilder is a builder for ListDatabasesOptions that implements
+// Builder[ListDatabasesOptions] following the MongoDB Go driver pattern.
+type ListDatabasesOptionsBuilder struct {
+	Opts []func(*ListDatabasesOptions)
+}
+
+// ListDatabases creates a new ListDatabasesOptionsBuilder.
+func ListDatabases() *ListDatabasesOptionsBuilder {
+	return &ListDatabasesOptionsBuilder{}
+}
+
+// List implements Builder[ListDatabasesOptions].
+func (b *ListDatabasesOptionsBuilder) List() []func(*ListDatabasesOptions) {
+	return b.Opts
+}
+
+// SetInclude filters databases by status.
+func (b *ListDatabasesOptionsBuilder) SetInclude(v string) *ListDatabasesOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *ListDatabasesOptions) {
+		o.Include = &v
+	})
+	return b
+}
+
+// SetProvider filters databases by cloud provider.
+func (b *ListDatabasesOptionsBuilder) SetProvider(v string) *ListDatabasesOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *ListDatabasesOptions) {
+		o.Provider = &v
+	})
+	return b
+}
+
+// SetLimit sets the maximum number of databases to return.
+func (b *ListDatabasesOptionsBuilder) SetLimit(v int) *ListDatabasesOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *ListDatabasesOptions) {
+		o.Limit = &v
+	})
+	return b
+}
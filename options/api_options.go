@@ -15,6 +15,8 @@
 package options
 
 import (
+	"context"
+	"log/slog"
 	"net/http"
 	"time"
 
@@ -28,13 +30,20 @@ type APIOptions struct {
 	// Token is the authentication token for Astra DB
 	Token *string
 
+	// TokenProvider supplies tokens dynamically. Takes precedence over Token.
+	TokenProvider TokenProvider
+
+	// Environment is the kind of Data API deployment. Defaults to Astra.
+	Environment *Environment
+
 	// Keyspace is the keyspace to use for operations
 	Keyspace *string
 
 	// APIVersion is the Data API version (e.g., "v1")
 	APIVersion *string
 
-	// HTTPClient is the HTTP client to use for requests
+	// HTTPClient is the HTTP client to use for requests. If unset, requests
+	// use the shared client of the DataAPIClient.
 	HTTPClient *http.Client
 
 	// Headers contains custom headers to include in requests
@@ -50,27 +59,50 @@ type APIOptions struct {
 	// WarningHandler is called for each warning received from the API.
 	// Set this at any level (Client, Database, Collection/Table, or Command).
 	WarningHandler WarningHandler
+
+	// StrictWarnings promotes warnings to errors. Nil disables strict mode.
+	StrictWarnings *StrictWarningsOptions
+
+	// Logger receives logs of commands and their responses. Nil disables
+	// logging.
+	Logger *slog.Logger
+
+	// Logging configures what is logged to Logger.
+	Logging *LoggingOptions
+
+	// Interceptors wrap the execution of each command. Interceptors from
+	// all layers are chained, outermost first.
+	Interceptors []CommandInterceptor
+
+	// AstraEnvironment selects the Astra DevOps API used for admin operations.
+	AstraEnvironment *DBEnvironment
+
+	// AdminEndpoint overrides the DevOps API URL derived from AstraEnvironment.
+	AdminEndpoint *string
+
+	// AdminTimeouts contains timeout budgets for blocking admin operations
+	AdminTimeouts *AdminTimeouts
+}
+
+// StrictWarningsOptions configures which warnings are treated as errors.
+type StrictWarningsOptions struct {
+	// Codes lists the warning codes (e.g. [results.WarningMissingIndex])
+	// that are promoted to errors. If empty, every warning is promoted.
+	Codes []string
 }
 
 // TimeoutOptions contains timeout configuration for API operations.
 type TimeoutOptions struct {
 	// Request is the timeout for individual HTTP requests
 	Request *time.Duration
-	// Connection is the timeout for establishing connections
+	// Connection is the timeout for establishing connections, including
+	// the TLS handshake. It only applies to the client's shared transport,
+	// and only when set on the client.
 	Connection *time.Duration
 	// BulkOperation is the timeout for bulk operations like insertMany
 	BulkOperation *time.Duration
 }
 
-// SerdesOptions contains options for serialization and deserialization behavior.
-// This is a placeholder for future extensibility.
-type SerdesOptions struct {
-	// Future options:
-	// - Custom date/time handling
-	// - Map encoding modes
-	// - Custom type converters
-}
-
 // WarningHandler is a callback function invoked for each warning in API responses.
 // Warnings indicate non-fatal conditions such as missing indexes or deprecated features.
 type WarningHandler func(w results.Warning)
@@ -82,14 +114,12 @@ type APIOption func(*APIOptions)
 // DefaultAPIOptions returns the default options used as the base for merging.
 func DefaultAPIOptions() *APIOptions {
 	apiVersion := "v1"
-	keyspace := "default_keyspace"
-	httpClient := &http.Client{}
 	requestTimeout := 30 * time.Second
 
+	// HTTPClient is left unset so commands use the shared client of their
+	// DataAPIClient
 	return &APIOptions{
 		APIVersion: &apiVersion,
-		Keyspace:   &keyspace,
-		HTTPClient: httpClient,
 		Headers:    make(map[string]string),
 		Timeout: &TimeoutOptions{
 			Request: &requestTimeout,
@@ -122,6 +152,12 @@ func Merge(layers ...*APIOptions) *APIOptions {
 		if layer.Token != nil {
 			result.Token = layer.Token
 		}
+		if layer.TokenProvider != nil {
+			result.TokenProvider = layer.TokenProvider
+		}
+		if layer.Environment != nil {
+			result.Environment = layer.Environment
+		}
 		if layer.Keyspace != nil {
 			result.Keyspace = layer.Keyspace
 		}
@@ -167,11 +203,75 @@ func Merge(layers ...*APIOptions) *APIOptions {
 		if layer.WarningHandler != nil {
 			result.WarningHandler = layer.WarningHandler
 		}
+
+		// Merge strict warnings (later layers override)
+		if layer.StrictWarnings != nil {
+			result.StrictWarnings = layer.StrictWarnings
+		}
+
+		// Merge logging options (later layers override)
+		if layer.Logger != nil {
+			result.Logger = layer.Logger
+		}
+		if layer.Logging != nil {
+			result.Logging = layer.Logging
+		}
+
+		// Chain interceptors (earlier layers run outermost)
+		result.Interceptors = append(result.Interceptors, layer.Interceptors...)
+
+		// Merge admin options
+		if layer.AstraEnvironment != nil {
+			result.AstraEnvironment = layer.AstraEnvironment
+		}
+		if layer.AdminEndpoint != nil {
+			result.AdminEndpoint = layer.AdminEndpoint
+		}
+		if layer.AdminTimeouts != nil {
+			if result.AdminTimeouts == nil {
+				result.AdminTimeouts = &AdminTimeouts{}
+			}
+			if layer.AdminTimeouts.Collection != nil {
+				result.AdminTimeouts.Collection = layer.AdminTimeouts.Collection
+			}
+			if layer.AdminTimeouts.Table != nil {
+				result.AdminTimeouts.Table = layer.AdminTimeouts.Table
+			}
+			if layer.AdminTimeouts.Database != nil {
+				result.AdminTimeouts.Database = layer.AdminTimeouts.Database
+			}
+			if layer.AdminTimeouts.Keyspace != nil {
+				result.AdminTimeouts.Keyspace = layer.AdminTimeouts.Keyspace
+			}
+		}
 	}
 
 	return result
 }
 
+// Clone returns a copy of o that can be modified without affecting o: its
+// headers, timeouts and interceptors are copied too.
+func (o *APIOptions) Clone() *APIOptions {
+	if o == nil {
+		return NewAPIOptions()
+	}
+	c := *o
+	c.Headers = make(map[string]string, len(o.Headers))
+	for k, v := range o.Headers {
+		c.Headers[k] = v
+	}
+	if o.Timeout != nil {
+		t := *o.Timeout
+		c.Timeout = &t
+	}
+	if o.AdminTimeouts != nil {
+		t := *o.AdminTimeouts
+		c.AdminTimeouts = &t
+	}
+	c.Interceptors = o.Interceptors[:len(o.Interceptors):len(o.Interceptors)]
+	return &c
+}
+
 // WithToken sets the authentication token.
 func WithToken(token string) APIOption {
 	return func(o *APIOptions) {
@@ -179,6 +279,28 @@ func WithToken(token string) APIOption {
 	}
 }
 
+// WithTokenProvider sets a provider that supplies the token for each
+// request, overriding any token set with [WithToken].
+func WithTokenProvider(p TokenProvider) APIOption {
+	return func(o *APIOptions) {
+		o.TokenProvider = p
+	}
+}
+
+// WithEnvironment sets the kind of Data API deployment.
+//
+// Example:
+//
+//	client := astradb.NewClient(
+//		options.WithEnvironment(options.EnvironmentHCD),
+//		options.WithTokenProvider(options.NewUsernamePasswordTokenProvider("cassandra", "cassandra")),
+//	)
+func WithEnvironment(env Environment) APIOption {
+	return func(o *APIOptions) {
+		o.Environment = &env
+	}
+}
+
 // WithKeyspace sets the keyspace.
 func WithKeyspace(keyspace string) APIOption {
 	return func(o *APIOptions) {
@@ -278,6 +400,61 @@ func WithWarningHandler(handler WarningHandler) APIOption {
 	}
 }
 
+// WithStrictWarnings makes commands fail when the API returns any of the
+// given warning codes, or any warning at all if no codes are given. The
+// command still returns the response body for inspection alongside the
+// error, which matches [astradb.WarningError] with errors.As.
+//
+// Example usage:
+//
+//	// Fail queries in CI that can't use an index or sort on disk
+//	client := astradb.NewClient(
+//		options.WithToken("..."),
+//		options.WithStrictWarnings(results.WarningMissingIndex, results.WarningInMemorySorting),
+//	)
+func WithStrictWarnings(codes ...string) APIOption {
+	return func(o *APIOptions) {
+		o.StrictWarnings = &StrictWarningsOptions{Codes: codes}
+	}
+}
+
+// WithAstraEnvironment sets the Astra environment used for admin operations.
+func WithAstraEnvironment(env DBEnvironment) APIOption {
+	return func(o *APIOptions) {
+		o.AstraEnvironment = &env
+	}
+}
+
+// WithAdminEndpoint overrides the DevOps API URL used for admin operations.
+// This is mostly useful for testing against a stand-in server.
+func WithAdminEndpoint(url string) APIOption {
+	return func(o *APIOptions) {
+		o.AdminEndpoint = &url
+	}
+}
+
+// WithDatabaseAdminTimeout sets the budget for blocking database admin
+// operations, such as waiting for a new database to become active.
+func WithDatabaseAdminTimeout(d time.Duration) APIOption {
+	return func(o *APIOptions) {
+		if o.AdminTimeouts == nil {
+			o.AdminTimeouts = &AdminTimeouts{}
+		}
+		o.AdminTimeouts.Database = &d
+	}
+}
+
+// WithKeyspaceAdminTimeout sets the budget for blocking keyspace admin
+// operations, such as waiting for a new keyspace to be created.
+func WithKeyspaceAdminTimeout(d time.Duration) APIOption {
+	return func(o *APIOptions) {
+		if o.AdminTimeouts == nil {
+			o.AdminTimeouts = &AdminTimeouts{}
+		}
+		o.AdminTimeouts.Keyspace = &d
+	}
+}
+
 // Helper functions for getting values with defaults
 
 // GetToken returns the token or empty string if not set.
@@ -288,10 +465,44 @@ func (o *APIOptions) GetToken() string {
 	return *o.Token
 }
 
-// GetKeyspace returns the keyspace or "default_keyspace" if not set.
+// ResolveToken returns the token from TokenProvider if set, otherwise the
+// static token. The token is returned as the provider gave it, so it can
+// be passed back to [APIOptions.InvalidateToken]; format it with
+// [Environment.FormatToken] before sending it.
+func (o *APIOptions) ResolveToken(ctx context.Context) (string, error) {
+	if o != nil && o.TokenProvider != nil {
+		return o.TokenProvider.Token(ctx)
+	}
+	return o.GetToken(), nil
+}
+
+// InvalidateToken tells the TokenProvider that token was rejected. It
+// reports whether the provider supports invalidation, in which case a
+// retry may succeed with a fresh token.
+func (o *APIOptions) InvalidateToken(token string) bool {
+	if o == nil {
+		return false
+	}
+	inv, ok := o.TokenProvider.(TokenInvalidator)
+	if ok {
+		inv.InvalidateToken(token)
+	}
+	return ok
+}
+
+// GetEnvironment returns the environment or [EnvironmentAstra] if not set.
+func (o *APIOptions) GetEnvironment() Environment {
+	if o == nil || o.Environment == nil {
+		return EnvironmentAstra
+	}
+	return *o.Environment
+}
+
+// GetKeyspace returns the keyspace or, if not set, the environment's
+// default keyspace ("default_keyspace" on Astra).
 func (o *APIOptions) GetKeyspace() string {
 	if o == nil || o.Keyspace == nil {
-		return "default_keyspace"
+		return o.GetEnvironment().DefaultKeyspace()
 	}
 	return *o.Keyspace
 }
@@ -304,10 +515,10 @@ func (o *APIOptions) GetAPIVersion() string {
 	return *o.APIVersion
 }
 
-// GetHTTPClient returns the HTTP client or a default client if not set.
+// GetHTTPClient returns the HTTP client or [http.DefaultClient] if not set.
 func (o *APIOptions) GetHTTPClient() *http.Client {
 	if o == nil || o.HTTPClient == nil {
-		return &http.Client{}
+		return http.DefaultClient
 	}
 	return o.HTTPClient
 }
@@ -319,3 +530,56 @@ func (o *APIOptions) GetRequestTimeout() time.Duration {
 	}
 	return *o.Timeout.Request
 }
+
+// GetConnectionTimeout returns the connection timeout or 10s if not set.
+func (o *APIOptions) GetConnectionTimeout() time.Duration {
+	if o == nil || o.Timeout == nil || o.Timeout.Connection == nil {
+		return 10 * time.Second
+	}
+	return *o.Timeout.Connection
+}
+
+// IsStrictWarning reports whether a warning with the given code should be
+// promoted to an error.
+func (o *APIOptions) IsStrictWarning(code string) bool {
+	if o == nil || o.StrictWarnings == nil {
+		return false
+	}
+	if len(o.StrictWarnings.Codes) == 0 {
+		return true
+	}
+	for _, c := range o.StrictWarnings.Codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// GetAdminEndpoint returns the DevOps API URL: AdminEndpoint if set,
+// otherwise the URL of AstraEnvironment (production by default).
+func (o *APIOptions) GetAdminEndpoint() string {
+	if o != nil && o.AdminEndpoint != nil {
+		return *o.AdminEndpoint
+	}
+	if o == nil || o.AstraEnvironment == nil {
+		return EnvironmentProduction.URL()
+	}
+	return o.AstraEnvironment.URL()
+}
+
+// GetDatabaseAdminTimeout returns the database admin timeout or 10m if not set.
+func (o *APIOptions) GetDatabaseAdminTimeout() time.Duration {
+	if o == nil || o.AdminTimeouts == nil || o.AdminTimeouts.Database == nil {
+		return 10 * time.Minute
+	}
+	return *o.AdminTimeouts.Database
+}
+
+// GetKeyspaceAdminTimeout returns the keyspace admin timeout or 30s if not set.
+func (o *APIOptions) GetKeyspaceAdminTimeout() time.Duration {
+	if o == nil || o.AdminTimeouts == nil || o.AdminTimeouts.Keyspace == nil {
+		return 30 * time.Second
+	}
+	return *o.AdminTimeouts.Keyspace
+}
@@ -15,11 +15,17 @@
 package options_test
 
 import (
+	"context"
+	"io"
+	"log/slog"
 	"net/http"
+	"slices"
 	"testing"
 	"time"
 
+	"github.com/datastax/astra-db-go/codec"
 	"github.com/datastax/astra-db-go/options"
+	"github.com/datastax/astra-db-go/results"
 )
 
 func TestDefaultAPIOptions(t *testing.T) {
@@ -201,6 +207,9 @@ func TestGetters_NilSafety(t *testing.T) {
 	if nilOpts.GetRequestTimeout() != 30*time.Second {
 		t.Error("expected default timeout for nil options")
 	}
+	if nilOpts.GetConnectionTimeout() != 10*time.Second {
+		t.Error("expected default connection timeout for nil options")
+	}
 }
 
 func TestMerge_FullHierarchy(t *testing.T) {
@@ -293,3 +302,182 @@ func TestWithHeaders(t *testing.T) {
 		t.Error("expected header 2 to be set")
 	}
 }
+
+func TestMerge_StrictWarnings(t *testing.T) {
+	clientOpts := options.NewAPIOptions(options.WithStrictWarnings())
+	collOpts := options.NewAPIOptions(options.WithStrictWarnings("MISSING_INDEX"))
+
+	result := options.Merge(clientOpts, nil)
+	if !result.IsStrictWarning("ANYTHING") {
+		t.Error("expected all warnings to be strict when no codes are given")
+	}
+
+	result = options.Merge(clientOpts, collOpts)
+	if !result.IsStrictWarning("MISSING_INDEX") {
+		t.Error("expected MISSING_INDEX to be strict")
+	}
+	if result.IsStrictWarning("ZERO_FILTER_OPERATIONS") {
+		t.Error("expected later layer to narrow strict codes")
+	}
+
+	result = options.Merge(nil)
+	if result.IsStrictWarning("MISSING_INDEX") {
+		t.Error("expected strict warnings to be disabled by default")
+	}
+}
+
+func TestMerge_Environment(t *testing.T) {
+	result := options.Merge(nil)
+	if result.GetEnvironment() != options.EnvironmentAstra {
+		t.Errorf("expected astra by default, got %q", result.GetEnvironment())
+	}
+
+	clientOpts := options.NewAPIOptions(options.WithEnvironment(options.EnvironmentHCD))
+	result = options.Merge(clientOpts)
+	if result.GetKeyspace() != "" {
+		t.Errorf("expected no default keyspace for hcd, got %q", result.GetKeyspace())
+	}
+
+	dbOpts := options.NewAPIOptions(options.WithKeyspace("ks"))
+	result = options.Merge(clientOpts, dbOpts)
+	if result.GetKeyspace() != "ks" {
+		t.Errorf("expected keyspace ks, got %q", result.GetKeyspace())
+	}
+}
+
+func TestEnvironment_FormatToken(t *testing.T) {
+	tests := []struct {
+		env   options.Environment
+		token string
+		want  string
+	}{
+		{options.EnvironmentAstra, "AstraCS:abc:def", "AstraCS:abc:def"},
+		{options.EnvironmentHCD, "cassandra:cassandra", "Cassandra:Y2Fzc2FuZHJh:Y2Fzc2FuZHJh"},
+		{options.EnvironmentDSE, "Cassandra:Y2Fzc2FuZHJh:Y2Fzc2FuZHJh", "Cassandra:Y2Fzc2FuZHJh:Y2Fzc2FuZHJh"},
+		{options.EnvironmentOther, "opaque", "opaque"},
+	}
+	for _, tt := range tests {
+		if got := tt.env.FormatToken(tt.token); got != tt.want {
+			t.Errorf("%s: FormatToken(%q) = %q, want %q", tt.env, tt.token, got, tt.want)
+		}
+	}
+
+	// ResolveToken leaves formatting to the request
+	opts := options.NewAPIOptions(options.WithEnvironment(options.EnvironmentHCD), options.WithToken("cassandra:cassandra"))
+	if got, _ := opts.ResolveToken(context.Background()); got != "cassandra:cassandra" {
+		t.Errorf("expected ResolveToken to return the raw token, got %q", got)
+	}
+}
+
+func TestEnvironment_Supports(t *testing.T) {
+	for _, f := range []options.Feature{options.FeatureDevOpsAPI, options.FeatureVectorize, options.FeatureReranking} {
+		if !options.EnvironmentAstra.Supports(f) {
+			t.Errorf("expected astra to support %s", f)
+		}
+		if options.EnvironmentHCD.Supports(f) {
+			t.Errorf("expected hcd not to support %s", f)
+		}
+	}
+}
+
+func TestUsernamePasswordTokenProvider(t *testing.T) {
+	p := options.NewUsernamePasswordTokenProvider("cassandra", "s3cret")
+	opts := options.NewAPIOptions(
+		options.WithToken("ignored"),
+		options.WithTokenProvider(p),
+	)
+	token, err := opts.ResolveToken(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "Cassandra:Y2Fzc2FuZHJh:czNjcmV0" {
+		t.Errorf("unexpected token %q", token)
+	}
+}
+
+func TestMerge_TokenProvider(t *testing.T) {
+	clientOpts := options.NewAPIOptions(options.WithToken("static"))
+	dbOpts := options.NewAPIOptions(options.WithTokenProvider(options.NewStaticTokenProvider("provided")))
+
+	token, _ := options.Merge(clientOpts).ResolveToken(context.Background())
+	if token != "static" {
+		t.Errorf("expected static token, got %q", token)
+	}
+	token, _ = options.Merge(clientOpts, dbOpts).ResolveToken(context.Background())
+	if token != "provided" {
+		t.Errorf("expected provider to take precedence, got %q", token)
+	}
+}
+
+func TestMerge_Interceptors(t *testing.T) {
+	var calls []string
+	interceptor := func(name string) options.CommandInterceptor {
+		return func(ctx context.Context, cmd *options.CommandInfo, next options.CommandHandler) ([]byte, results.Warnings, error) {
+			calls = append(calls, name)
+			return next(ctx, cmd)
+		}
+	}
+	clientOpts := options.NewAPIOptions(options.WithCommandInterceptor(interceptor("client1"), interceptor("client2")))
+	dbOpts := options.NewAPIOptions(options.WithCommandInterceptor(interceptor("db")))
+	cmdOpts := options.NewAPIOptions(options.WithCommandInterceptor(interceptor("command")))
+
+	result := options.Merge(clientOpts, dbOpts, nil, cmdOpts)
+	h := result.Chain(func(context.Context, *options.CommandInfo) ([]byte, results.Warnings, error) {
+		calls = append(calls, "send")
+		return nil, nil, nil
+	})
+	if _, _, err := h(context.Background(), &options.CommandInfo{}); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"client1", "client2", "db", "command", "send"}
+	if !slices.Equal(calls, want) {
+		t.Errorf("expected calls %v, got %v", want, calls)
+	}
+	if len(clientOpts.Interceptors) != 2 {
+		t.Errorf("expected merge to leave layers untouched, got %d client interceptors", len(clientOpts.Interceptors))
+	}
+}
+
+func TestMerge_Logging(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	clientOpts := options.NewAPIOptions(options.WithLogger(logger))
+	collOpts := options.NewAPIOptions(options.WithLogging(options.LoggingOptions{MaxBodyLength: -1}))
+
+	result := options.Merge(nil)
+	if result.Logger != nil {
+		t.Error("expected logging to be off by default")
+	}
+	if l := result.GetLogging(); l.MaxBodyLength != options.DefaultMaxBodyLength || l.WarningLevel != slog.LevelWarn {
+		t.Errorf("unexpected default logging options %+v", l)
+	}
+
+	result = options.Merge(clientOpts, collOpts)
+	if result.Logger != logger {
+		t.Error("expected logger to be kept from the client layer")
+	}
+	if l := result.GetLogging(); l.MaxBodyLength != -1 || l.RequestLevel != slog.LevelDebug {
+		t.Errorf("expected collection logging options with defaults, got %+v", l)
+	}
+}
+
+func TestMerge_Serdes(t *testing.T) {
+	if c := options.Merge(nil).GetCodec(); c != (codec.JSON{}) {
+		t.Errorf("expected default JSON codec, got %#v", c)
+	}
+
+	clientOpts := options.NewAPIOptions(options.WithSerdes(options.SerdesOptions{UseNumber: true}))
+	collOpts := options.NewAPIOptions(options.WithSerdes(options.SerdesOptions{DisallowUnknownFields: true}))
+	if c := options.Merge(clientOpts).GetCodec(); c != (codec.JSON{UseNumber: true}) {
+		t.Errorf("expected JSON codec with UseNumber, got %#v", c)
+	}
+	if c := options.Merge(clientOpts, collOpts).GetCodec(); c != (codec.JSON{DisallowUnknownFields: true}) {
+		t.Errorf("expected collection serdes options to replace the client's, got %#v", c)
+	}
+
+	custom := codec.JSON{UseNumber: true, DisallowUnknownFields: true}
+	cmdOpts := options.NewAPIOptions(options.WithSerdes(options.SerdesOptions{UseNumber: true}), options.WithCodec(custom))
+	result := options.Merge(clientOpts, cmdOpts)
+	if result.GetCodec() != custom || !result.Serdes.UseNumber {
+		t.Errorf("expected custom codec alongside other serdes options, got %+v", result.Serdes)
+	}
+}
@@ -32,6 +32,36 @@ type CollectionOptions struct {
 	Rerank *RerankOptions `json:"rerank,omitempty"`
 }
 
+// CreateCollectionOptions configures how a collection is created, as
+// opposed to the collection's own [CollectionOptions].
+type CreateCollectionOptions struct {
+	// ValidateProviders checks a vectorize service against the database's
+	// embedding providers before the collection is created, at the cost of
+	// a findEmbeddingProviders command.
+	ValidateProviders bool
+}
+
+// CreateCollectionOption is a functional option for configuring
+// CreateCollectionOptions.
+type CreateCollectionOption func(*CreateCollectionOptions)
+
+// WithCollectionProviderValidation sets the ValidateProviders option.
+func WithCollectionProviderValidation(validate bool) CreateCollectionOption {
+	return func(opts *CreateCollectionOptions) {
+		opts.ValidateProviders = validate
+	}
+}
+
+// NewCreateCollectionOptions creates a CreateCollectionOptions with the
+// provided options applied.
+func NewCreateCollectionOptions(opts ...CreateCollectionOption) *CreateCollectionOptions {
+	options := &CreateCollectionOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
 // -- Placeholder structs for the types referenced above --
 
 type DefaultIdOptions struct {
@@ -59,6 +89,13 @@ type VectorServiceOptions struct {
 
 	// ModelName is the name of the embedding model to use.
 	ModelName string `json:"modelName,omitempty"`
+
+	// Authentication contains authentication configuration, e.g. the
+	// "providerKey" name of a shared secret stored in Astra.
+	Authentication map[string]string `json:"authentication,omitempty"`
+
+	// Parameters contains provider-specific parameters.
+	Parameters map[string]any `json:"parameters,omitempty"`
 }
 
 type IndexingOptions struct {
@@ -14,6 +14,8 @@
 
 package options
 
+import "time"
+
 // DBEnvironment represents the Astra DB environment.
 // This is used for admin operations (creating/deleting databases).
 type DBEnvironment int
@@ -41,10 +43,11 @@ const (
 	EnvironmentTest
 )
 
-// AdminTimeouts contains timeout settings for admin operations.
+// AdminTimeouts contains timeout settings for admin operations. Each value
+// is the total budget for a blocking operation, including polling.
 type AdminTimeouts struct {
-	Collection *int64
-	Table      *int64
-	Database   *int64
-	Keyspace   *int64
+	Collection *time.Duration
+	Table      *time.Duration
+	Database   *time.Duration
+	Keyspace   *time.Duration
 }
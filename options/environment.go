@@ -0,0 +1,116 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// Environment identifies the kind of Data API deployment the client talks to.
+//
+// Astra is the default. The self-hosted environments use username/password
+// tokens (see [UsernamePasswordTokenProvider] and [Environment.FormatToken]),
+// have no default keyspace, manage keyspaces with Data API commands, and
+// lack the Astra-only features listed by [Feature].
+type Environment string
+
+const (
+	// EnvironmentAstra is DataStax Astra DB (the default).
+	EnvironmentAstra Environment = "astra"
+	// EnvironmentHCD is Hyper-Converged Database.
+	EnvironmentHCD Environment = "hcd"
+	// EnvironmentDSE is DataStax Enterprise.
+	EnvironmentDSE Environment = "dse"
+	// EnvironmentCassandra is Apache Cassandra with the Data API.
+	EnvironmentCassandra Environment = "cassandra"
+	// EnvironmentOther is any other self-hosted Data API deployment.
+	EnvironmentOther Environment = "other"
+)
+
+// IsAstra reports whether e is the Astra environment.
+func (e Environment) IsAstra() bool {
+	return e == EnvironmentAstra
+}
+
+// Validate returns an error if e is not a known environment.
+func (e Environment) Validate() error {
+	switch e {
+	case EnvironmentAstra, EnvironmentHCD, EnvironmentDSE, EnvironmentCassandra, EnvironmentOther:
+		return nil
+	}
+	return fmt.Errorf("unknown environment %q", string(e))
+}
+
+// DefaultKeyspace returns the keyspace used when none is set: "default_keyspace"
+// on Astra, and none on self-hosted deployments.
+func (e Environment) DefaultKeyspace() string {
+	if e.IsAstra() {
+		return "default_keyspace"
+	}
+	return ""
+}
+
+// Feature is a capability that only some environments offer. Operations
+// that need a feature the environment lacks fail with
+// astradb.ErrUnsupportedEnvironment before any request is sent.
+type Feature string
+
+const (
+	// FeatureDevOpsAPI is the Astra DevOps API, used for database and
+	// Astra keyspace administration.
+	FeatureDevOpsAPI Feature = "devops-api"
+	// FeatureVectorize is server-side embedding generation through
+	// embedding providers, including findEmbeddingProviders.
+	FeatureVectorize Feature = "vectorize"
+	// FeatureReranking is reranking through reranking providers,
+	// including findRerankingProviders.
+	FeatureReranking Feature = "reranking"
+)
+
+// Supports reports whether e offers f. All features are currently Astra
+// only.
+func (e Environment) Supports(f Feature) bool {
+	switch f {
+	case FeatureDevOpsAPI, FeatureVectorize, FeatureReranking:
+		return e.IsAstra()
+	}
+	return false
+}
+
+// FormatToken returns token as it must be sent in e. Astra tokens are sent
+// as is. Self-hosted deployments expect
+//
+//	Cassandra:<base64 username>:<base64 password>
+//
+// so a "username:password" token is converted to that form; tokens already
+// in it, or without a colon, are sent as is.
+func (e Environment) FormatToken(token string) string {
+	if e.IsAstra() || strings.HasPrefix(token, "Cassandra:") {
+		return token
+	}
+	username, password, ok := strings.Cut(token, ":")
+	if !ok {
+		return token
+	}
+	return usernamePasswordToken(username, password)
+}
+
+// usernamePasswordToken returns the self-hosted token for the credentials.
+func usernamePasswordToken(username, password string) string {
+	enc := base64.StdEncoding
+	return "Cassandra:" + enc.EncodeToString([]byte(username)) + ":" + enc.EncodeToString([]byte(password))
+}
@@ -14,6 +14,11 @@
 
 package options
 
+import (
+	"encoding/json"
+	"errors"
+)
+
 // VectorMetric represents the similarity measurement for vector search.
 type VectorMetric string
 
@@ -41,6 +46,10 @@ type CreateIndexOptions struct {
 	// CaseSensitive if true (default), enforces case-sensitive matching.
 	// Only applicable to text columns.
 	CaseSensitive *bool
+
+	// MapTarget selects whether the keys, values or entries of a map column
+	// are indexed. Only applicable to map columns given by name.
+	MapTarget *MapIndexTarget
 }
 
 // List implements Builder[CreateIndexOptions] allowing the raw struct to be
@@ -106,6 +115,28 @@ func (b *CreateIndexOptionsBuilder) SetCaseSensitive(v bool) *CreateIndexOptions
 	return b
 }
 
+// SetMapTarget sets which part of a map column is indexed.
+//
+// Can be one of: [MapIndexEntries] (default), [MapIndexKeys], [MapIndexValues].
+func (b *CreateIndexOptionsBuilder) SetMapTarget(v MapIndexTarget) *CreateIndexOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *CreateIndexOptions) {
+		o.MapTarget = &v
+	})
+	return b
+}
+
+// MapIndexTarget selects which part of a map column an index covers.
+type MapIndexTarget string
+
+// Map index targets. Entries are indexed by naming the column itself, while
+// keys and values are indexed with {"column": "$keys"} or {"column": "$values"}.
+// Indexes on list and set columns always cover their values.
+const (
+	MapIndexEntries MapIndexTarget = "$entries"
+	MapIndexKeys    MapIndexTarget = "$keys"
+	MapIndexValues  MapIndexTarget = "$values"
+)
+
 // CreateVectorIndexOptions represents options for creating a vector index.
 type CreateVectorIndexOptions struct {
 	// IfNotExists if true, the command will silently succeed even if an index
@@ -225,3 +256,138 @@ func (b *ListIndexesOptionsBuilder) SetExplain(v bool) *ListIndexesOptionsBuilde
 	})
 	return b
 }
+
+// TextAnalyzer configures how a text index breaks text into terms for
+// lexical search. It is either a named analyzer such as "standard" or
+// "english", or a custom analyzer made of a tokenizer and filters.
+//
+// Example - named analyzer:
+//
+//	options.TextAnalyzer{Name: "english"}
+//
+// Example - custom analyzer:
+//
+//	options.TextAnalyzer{
+//		Tokenizer: &options.AnalyzerComponent{Name: "standard"},
+//		Filters:   []options.AnalyzerComponent{{Name: "lowercase"}, {Name: "porterstem"}},
+//	}
+type TextAnalyzer struct {
+	// Name is the name of a predefined analyzer. It is mutually exclusive
+	// with the custom analyzer fields.
+	Name string `json:"-"`
+
+	// Tokenizer splits text into terms.
+	Tokenizer *AnalyzerComponent `json:"tokenizer,omitempty"`
+
+	// Filters are applied to each term in order, e.g. lowercase or stop.
+	Filters []AnalyzerComponent `json:"filters,omitempty"`
+
+	// CharFilters are applied to the text before it is tokenized.
+	CharFilters []AnalyzerComponent `json:"charFilters,omitempty"`
+}
+
+// AnalyzerComponent is a tokenizer, filter or char filter of a custom
+// [TextAnalyzer].
+type AnalyzerComponent struct {
+	Name string         `json:"name"`
+	Args map[string]any `json:"args,omitempty"`
+}
+
+// isCustom reports whether any custom analyzer fields are set.
+func (a TextAnalyzer) isCustom() bool {
+	return a.Tokenizer != nil || len(a.Filters) > 0 || len(a.CharFilters) > 0
+}
+
+// MarshalJSON implements custom JSON marshaling for TextAnalyzer. A named
+// analyzer marshals as a plain string.
+func (a TextAnalyzer) MarshalJSON() ([]byte, error) {
+	if !a.isCustom() {
+		return json.Marshal(a.Name)
+	}
+	type analyzerAlias TextAnalyzer
+	return json.Marshal(analyzerAlias(a))
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for TextAnalyzer. It
+// handles both named analyzers and custom analyzer objects.
+func (a *TextAnalyzer) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*a = TextAnalyzer{Name: name}
+		return nil
+	}
+	type analyzerAlias TextAnalyzer
+	var alias analyzerAlias
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return err
+	}
+	*a = TextAnalyzer(alias)
+	return nil
+}
+
+// CreateTextIndexOptions represents options for creating a text index.
+type CreateTextIndexOptions struct {
+	// IfNotExists if true, the command will silently succeed even if an index
+	// with the given name already exists. This only checks index names, not definitions.
+	IfNotExists *bool
+
+	// Analyzer configures how text is split into terms. If unset, the
+	// server default ("standard") is used.
+	Analyzer *TextAnalyzer
+}
+
+// List implements Builder[CreateTextIndexOptions] allowing the raw struct to be
+// passed directly to methods that accept ...Builder[CreateTextIndexOptions].
+func (o *CreateTextIndexOptions) List() []func(*CreateTextIndexOptions) {
+	return NoopBuilder(o)
+}
+
+// Validate implements Validator for CreateTextIndexOptions.
+func (o CreateTextIndexOptions) Validate() error {
+	if o.Analyzer != nil && o.Analyzer.Name != "" && o.Analyzer.isCustom() {
+		return errors.New("text analyzer cannot have both a name and a custom tokenizer or filters")
+	}
+	return nil
+}
+
+// CreateTextIndexOptionsBuilder is a builder for CreateTextIndexOptions that implements
+// Builder[CreateTextIndexOptions] following the MongoDB Go driver pattern.
+type CreateTextIndexOptionsBuilder struct {
+	Opts []func(*CreateTextIndexOptions)
+}
+
+// CreateTextIndex creates a new CreateTextIndexOptionsBuilder.
+func CreateTextIndex() *CreateTextIndexOptionsBuilder {
+	return &CreateTextIndexOptionsBuilder{}
+}
+
+// List implements Builder[CreateTextIndexOptions].
+func (b *CreateTextIndexOptionsBuilder) List() []func(*CreateTextIndexOptions) {
+	return b.Opts
+}
+
+// SetIfNotExists sets the ifNotExists option for text index creation.
+// When true, the command will silently succeed even if an index with the given name already exists.
+func (b *CreateTextIndexOptionsBuilder) SetIfNotExists(v bool) *CreateTextIndexOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *CreateTextIndexOptions) {
+		o.IfNotExists = &v
+	})
+	return b
+}
+
+// SetAnalyzer sets a predefined analyzer by name, e.g. "standard", "english"
+// or "whitespace".
+func (b *CreateTextIndexOptionsBuilder) SetAnalyzer(name string) *CreateTextIndexOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *CreateTextIndexOptions) {
+		o.Analyzer = &TextAnalyzer{Name: name}
+	})
+	return b
+}
+
+// SetCustomAnalyzer sets a custom analyzer built from a tokenizer and filters.
+func (b *CreateTextIndexOptionsBuilder) SetCustomAnalyzer(a TextAnalyzer) *CreateTextIndexOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *CreateTextIndexOptions) {
+		o.Analyzer = &a
+	})
+	return b
+}
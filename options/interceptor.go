@@ -0,0 +1,230 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/datastax/astra-db-go/codec"
+	"github.com/datastax/astra-db-go/results"
+)
+
+// CommandInfo describes a Data API command about to be sent. Interceptors
+// may modify it before calling the next handler: changes to URL, Payload,
+// Body and Options (such as Options.Headers or Options.Token) apply to the
+// request.
+type CommandInfo struct {
+	// Name is the command name, such as "insertOne" or "find".
+	Name string
+
+	// Keyspace is the keyspace the command runs in. It is empty for
+	// database-level commands such as createKeyspace.
+	Keyspace string
+
+	// Resource is the collection or table the command targets, if any.
+	Resource string
+
+	// URL is the endpoint the command is posted to.
+	URL string
+
+	// Payload is the command payload. It is encoded as the request is
+	// sent, so large payloads are never held in memory as JSON.
+	Payload any
+
+	// Body, if set, is sent as the request body instead of Payload. It is
+	// nil unless an interceptor sets it; use [CommandInfo.MarshalBody] to
+	// get the body that will be sent.
+	Body []byte
+
+	// Options are the resolved options for the command. They are a copy
+	// made for this command only.
+	Options *APIOptions
+
+	// Cursor is set when the command fetches a page for a cursor returned
+	// by Find.
+	Cursor *CursorInfo
+
+	// Retries is the number of times the request was retried, for example
+	// after a 401 with a refreshable token. It is set once next returns.
+	Retries int
+}
+
+// EncodeBody writes the request body to w: Body if set, and otherwise
+// {"<Name>": <Payload>}, or just Payload if Name is empty. Payload is
+// encoded with the codec of Options.
+func (c *CommandInfo) EncodeBody(w io.Writer) error {
+	if c.Body != nil {
+		_, err := w.Write(c.Body)
+		return err
+	}
+	enc := c.Options.GetCodec()
+	if c.Name == "" {
+		return encodeCompact(w, enc, c.Payload)
+	}
+	name, err := json.Marshal(c.Name)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "{%s:", name); err != nil {
+		return err
+	}
+	if err := encodeCompact(w, enc, c.Payload); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "}")
+	return err
+}
+
+// encodeCompact writes v to w with enc, without the newline a json.Encoder
+// adds.
+func encodeCompact(w io.Writer, enc codec.Codec, v any) error {
+	return enc.Encode(&trimNewline{w: w}, v)
+}
+
+// trimNewline writes to w all but a trailing newline.
+type trimNewline struct {
+	w       io.Writer
+	pending bool // A newline is held back
+}
+
+func (t *trimNewline) Write(p []byte) (int, error) {
+	n := len(p)
+	if n == 0 {
+		return 0, nil
+	}
+	if t.pending {
+		if _, err := io.WriteString(t.w, "\n"); err != nil {
+			return 0, err
+		}
+		t.pending = false
+	}
+	if p[n-1] == '\n' {
+		p, t.pending = p[:n-1], true
+	}
+	if _, err := t.w.Write(p); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// MarshalBody returns the request body, as written by
+// [CommandInfo.EncodeBody], in memory.
+func (c *CommandInfo) MarshalBody() ([]byte, error) {
+	if c.Body != nil {
+		return c.Body, nil
+	}
+	var buf bytes.Buffer
+	if err := c.EncodeBody(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// CursorInfo describes the cursor a find command fetches a page for. The
+// same CursorInfo is passed with every page of a cursor, and pages are
+// never fetched concurrently.
+type CursorInfo struct {
+	// Context is the context passed to Find. Interceptors may replace it
+	// to carry values, such as a tracing span for the whole query, from
+	// one page to the next.
+	Context context.Context
+
+	// Page is the 1-based number of the page being fetched.
+	Page int
+
+	mu      sync.Mutex
+	onClose []func()
+	closed  bool
+}
+
+// OnClose registers f to run when the cursor is closed, such as to end a
+// tracing span for the whole query. Functions run once, in the order they
+// were registered; f runs immediately if the cursor is already closed.
+func (c *CursorInfo) OnClose(f func()) {
+	c.mu.Lock()
+	if !c.closed {
+		c.onClose = append(c.onClose, f)
+		c.mu.Unlock()
+		return
+	}
+	c.mu.Unlock()
+	f()
+}
+
+// Close runs the functions registered with [CursorInfo.OnClose]. It is
+// called when the cursor is closed; later calls do nothing.
+func (c *CursorInfo) Close() {
+	c.mu.Lock()
+	fns := c.onClose
+	c.onClose, c.closed = nil, true
+	c.mu.Unlock()
+	for _, f := range fns {
+		f()
+	}
+}
+
+// CommandHandler executes a command and returns the response body, any
+// warnings from the API, and any error that occurred.
+type CommandHandler func(ctx context.Context, cmd *CommandInfo) ([]byte, results.Warnings, error)
+
+// CommandInterceptor wraps the execution of every command. It must call
+// next to send the command, and may inspect or modify the command before
+// and the results after, or return without calling next to short-circuit it.
+//
+// Interceptors see the response after errors and warnings have been
+// extracted, so err may be any error the command would return, such as
+// [astradb.DataAPIResponseError] or [astradb.HTTPError].
+type CommandInterceptor func(ctx context.Context, cmd *CommandInfo, next CommandHandler) ([]byte, results.Warnings, error)
+
+// WithCommandInterceptor adds interceptors around command execution.
+// Unlike most options, interceptors from every layer are kept and chained:
+// client interceptors run outermost, then database, collection/table and
+// command interceptors, in the order they were added.
+//
+// Example usage:
+//
+//	client := astradb.NewClient(
+//		options.WithToken("..."),
+//		options.WithCommandInterceptor(func(ctx context.Context, cmd *options.CommandInfo, next options.CommandHandler) ([]byte, results.Warnings, error) {
+//			start := time.Now()
+//			body, warnings, err := next(ctx, cmd)
+//			slog.Info("command", "name", cmd.Name, "elapsed", time.Since(start), "err", err)
+//			return body, warnings, err
+//		}),
+//	)
+func WithCommandInterceptor(interceptors ...CommandInterceptor) APIOption {
+	return func(o *APIOptions) {
+		o.Interceptors = append(o.Interceptors, interceptors...)
+	}
+}
+
+// Chain returns a handler that runs the interceptors in o around h.
+func (o *APIOptions) Chain(h CommandHandler) CommandHandler {
+	if o == nil {
+		return h
+	}
+	for i := len(o.Interceptors) - 1; i >= 0; i-- {
+		interceptor, next := o.Interceptors[i], h
+		h = func(ctx context.Context, cmd *CommandInfo) ([]byte, results.Warnings, error) {
+			return interceptor(ctx, cmd, next)
+		}
+	}
+	return h
+}
@@ -0,0 +1,157 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// KeyspaceReplication is the replication strategy of a new keyspace.
+// Only used by self-hosted deployments; Astra manages replication itself.
+type KeyspaceReplication struct {
+	// Class is "SimpleStrategy" or "NetworkTopologyStrategy".
+	Class string
+
+	// ReplicationFactor is used with SimpleStrategy.
+	ReplicationFactor int
+
+	// DataCenters maps data center names to replication factors and is
+	// used with NetworkTopologyStrategy.
+	DataCenters map[string]int
+}
+
+// SimpleStrategy returns a SimpleStrategy replication with factor rf.
+func SimpleStrategy(rf int) *KeyspaceReplication {
+	return &KeyspaceReplication{Class: "SimpleStrategy", ReplicationFactor: rf}
+}
+
+// NetworkTopologyStrategy returns a NetworkTopologyStrategy replication
+// with the given per data center replication factors.
+func NetworkTopologyStrategy(dataCenters map[string]int) *KeyspaceReplication {
+	return &KeyspaceReplication{Class: "NetworkTopologyStrategy", DataCenters: dataCenters}
+}
+
+// MarshalJSON implements [json.Marshaler], flattening data centers into
+// the replication object as the Data API expects.
+func (r KeyspaceReplication) MarshalJSON() ([]byte, error) {
+	out := map[string]any{"class": r.Class}
+	if r.ReplicationFactor > 0 {
+		out["replication_factor"] = r.ReplicationFactor
+	}
+	for dc, rf := range r.DataCenters {
+		out[dc] = rf
+	}
+	return json.Marshal(out)
+}
+
+// CreateKeyspaceOptions represents options for creating a keyspace.
+type CreateKeyspaceOptions struct {
+	// UpdateDBKeyspace if true, switches the database's working keyspace to
+	// the new keyspace once it is created.
+	UpdateDBKeyspace *bool
+
+	// Replication sets the replication strategy (self-hosted only).
+	Replication *KeyspaceReplication
+
+	// Blocking if true (default), waits until the keyspace is usable.
+	Blocking *bool
+
+	// PollInterval is the time between status checks while blocking.
+	PollInterval *time.Duration
+
+	// Timeout overrides the [AdminTimeouts] Keyspace budget.
+	Timeout *time.Duration
+}
+
+// List implements Builder[CreateKeyspaceOptions] allowing the raw struct to be
+// passed directly to methods that accept ...Builder[CreateKeyspaceOptions].
+func (o *CreateKeyspaceOptions) List() []func(*CreateKeyspaceOptions) {
+	return NoopBuilder(o)
+}
+
+// Validate implements Validator for CreateKeyspaceOptions.
+func (o CreateKeyspaceOptions) Validate() error {
+	if o.Replication != nil && o.Replication.Class == "" {
+		return errors.New("replication class cannot be empty")
+	}
+	return o.AdminOptions().Validate()
+}
+
+// AdminOptions returns the options that control blocking.
+func (o *CreateKeyspaceOptions) AdminOptions() *AdminOptions {
+	return &AdminOptions{
+		Blocking:     o.Blocking,
+		PollInterval: o.PollInterval,
+		Timeout:      o.Timeout,
+	}
+}
+
+// CreateKeyspaceOptionsBuilder is a builder for CreateKeyspaceOptions that implements
+// Builder[CreateKeyspaceOptions] following the MongoDB Go driver pattern.
+type CreateKeyspaceOptionsBuilder struct {
+	Opts []func(*CreateKeyspaceOptions)
+}
+
+// CreateKeyspace creates a new CreateKeyspaceOptionsBuilder.
+func CreateKeyspace() *CreateKeyspaceOptionsBuilder {
+	return &CreateKeyspaceOptionsBuilder{}
+}
+
+// List implements Builder[CreateKeyspaceOptions].
+func (b *CreateKeyspaceOptionsBuilder) List() []func(*CreateKeyspaceOptions) {
+	return b.Opts
+}
+
+// SetUpdateDBKeyspace sets whether the database switches to the new keyspace.
+func (b *CreateKeyspaceOptionsBuilder) SetUpdateDBKeyspace(v bool) *CreateKeyspaceOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *CreateKeyspaceOptions) {
+		o.UpdateDBKeyspace = &v
+	})
+	return b
+}
+
+// SetReplication sets the replication strategy (self-hosted only).
+func (b *CreateKeyspaceOptionsBuilder) SetReplication(r *KeyspaceReplication) *CreateKeyspaceOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *CreateKeyspaceOptions) {
+		o.Replication = r
+	})
+	return b
+}
+
+// SetBlocking sets whether the operation waits for completion.
+func (b *CreateKeyspaceOptionsBuilder) SetBlocking(v bool) *CreateKeyspaceOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *CreateKeyspaceOptions) {
+		o.Blocking = &v
+	})
+	return b
+}
+
+// SetPollInterval sets the time between status checks while blocking.
+func (b *CreateKeyspaceOptionsBuilder) SetPollInterval(d time.Duration) *CreateKeyspaceOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *CreateKeyspaceOptions) {
+		o.PollInterval = &d
+	})
+	return b
+}
+
+// SetTimeout sets the total time budget for the operation.
+func (b *CreateKeyspaceOptionsBuilder) SetTimeout(d time.Duration) *CreateKeyspaceOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *CreateKeyspaceOptions) {
+		o.Timeout = &d
+	})
+	return b
+}
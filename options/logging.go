@@ -0,0 +1,117 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import "log/slog"
+
+// DefaultMaxBodyLength is the length that logged bodies are truncated to
+// unless [LoggingOptions.MaxBodyLength] is set.
+const DefaultMaxBodyLength = 2048
+
+// LoggingOptions configures what is logged to the Logger set with
+// [WithLogger]. The zero value logs requests and responses at debug level,
+// retries at info level and warnings at warn level, with vectors elided
+// and bodies truncated to [DefaultMaxBodyLength] bytes.
+type LoggingOptions struct {
+	// RequestLevel is the level commands are logged at before they are
+	// sent. Defaults to [slog.LevelDebug].
+	RequestLevel slog.Leveler
+
+	// ResponseLevel is the level responses are logged at. Defaults to
+	// [slog.LevelDebug].
+	ResponseLevel slog.Leveler
+
+	// RetryLevel is the level retried requests are logged at. Defaults to
+	// [slog.LevelInfo].
+	RetryLevel slog.Leveler
+
+	// WarningLevel is the level API warnings are logged at. Defaults to
+	// [slog.LevelWarn].
+	WarningLevel slog.Leveler
+
+	// OmitBodies leaves request and response bodies out of the logs.
+	OmitBodies bool
+
+	// MaxBodyLength is the length logged bodies are truncated to, after
+	// redaction. Zero uses [DefaultMaxBodyLength] and a negative value
+	// disables truncation.
+	MaxBodyLength int
+
+	// KeepVectors logs vectors in full. By default "$vector" values, and
+	// arrays of 16 or more numbers such as table vector columns, are
+	// replaced with a placeholder giving their length.
+	KeepVectors bool
+
+	// Redact lists the JSON paths of fields whose values are replaced with
+	// "REDACTED" in logged bodies. Path segments are object keys separated
+	// by dots; array indexes are skipped, "*" matches any one key and "**"
+	// matches any number of keys. For example, "insertOne.document.email"
+	// matches only that field of insertOne commands, and "**.email"
+	// matches email fields at any depth of requests and responses.
+	Redact []string
+}
+
+// WithLogger sets the logger for commands and their responses. Logging is
+// off unless a logger is set. The optional LoggingOptions control levels,
+// truncation and redaction.
+//
+// Example usage:
+//
+//	client := astradb.NewClient(
+//		options.WithToken("..."),
+//		options.WithLogger(slog.Default(), options.LoggingOptions{
+//			Redact: []string{"**.email", "**.ssn"},
+//		}),
+//	)
+func WithLogger(logger *slog.Logger, opts ...LoggingOptions) APIOption {
+	return func(o *APIOptions) {
+		o.Logger = logger
+		if len(opts) > 0 {
+			o.Logging = &opts[len(opts)-1]
+		}
+	}
+}
+
+// WithLogging sets the logging options without changing the logger, for
+// example to redact extra fields for one collection.
+func WithLogging(opts LoggingOptions) APIOption {
+	return func(o *APIOptions) {
+		o.Logging = &opts
+	}
+}
+
+// GetLogging returns the logging options with defaults filled in.
+func (o *APIOptions) GetLogging() LoggingOptions {
+	var l LoggingOptions
+	if o != nil && o.Logging != nil {
+		l = *o.Logging
+	}
+	if l.RequestLevel == nil {
+		l.RequestLevel = slog.LevelDebug
+	}
+	if l.ResponseLevel == nil {
+		l.ResponseLevel = slog.LevelDebug
+	}
+	if l.RetryLevel == nil {
+		l.RetryLevel = slog.LevelInfo
+	}
+	if l.WarningLevel == nil {
+		l.WarningLevel = slog.LevelWarn
+	}
+	if l.MaxBodyLength == 0 {
+		l.MaxBodyLength = DefaultMaxBodyLength
+	}
+	return l
+}
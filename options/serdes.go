@@ -0,0 +1,77 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import "github.com/datastax/astra-db-go/codec"
+
+// SerdesOptions configures how command payloads are encoded and documents
+// are decoded. Options set on a later layer replace those of earlier
+// layers as a whole.
+type SerdesOptions struct {
+	// Codec encodes payloads and decodes documents, for example to use a
+	// faster JSON library or one with custom type converters. Defaults to
+	// a [codec.JSON] configured by the fields below.
+	Codec codec.Codec
+
+	// UseNumber decodes numbers in untyped documents, such as
+	// map[string]any, as [encoding/json.Number] so large integers keep their
+	// precision. Ignored when Codec is set.
+	UseNumber bool
+
+	// DisallowUnknownFields makes decoding into a struct fail when a
+	// document has a field the struct doesn't. Ignored when Codec is set.
+	DisallowUnknownFields bool
+}
+
+// WithSerdes sets the serialization options.
+//
+// Example usage:
+//
+//	coll := db.Collection("ledger", options.WithSerdes(options.SerdesOptions{
+//		UseNumber:             true,
+//		DisallowUnknownFields: true,
+//	}))
+func WithSerdes(opts SerdesOptions) APIOption {
+	return func(o *APIOptions) {
+		o.Serdes = &opts
+	}
+}
+
+// WithCodec sets the codec that encodes payloads and decodes documents.
+// See the [codec] package for adapting other JSON libraries.
+func WithCodec(c codec.Codec) APIOption {
+	return func(o *APIOptions) {
+		serdes := SerdesOptions{}
+		if o.Serdes != nil {
+			serdes = *o.Serdes
+		}
+		serdes.Codec = c
+		o.Serdes = &serdes
+	}
+}
+
+// GetCodec returns the codec to encode payloads and decode documents with.
+func (o *APIOptions) GetCodec() codec.Codec {
+	if o == nil || o.Serdes == nil {
+		return codec.JSON{}
+	}
+	if o.Serdes.Codec != nil {
+		return o.Serdes.Codec
+	}
+	return codec.JSON{
+		UseNumber:             o.Serdes.UseNumber,
+		DisallowUnknownFields: o.Serdes.DisallowUnknownFields,
+	}
+}
@@ -14,6 +14,13 @@
 
 package options
 
+import (
+	"reflect"
+
+	"github.com/datastax/astra-db-go/projection"
+	"github.com/datastax/astra-db-go/sort"
+)
+
 // CreateTableOptions represents options for creating a table
 type CreateTableOptions struct {
 	// IfNotExists if true, the command will silently succeed even if a table
@@ -23,6 +30,11 @@ type CreateTableOptions struct {
 	// Keyspace specifies the keyspace in which to create the table.
 	// If not provided, defaults to the working keyspace for the database.
 	Keyspace string `json:"-"`
+
+	// ValidateProviders checks vectorize services against the database's
+	// embedding providers before the table is created, at the cost of a
+	// findEmbeddingProviders command.
+	ValidateProviders bool `json:"-"`
 }
 
 // TableOption is a functional option for configuring CreateTableOptions
@@ -42,6 +54,13 @@ func WithTableKeyspace(keyspace string) TableOption {
 	}
 }
 
+// WithProviderValidation sets the ValidateProviders option
+func WithProviderValidation(validate bool) TableOption {
+	return func(opts *CreateTableOptions) {
+		opts.ValidateProviders = validate
+	}
+}
+
 // NewCreateTableOptions creates a CreateTableOptions with the provided options applied
 func NewCreateTableOptions(opts ...TableOption) *CreateTableOptions {
 	options := &CreateTableOptions{}
@@ -57,11 +76,22 @@ type TableFindOptions struct {
 	// - Ascending/descending sort on columns (e.g., {"rating": 1, "title": -1})
 	// - Vector search with a vector (e.g., {"vector_column": [0.1, 0.2, 0.3]})
 	// - Vector search with vectorize (e.g., {"vector_column": "search text"})
-	Sort map[string]any `json:"sort,omitempty"`
+	//
+	// Holds either a map[string]any or a *[sort.Sort]. Use the latter when
+	// sorting on more than one column so the order is preserved. The field
+	// was a map[string]any before builders were accepted; code that reads
+	// it needs a type assertion. [WithSort] leaves it unset for a nil or
+	// empty value.
+	Sort any `json:"sort,omitempty"`
 
 	// Projection controls which columns are included or excluded in the returned rows
-	// Use true to include a column, false to exclude it
-	Projection map[string]bool `json:"projection,omitempty"`
+	// Use true to include a column, false to exclude it.
+	//
+	// Holds either a map[string]bool or a *[projection.Projection]. The
+	// field was a map[string]bool before builders were accepted; code that
+	// reads it needs a type assertion. [WithProjection] leaves it unset for
+	// a nil or empty value.
+	Projection any `json:"projection,omitempty"`
 
 	// Limit limits the total number of rows returned
 	Limit *int `json:"limit,omitempty"`
@@ -81,17 +111,60 @@ type TableFindOptions struct {
 // TableFindOption is a functional option for configuring TableFindOptions
 type TableFindOption func(*TableFindOptions)
 
-// WithSort sets the sort option for the find operation
-func WithSort(sort map[string]any) TableFindOption {
+// SortSpec is the set of sort clause types accepted by find options.
+// A map is unordered; use [sort.Sort] when field order matters.
+type SortSpec interface {
+	map[string]any | *sort.Sort
+}
+
+// TableProjectionSpec is the set of projection types accepted by table find options.
+type TableProjectionSpec interface {
+	map[string]bool | *projection.Projection
+}
+
+// CollectionProjectionSpec is the set of projection types accepted by collection find options.
+type CollectionProjectionSpec interface {
+	map[string]any | *projection.Projection
+}
+
+// isUnset reports whether v, a map or pointer, is nil or an empty map.
+// Such sorts and projections are left unset: stored in an any field, a nil
+// one would be sent as null and an empty map as {}, which omitempty only
+// dropped while the fields were maps.
+func isUnset(v any) bool {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return true
+	}
+	if rv.Kind() == reflect.Map {
+		return rv.Len() == 0
+	}
+	return rv.IsNil()
+}
+
+// WithSort sets the sort option for the find operation.
+//
+// Example:
+//
+//	options.WithSort(sort.Desc("rating").Asc("title"))
+func WithSort[S SortSpec](s S) TableFindOption {
 	return func(opts *TableFindOptions) {
-		opts.Sort = sort
+		if !isUnset(s) {
+			opts.Sort = s
+		}
 	}
 }
 
-// WithProjection sets the projection option for the find operation
-func WithProjection(projection map[string]bool) TableFindOption {
+// WithProjection sets the projection option for the find operation.
+//
+// Example:
+//
+//	options.WithProjection(projection.Include("title", "author"))
+func WithProjection[P TableProjectionSpec](p P) TableFindOption {
 	return func(opts *TableFindOptions) {
-		opts.Projection = projection
+		if !isUnset(p) {
+			opts.Projection = p
+		}
 	}
 }
 
@@ -144,11 +217,23 @@ type CollectionFindOptions struct {
 	// - Ascending/descending sort on fields (e.g., {"rating": 1, "title": -1})
 	// - Vector search with a vector (e.g., {"$vector": [0.1, 0.2, 0.3]})
 	// - Vector search with vectorize (e.g., {"$vectorize": "search text"})
-	Sort map[string]any `json:"sort,omitempty"`
+	// - Lexical search (e.g., {"$lexical": "search text"})
+	//
+	// Holds either a map[string]any or a *[sort.Sort]. Use the latter when
+	// sorting on more than one field so the order is preserved. The field
+	// was a map[string]any before builders were accepted; code that reads
+	// it needs a type assertion. [WithCollectionSort] leaves it unset for a
+	// nil or empty value.
+	Sort any `json:"sort,omitempty"`
 
 	// Projection controls which fields are included or excluded in the returned documents
-	// Use true to include a field, false to exclude it
-	Projection map[string]any `json:"projection,omitempty"`
+	// Use true to include a field, false to exclude it.
+	//
+	// Holds either a map[string]any or a *[projection.Projection]. The
+	// field was a map[string]any before builders were accepted; code that
+	// reads it needs a type assertion. [WithCollectionProjection] leaves it
+	// unset for a nil or empty value.
+	Projection any `json:"projection,omitempty"`
 
 	// Limit limits the total number of documents returned
 	Limit *int `json:"limit,omitempty"`
@@ -172,17 +257,29 @@ type CollectionFindOptions struct {
 // CollectionFindOption is a functional option for configuring CollectionFindOptions
 type CollectionFindOption func(*CollectionFindOptions)
 
-// WithCollectionSort sets the sort option for the find operation
-func WithCollectionSort(sort map[string]any) CollectionFindOption {
+// WithCollectionSort sets the sort option for the find operation.
+//
+// Example:
+//
+//	options.WithCollectionSort(sort.Asc("rating").Desc("title"))
+func WithCollectionSort[S SortSpec](s S) CollectionFindOption {
 	return func(opts *CollectionFindOptions) {
-		opts.Sort = sort
+		if !isUnset(s) {
+			opts.Sort = s
+		}
 	}
 }
 
-// WithCollectionProjection sets the projection option for the find operation
-func WithCollectionProjection(projection map[string]any) CollectionFindOption {
+// WithCollectionProjection sets the projection option for the find operation.
+//
+// Example:
+//
+//	options.WithCollectionProjection(projection.Include("title").SliceN("reviews", 3))
+func WithCollectionProjection[P CollectionProjectionSpec](p P) CollectionFindOption {
 	return func(opts *CollectionFindOptions) {
-		opts.Projection = projection
+		if !isUnset(p) {
+			opts.Projection = p
+		}
 	}
 }
 
@@ -0,0 +1,228 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenProvider supplies the token sent with each request. It takes
+// precedence over a static token set with [WithToken], and is merged
+// through [Merge] like other options.
+//
+// Providers that cache tokens should also implement [TokenInvalidator].
+type TokenProvider interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// UsernamePasswordTokenProvider produces the tokens expected by self-hosted
+// Data API deployments (HCD, DSE, Cassandra):
+//
+//	Cassandra:<base64 username>:<base64 password>
+type UsernamePasswordTokenProvider struct {
+	token string
+}
+
+// NewUsernamePasswordTokenProvider returns a provider for the given credentials.
+//
+// Example:
+//
+//	client := astradb.NewClient(
+//		options.WithEnvironment(options.EnvironmentHCD),
+//		options.WithTokenProvider(options.NewUsernamePasswordTokenProvider("cassandra", "cassandra")),
+//	)
+func NewUsernamePasswordTokenProvider(username, password string) *UsernamePasswordTokenProvider {
+	return &UsernamePasswordTokenProvider{
+		token: usernamePasswordToken(username, password),
+	}
+}
+
+// Token implements [TokenProvider].
+func (p *UsernamePasswordTokenProvider) Token(context.Context) (string, error) {
+	return p.token, nil
+}
+
+// TokenInvalidator is implemented by token providers that cache tokens.
+// When a request is rejected with 401 Unauthorized, the client calls
+// InvalidateToken with the rejected token and retries once with a fresh one.
+type TokenInvalidator interface {
+	// InvalidateToken discards token if it is still the cached token.
+	InvalidateToken(token string)
+}
+
+// StaticTokenProvider always returns the same token.
+type StaticTokenProvider struct {
+	token string
+}
+
+// NewStaticTokenProvider returns a provider for a fixed token.
+func NewStaticTokenProvider(token string) *StaticTokenProvider {
+	return &StaticTokenProvider{token: token}
+}
+
+// Token implements [TokenProvider].
+func (p *StaticTokenProvider) Token(context.Context) (string, error) {
+	return p.token, nil
+}
+
+// EnvTokenProvider reads the token from an environment variable on each
+// request, so changes to the variable take effect immediately.
+type EnvTokenProvider struct {
+	name string
+}
+
+// NewEnvTokenProvider returns a provider that reads the named environment variable.
+//
+// Example:
+//
+//	client := astradb.NewClient(
+//		options.WithTokenProvider(options.NewEnvTokenProvider("ASTRA_DB_APPLICATION_TOKEN")),
+//	)
+func NewEnvTokenProvider(name string) *EnvTokenProvider {
+	return &EnvTokenProvider{name: name}
+}
+
+// Token implements [TokenProvider]. It returns an error if the variable is
+// unset or empty.
+func (p *EnvTokenProvider) Token(context.Context) (string, error) {
+	token := os.Getenv(p.name)
+	if token == "" {
+		return "", fmt.Errorf("environment variable %s is not set", p.name)
+	}
+	return token, nil
+}
+
+// FileTokenProvider reads the token from a file, such as one written by a
+// Vault agent sidecar. The file is re-read whenever its modification time
+// or size changes, or after a 401 response. Surrounding whitespace is trimmed.
+type FileTokenProvider struct {
+	path string
+
+	mu      sync.Mutex
+	token   string
+	modTime time.Time
+	size    int64
+}
+
+// NewFileTokenProvider returns a provider that reads the token from path.
+func NewFileTokenProvider(path string) *FileTokenProvider {
+	return &FileTokenProvider{path: path}
+}
+
+// Token implements [TokenProvider].
+func (p *FileTokenProvider) Token(context.Context) (string, error) {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return "", err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.token != "" && info.ModTime().Equal(p.modTime) && info.Size() == p.size {
+		return p.token, nil
+	}
+	b, err := os.ReadFile(p.path)
+	if err != nil {
+		return "", err
+	}
+	token := strings.TrimSpace(string(b))
+	if token == "" {
+		return "", fmt.Errorf("token file %s is empty", p.path)
+	}
+	p.token, p.modTime, p.size = token, info.ModTime(), info.Size()
+	return p.token, nil
+}
+
+// InvalidateToken implements [TokenInvalidator], forcing the file to be re-read.
+func (p *FileTokenProvider) InvalidateToken(token string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.token == token {
+		p.token = ""
+	}
+}
+
+// RefreshFunc fetches a new token and the time it expires. A zero expiry
+// means the token does not expire.
+type RefreshFunc func(ctx context.Context) (token string, expiry time.Time, err error)
+
+// DefaultRefreshBefore is how long before expiry a [CachingTokenProvider]
+// refreshes its token by default.
+const DefaultRefreshBefore = time.Minute
+
+// CachingTokenProvider caches the token returned by a [RefreshFunc] and
+// refreshes it shortly before it expires or after a 401 response.
+// Concurrent callers share a single refresh.
+type CachingTokenProvider struct {
+	refresh       RefreshFunc
+	refreshBefore time.Duration
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// NewCachingTokenProvider returns a provider that caches tokens from
+// refresh, refreshing them refreshBefore their expiry. A refreshBefore of
+// zero uses [DefaultRefreshBefore].
+//
+// Example:
+//
+//	provider := options.NewCachingTokenProvider(func(ctx context.Context) (string, time.Time, error) {
+//		secret, err := vault.Read(ctx, "astra/token")
+//		if err != nil {
+//			return "", time.Time{}, err
+//		}
+//		return secret.Token, secret.Expiry, nil
+//	}, 0)
+func NewCachingTokenProvider(refresh RefreshFunc, refreshBefore time.Duration) *CachingTokenProvider {
+	if refreshBefore <= 0 {
+		refreshBefore = DefaultRefreshBefore
+	}
+	return &CachingTokenProvider{
+		refresh:       refresh,
+		refreshBefore: refreshBefore,
+	}
+}
+
+// Token implements [TokenProvider].
+func (p *CachingTokenProvider) Token(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.token != "" && (p.expiry.IsZero() || time.Now().Before(p.expiry.Add(-p.refreshBefore))) {
+		return p.token, nil
+	}
+	token, expiry, err := p.refresh(ctx)
+	if err != nil {
+		return "", err
+	}
+	p.token, p.expiry = token, expiry
+	return p.token, nil
+}
+
+// InvalidateToken implements [TokenInvalidator], forcing a refresh on the
+// next call to Token.
+func (p *CachingTokenProvider) InvalidateToken(token string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.token == token {
+		p.token = ""
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/datastax/astra-db-go/options"
+)
+
+func TestStaticAndEnvTokenProviders(t *testing.T) {
+	ctx := context.Background()
+	if token, _ := options.NewStaticTokenProvider("abc").Token(ctx); token != "abc" {
+		t.Errorf("unexpected static token %q", token)
+	}
+
+	p := options.NewEnvTokenProvider("ASTRA_TEST_TOKEN")
+	t.Setenv("ASTRA_TEST_TOKEN", "")
+	if _, err := p.Token(ctx); err == nil {
+		t.Error("expected error for empty variable")
+	}
+	t.Setenv("ASTRA_TEST_TOKEN", "from-env")
+	if token, _ := p.Token(ctx); token != "from-env" {
+		t.Errorf("unexpected env token %q", token)
+	}
+}
+
+func TestFileTokenProvider(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("first\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	p := options.NewFileTokenProvider(path)
+	if token, err := p.Token(ctx); err != nil || token != "first" {
+		t.Fatalf("Token() = %q, %v", token, err)
+	}
+
+	// Rotation is picked up from the modification time and size
+	if err := os.WriteFile(path, []byte("second-token"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Second)
+	os.Chtimes(path, later, later)
+	if token, _ := p.Token(ctx); token != "second-token" {
+		t.Errorf("expected rotated token, got %q", token)
+	}
+}
+
+func TestCachingTokenProvider(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+	expiry := time.Now().Add(time.Hour)
+	p := options.NewCachingTokenProvider(func(context.Context) (string, time.Time, error) {
+		calls++
+		return fmt.Sprintf("token-%d", calls), expiry, nil
+	}, time.Minute)
+
+	for range 3 {
+		if token, _ := p.Token(ctx); token != "token-1" {
+			t.Fatalf("expected cached token-1, got %q", token)
+		}
+	}
+
+	// A stale token doesn't invalidate a newer one
+	p.InvalidateToken("stale")
+	if token, _ := p.Token(ctx); token != "token-1" {
+		t.Errorf("expected token-1 after stale invalidation, got %q", token)
+	}
+	p.InvalidateToken("token-1")
+	if token, _ := p.Token(ctx); token != "token-2" {
+		t.Errorf("expected token-2 after invalidation, got %q", token)
+	}
+
+	// Tokens within refreshBefore of expiry are refreshed
+	expiry = time.Now().Add(30 * time.Second)
+	p.InvalidateToken("token-2")
+	p.Token(ctx)
+	if token, _ := p.Token(ctx); token != "token-4" {
+		t.Errorf("expected refresh before expiry, got %q", token)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/datastax/astra-db-go/results"
+)
+
+// maxTrackedWarnings bounds the memory used for deduplication. When more
+// distinct warnings than this have been seen, the history is reset.
+const maxTrackedWarnings = 1024
+
+// SlogWarningHandlerOptions configures [NewSlogWarningHandler].
+type SlogWarningHandlerOptions struct {
+	// Logger receives the warnings. Defaults to [slog.Default].
+	Logger *slog.Logger
+
+	// Level is the level warnings are logged at. Defaults to [slog.LevelWarn].
+	Level slog.Leveler
+
+	// Window is how long an identical warning (same code and message) is
+	// suppressed after being logged. Zero logs each distinct warning once.
+	Window time.Duration
+}
+
+// warningLog tracks when a warning was last logged and how many times it
+// was suppressed since.
+type warningLog struct {
+	last       time.Time
+	suppressed int
+}
+
+// NewSlogWarningHandler returns a [WarningHandler] that logs warnings via
+// [log/slog]. Identical warnings are deduplicated so a query that runs in a
+// loop doesn't flood the logs; the number of suppressed repeats is reported
+// the next time the warning is logged.
+//
+// Example usage:
+//
+//	client := astradb.NewClient(
+//		options.WithToken("..."),
+//		options.WithWarningHandler(options.NewSlogWarningHandler(options.SlogWarningHandlerOptions{
+//			Window: time.Minute,
+//		})),
+//	)
+func NewSlogWarningHandler(opts SlogWarningHandlerOptions) WarningHandler {
+	if opts.Level == nil {
+		opts.Level = slog.LevelWarn
+	}
+	var (
+		mu   sync.Mutex
+		seen = make(map[string]*warningLog)
+	)
+	return func(w results.Warning) {
+		key := w.ErrorCode + "\x00" + w.Message
+		now := time.Now()
+
+		mu.Lock()
+		entry, ok := seen[key]
+		if ok && (opts.Window == 0 || now.Sub(entry.last) < opts.Window) {
+			entry.suppressed++
+			mu.Unlock()
+			return
+		}
+		suppressed := 0
+		if ok {
+			suppressed = entry.suppressed
+		} else if len(seen) >= maxTrackedWarnings {
+			seen = make(map[string]*warningLog)
+		}
+		seen[key] = &warningLog{last: now}
+		mu.Unlock()
+
+		logger := opts.Logger
+		if logger == nil {
+			logger = slog.Default()
+		}
+		attrs := []slog.Attr{
+			slog.String("code", w.ErrorCode),
+			slog.String("message", w.Message),
+		}
+		if w.Title != "" {
+			attrs = append(attrs, slog.String("title", w.Title))
+		}
+		if suppressed > 0 {
+			attrs = append(attrs, slog.Int("suppressed", suppressed))
+		}
+		logger.LogAttrs(context.Background(), opts.Level.Level(), "Data API warning", attrs...)
+	}
+}
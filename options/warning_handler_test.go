@@ -0,0 +1,86 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options_test
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/datastax/astra-db-go/options"
+	"github.com/datastax/astra-db-go/results"
+)
+
+func TestSlogWarningHandler_Dedup(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	handler := options.NewSlogWarningHandler(options.SlogWarningHandlerOptions{Logger: logger})
+
+	zeroFilter := results.Warning{ErrorCode: results.WarningZeroFilterOperations, Message: "Zero filters"}
+	missingIndex := results.Warning{ErrorCode: results.WarningMissingIndex, Message: "Missing index"}
+	for i := 0; i < 5; i++ {
+		handler(zeroFilter)
+	}
+	handler(missingIndex)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, got %d:\n%s", len(lines), buf.String())
+	}
+	if !strings.Contains(lines[0], "code="+results.WarningZeroFilterOperations) {
+		t.Errorf("expected first line to log zero filter warning: %s", lines[0])
+	}
+	if !strings.Contains(lines[0], "level=WARN") {
+		t.Errorf("expected warn level: %s", lines[0])
+	}
+	if !strings.Contains(lines[1], "code="+results.WarningMissingIndex) {
+		t.Errorf("expected second line to log missing index warning: %s", lines[1])
+	}
+}
+
+func TestSlogWarningHandler_Window(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	handler := options.NewSlogWarningHandler(options.SlogWarningHandlerOptions{
+		Logger: logger,
+		Window: time.Millisecond,
+	})
+
+	w := results.Warning{ErrorCode: results.WarningInMemorySorting, Message: "sorted in memory"}
+	handler(w)
+	handler(w)
+	time.Sleep(5 * time.Millisecond)
+	handler(w)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, got %d:\n%s", len(lines), buf.String())
+	}
+	if !strings.Contains(lines[1], "suppressed=1") {
+		t.Errorf("expected suppressed count on second line: %s", lines[1])
+	}
+}
+
+func TestWarningsHas(t *testing.T) {
+	ws := results.Warnings{{ErrorCode: results.WarningMissingIndex}}
+	if !ws.Has(results.WarningMissingIndex) {
+		t.Error("expected Has to find MISSING_INDEX")
+	}
+	if ws.Has(results.WarningZeroFilterOperations) {
+		t.Error("did not expect Has to find ZERO_FILTER_OPERATIONS")
+	}
+}
@@ -0,0 +1,352 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otel instruments Data API commands with OpenTelemetry traces and
+// metrics. It is built on [options.CommandInterceptor], so it can be enabled
+// on a client, database, collection or table:
+//
+//	client := astradb.NewClient(
+//		options.WithToken("..."),
+//		otel.WithTelemetry(otel.Options{}),
+//	)
+//
+// Each command gets a client span with the attributes below, and trace
+// context is propagated to the Data API in the request headers. The pages
+// of a cursor returned by Find are child spans of a span for the whole
+// query, which starts with the first page and ends with the last one, the
+// first error or when the cursor is closed, whichever comes first.
+//
+// Metrics are recorded with the same attributes, minus the per-request
+// ones: the [MetricDuration] histogram, and the [MetricErrors] and
+// [MetricRetries] counters.
+//
+// The package is a separate module, so programs that don't import it don't
+// depend on OpenTelemetry.
+package otel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	astradb "github.com/datastax/astra-db-go"
+	"github.com/datastax/astra-db-go/options"
+	"github.com/datastax/astra-db-go/results"
+	global "go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope of the tracer and meter.
+const ScopeName = "github.com/datastax/astra-db-go/otel"
+
+// Span and metric attributes.
+const (
+	// AttrSystem is always "astra".
+	AttrSystem = attribute.Key("db.system")
+	// AttrOperation is the command name, such as "find".
+	AttrOperation = attribute.Key("db.operation")
+	// AttrCollection is the collection or table name, if any.
+	AttrCollection = attribute.Key("db.collection.name")
+	// AttrKeyspace is the keyspace, if any.
+	AttrKeyspace = attribute.Key("db.namespace")
+	// AttrErrorType is the Data API error code, HTTP status code or
+	// "_OTHER" for failed commands.
+	AttrErrorType = attribute.Key("error.type")
+	// AttrPage is the 1-based page number of a cursor fetch.
+	AttrPage = attribute.Key("astra.cursor.page")
+	// AttrPages is the number of pages fetched, on the span for a query.
+	AttrPages = attribute.Key("astra.cursor.pages")
+	// AttrDocumentsReturned is the number of documents or rows returned.
+	AttrDocumentsReturned = attribute.Key("astra.documents.returned")
+	// AttrDocumentsInserted is the number of documents or rows inserted.
+	AttrDocumentsInserted = attribute.Key("astra.documents.inserted")
+	// AttrDocumentsMatched is the number of documents matched by an update.
+	AttrDocumentsMatched = attribute.Key("astra.documents.matched")
+	// AttrDocumentsModified is the number of documents modified by an update.
+	AttrDocumentsModified = attribute.Key("astra.documents.modified")
+	// AttrDocumentsDeleted is the number of documents or rows deleted.
+	AttrDocumentsDeleted = attribute.Key("astra.documents.deleted")
+	// AttrWarnings is the number of warnings returned.
+	AttrWarnings = attribute.Key("astra.warnings")
+	// AttrRetries is the number of times the request was retried.
+	AttrRetries = attribute.Key("astra.retries")
+)
+
+// Metric names.
+const (
+	MetricDuration = "db.client.operation.duration"
+	MetricErrors   = "astra.client.errors"
+	MetricRetries  = "astra.client.retries"
+)
+
+// Options configures [NewInterceptor].
+type Options struct {
+	// TracerProvider creates the tracer. Defaults to the global provider.
+	TracerProvider trace.TracerProvider
+
+	// MeterProvider creates the meter. Defaults to the global provider.
+	MeterProvider metric.MeterProvider
+
+	// Propagator injects trace context into request headers. Defaults to
+	// the global propagator.
+	Propagator propagation.TextMapPropagator
+}
+
+// instruments holds the tracer and metric instruments of an interceptor.
+type instruments struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+	duration   metric.Float64Histogram
+	errors     metric.Int64Counter
+	retries    metric.Int64Counter
+}
+
+// WithTelemetry returns an option that instruments commands as described
+// by [NewInterceptor].
+func WithTelemetry(opts Options) options.APIOption {
+	return options.WithCommandInterceptor(NewInterceptor(opts))
+}
+
+// NewInterceptor returns an interceptor that traces each command and
+// records its metrics. Errors creating instruments are reported to the
+// global OpenTelemetry error handler, and the failed instruments are
+// replaced with no-ops.
+func NewInterceptor(opts Options) options.CommandInterceptor {
+	if opts.TracerProvider == nil {
+		opts.TracerProvider = global.GetTracerProvider()
+	}
+	if opts.MeterProvider == nil {
+		opts.MeterProvider = global.GetMeterProvider()
+	}
+	if opts.Propagator == nil {
+		opts.Propagator = global.GetTextMapPropagator()
+	}
+
+	meter := opts.MeterProvider.Meter(ScopeName)
+	var err, errs error
+	in := &instruments{
+		tracer:     opts.TracerProvider.Tracer(ScopeName),
+		propagator: opts.Propagator,
+	}
+	in.duration, err = meter.Float64Histogram(MetricDuration,
+		metric.WithDescription("Duration of Data API commands."),
+		metric.WithUnit("s"))
+	errs = errors.Join(errs, err)
+	in.errors, err = meter.Int64Counter(MetricErrors,
+		metric.WithDescription("Number of failed Data API commands."),
+		metric.WithUnit("{command}"))
+	errs = errors.Join(errs, err)
+	in.retries, err = meter.Int64Counter(MetricRetries,
+		metric.WithDescription("Number of retried Data API requests."),
+		metric.WithUnit("{request}"))
+	errs = errors.Join(errs, err)
+	if errs != nil {
+		global.Handle(errs)
+	}
+	return in.intercept
+}
+
+// intercept implements [options.CommandInterceptor].
+func (in *instruments) intercept(ctx context.Context, cmd *options.CommandInfo, next options.CommandHandler) ([]byte, results.Warnings, error) {
+	attrs := []attribute.KeyValue{
+		AttrSystem.String("astra"),
+		AttrOperation.String(cmd.Name),
+	}
+	if cmd.Resource != "" {
+		attrs = append(attrs, AttrCollection.String(cmd.Resource))
+	}
+	if cmd.Keyspace != "" {
+		attrs = append(attrs, AttrKeyspace.String(cmd.Keyspace))
+	}
+	name := cmd.Name
+	if cmd.Resource != "" {
+		name += " " + cmd.Resource
+	}
+
+	// Cursor pages are children of the span for the whole query
+	var query trace.Span
+	if cur := cmd.Cursor; cur != nil {
+		if cur.Page == 1 {
+			parent := cur.Context
+			if parent == nil {
+				parent = ctx
+			}
+			cur.Context, _ = in.tracer.Start(parent, name, trace.WithAttributes(attrs...))
+			span := trace.SpanFromContext(cur.Context)
+			cur.OnClose(func() { endQuery(span, cur.Page, nil) })
+		}
+		query = trace.SpanFromContext(cur.Context)
+		ctx = trace.ContextWithSpan(ctx, query)
+		name += " page"
+	}
+
+	spanAttrs := attrs
+	if cmd.Cursor != nil {
+		spanAttrs = append(spanAttrs[:len(spanAttrs):len(spanAttrs)], AttrPage.Int(cmd.Cursor.Page))
+	}
+	ctx, span := in.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(spanAttrs...))
+	defer span.End()
+
+	if cmd.Options.Headers == nil {
+		cmd.Options.Headers = make(map[string]string)
+	}
+	in.propagator.Inject(ctx, propagation.MapCarrier(cmd.Options.Headers))
+
+	start := time.Now()
+	body, warnings, err := next(ctx, cmd)
+	elapsed := time.Since(start)
+
+	// The response is only decoded for the attributes of a recording span,
+	// or to find the last page of a recording query. A page that can't be
+	// decoded is taken as the last one, as the cursor fails on it too.
+	lastPage := true
+	switch {
+	case err != nil || len(body) == 0:
+	case span.IsRecording():
+		var resp response
+		if decode(cmd.Name, body, &resp) {
+			span.SetAttributes(resp.attributes()...)
+			lastPage = isLastPage(resp.Data.NextPageState)
+		}
+	case query != nil && query.IsRecording():
+		var page pageState
+		if decode(cmd.Name, body, &page) {
+			lastPage = isLastPage(page.Data.NextPageState)
+		}
+	}
+	if span.IsRecording() {
+		span.SetAttributes(AttrWarnings.Int(len(warnings)), AttrRetries.Int(cmd.Retries))
+	}
+	if err != nil {
+		attrs = append(attrs, AttrErrorType.String(errorType(err)))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		in.errors.Add(ctx, 1, metric.WithAttributes(attrs...))
+	}
+	in.duration.Record(ctx, elapsed.Seconds(), metric.WithAttributes(attrs...))
+	if cmd.Retries > 0 {
+		in.retries.Add(ctx, int64(cmd.Retries), metric.WithAttributes(attrs...))
+	}
+
+	if query != nil && lastPage {
+		endQuery(query, cmd.Cursor.Page, err)
+	}
+	return body, warnings, err
+}
+
+// decode decodes the response body of the named command into v. Errors
+// are reported to the global OpenTelemetry error handler.
+func decode(name string, body []byte, v any) bool {
+	if err := json.Unmarshal(body, v); err != nil {
+		global.Handle(fmt.Errorf("decoding %s response: %w", name, err))
+		return false
+	}
+	return true
+}
+
+// endQuery ends the span for a query after pages pages, with err if the
+// last page failed. Spans that have already ended are left as they are.
+func endQuery(query trace.Span, pages int, err error) {
+	if !query.IsRecording() {
+		return
+	}
+	query.SetAttributes(AttrPages.Int(pages))
+	if err != nil {
+		query.SetStatus(codes.Error, err.Error())
+	}
+	query.End()
+}
+
+// errorType returns the error.type attribute for err.
+func errorType(err error) string {
+	var respErr *astradb.DataAPIResponseError
+	if errors.As(err, &respErr) && len(respErr.Errors) > 0 && respErr.Errors[0].ErrorCode != "" {
+		return respErr.Errors[0].ErrorCode
+	}
+	var httpErr *astradb.HTTPError
+	if errors.As(err, &httpErr) {
+		return strconv.Itoa(httpErr.StatusCode)
+	}
+	return "_OTHER"
+}
+
+// skip consumes a JSON value without decoding or copying it, so that
+// arrays of skip only count their elements.
+type skip struct{}
+
+// UnmarshalJSON implements [json.Unmarshaler].
+func (*skip) UnmarshalJSON([]byte) error {
+	return nil
+}
+
+// pageState holds the page state of a find response.
+type pageState struct {
+	Data struct {
+		NextPageState *string `json:"nextPageState"`
+	} `json:"data"`
+}
+
+// isLastPage reports whether a response with the next page state state is
+// the last page of a query.
+func isLastPage(state *string) bool {
+	return state == nil || *state == ""
+}
+
+// response holds the parts of a Data API response that are recorded on spans.
+type response struct {
+	Data struct {
+		Documents     []skip  `json:"documents"`
+		Document      *skip   `json:"document"`
+		NextPageState *string `json:"nextPageState"`
+	} `json:"data"`
+	Status struct {
+		InsertedIds   []skip `json:"insertedIds"`
+		MatchedCount  *int   `json:"matchedCount"`
+		ModifiedCount *int   `json:"modifiedCount"`
+		DeletedCount  *int   `json:"deletedCount"`
+	} `json:"status"`
+}
+
+// attributes returns the document counts in r.
+func (r *response) attributes() []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	switch {
+	case r.Data.Documents != nil:
+		attrs = append(attrs, AttrDocumentsReturned.Int(len(r.Data.Documents)))
+	case r.Data.Document != nil:
+		attrs = append(attrs, AttrDocumentsReturned.Int(1))
+	}
+	if r.Status.InsertedIds != nil {
+		attrs = append(attrs, AttrDocumentsInserted.Int(len(r.Status.InsertedIds)))
+	}
+	if r.Status.MatchedCount != nil {
+		attrs = append(attrs, AttrDocumentsMatched.Int(*r.Status.MatchedCount))
+	}
+	if r.Status.ModifiedCount != nil {
+		attrs = append(attrs, AttrDocumentsModified.Int(*r.Status.ModifiedCount))
+	}
+	if r.Status.DeletedCount != nil {
+		attrs = append(attrs, AttrDocumentsDeleted.Int(*r.Status.DeletedCount))
+	}
+	return attrs
+}
@@ -0,0 +1,236 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otel_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	astradb "github.com/datastax/astra-db-go"
+	"github.com/datastax/astra-db-go/astradbtest"
+	"github.com/datastax/astra-db-go/filter"
+	"github.com/datastax/astra-db-go/options"
+	"github.com/datastax/astra-db-go/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// headerRecorder records the traceparent header of each request.
+type headerRecorder struct {
+	parents []string
+}
+
+func (h *headerRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	h.parents = append(h.parents, req.Header.Get("traceparent"))
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// attr returns the value of key in attrs.
+func attr(attrs []attribute.KeyValue, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range attrs {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestTelemetry(t *testing.T) {
+	fake := astradbtest.New(t, astradbtest.WithPageSize(2))
+	spans := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	headers := &headerRecorder{}
+
+	db := astradb.NewClient(
+		options.WithToken("token"),
+		options.WithHTTPClient(&http.Client{Transport: headers}),
+		otel.WithTelemetry(otel.Options{
+			TracerProvider: tp,
+			MeterProvider:  mp,
+			Propagator:     propagation.TraceContext{},
+		}),
+	).Database(fake.URL)
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+	coll, err := db.CreateCollection(ctx, "books", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	docs := make([]map[string]any, 5)
+	for i := range docs {
+		docs[i] = map[string]any{"_id": fmt.Sprint(i)}
+	}
+	if _, err := coll.InsertMany(ctx, docs); err != nil {
+		t.Fatal(err)
+	}
+	var found []map[string]any
+	if err := coll.Find(ctx, filter.F{}).All(ctx, &found); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := coll.InsertOne(ctx, map[string]any{"_id": "0"}); err == nil {
+		t.Fatal("expected duplicate insert to fail")
+	}
+	parent.End()
+
+	ended := spans.Ended()
+	byName := make(map[string][]sdktrace.ReadOnlySpan)
+	for _, s := range ended {
+		byName[s.Name()] = append(byName[s.Name()], s)
+	}
+
+	insert := byName["insertMany books"]
+	if len(insert) != 1 {
+		t.Fatalf("expected one insertMany span, got %v", byName)
+	}
+	for key, want := range map[attribute.Key]attribute.Value{
+		otel.AttrSystem:            attribute.StringValue("astra"),
+		otel.AttrOperation:         attribute.StringValue("insertMany"),
+		otel.AttrCollection:        attribute.StringValue("books"),
+		otel.AttrKeyspace:          attribute.StringValue("default_keyspace"),
+		otel.AttrDocumentsInserted: attribute.IntValue(5),
+		otel.AttrWarnings:          attribute.IntValue(0),
+	} {
+		if got, _ := attr(insert[0].Attributes(), key); got != want {
+			t.Errorf("expected %s=%v, got %v", key, want.Emit(), got.Emit())
+		}
+	}
+	if insert[0].Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Error("expected command span to be a child of the caller's span")
+	}
+
+	// Five documents at two per page take three pages, under one query span
+	query := byName["find books"]
+	pages := byName["find books page"]
+	if len(query) != 1 || len(pages) != 3 {
+		t.Fatalf("expected one query span and three page spans, got %v", byName)
+	}
+	if query[0].Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Error("expected query span to be a child of the caller's span")
+	}
+	if got, _ := attr(query[0].Attributes(), otel.AttrPages); got.AsInt64() != 3 {
+		t.Errorf("expected 3 pages on query span, got %v", got.Emit())
+	}
+	for i, p := range pages {
+		if p.Parent().SpanID() != query[0].SpanContext().SpanID() {
+			t.Errorf("expected page %d to be a child of the query span", i+1)
+		}
+		if got, _ := attr(p.Attributes(), otel.AttrPage); got.AsInt64() != int64(i+1) {
+			t.Errorf("expected page number %d, got %v", i+1, got.Emit())
+		}
+	}
+	if got, _ := attr(pages[2].Attributes(), otel.AttrDocumentsReturned); got.AsInt64() != 1 {
+		t.Errorf("expected 1 document on the last page, got %v", got.Emit())
+	}
+
+	failed := byName["insertOne books"]
+	if len(failed) != 1 || failed[0].Status().Code != codes.Error {
+		t.Fatalf("expected a failed insertOne span, got %v", failed)
+	}
+
+	// Every request carries the trace context of its span
+	if len(headers.parents) != 6 {
+		t.Fatalf("expected 6 requests, got %d", len(headers.parents))
+	}
+	want := fmt.Sprintf("00-%s-%s-01", insert[0].SpanContext().TraceID(), insert[0].SpanContext().SpanID())
+	if headers.parents[1] != want {
+		t.Errorf("expected traceparent %s, got %s", want, headers.parents[1])
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	metrics := make(map[string]metricdata.Aggregation)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+	var durations uint64
+	for _, dp := range metrics[otel.MetricDuration].(metricdata.Histogram[float64]).DataPoints {
+		durations += dp.Count
+	}
+	if durations != 6 {
+		t.Errorf("expected 6 recorded durations, got %d", durations)
+	}
+	errs := metrics[otel.MetricErrors].(metricdata.Sum[int64]).DataPoints
+	if len(errs) != 1 || errs[0].Value != 1 {
+		t.Fatalf("expected one error, got %+v", errs)
+	}
+	if got, _ := errs[0].Attributes.Value(otel.AttrErrorType); got.AsString() != "DOCUMENT_ALREADY_EXISTS" {
+		t.Errorf("expected error type DOCUMENT_ALREADY_EXISTS, got %v", got.Emit())
+	}
+}
+
+func TestQuerySpanEndsOnClose(t *testing.T) {
+	fake := astradbtest.New(t, astradbtest.WithPageSize(2))
+	spans := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+	db := astradb.NewClient(
+		options.WithToken("token"),
+		otel.WithTelemetry(otel.Options{TracerProvider: tp}),
+	).Database(fake.URL)
+
+	ctx := context.Background()
+	coll, err := db.CreateCollection(ctx, "books", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	docs := make([]map[string]any, 5)
+	for i := range docs {
+		docs[i] = map[string]any{"_id": fmt.Sprint(i)}
+	}
+	if _, err := coll.InsertMany(ctx, docs); err != nil {
+		t.Fatal(err)
+	}
+
+	cur := coll.Find(ctx, filter.F{})
+	if !cur.Next(ctx) {
+		t.Fatalf("expected a document, got %v", cur.Err())
+	}
+	for _, s := range spans.Ended() {
+		if s.Name() == "find books" {
+			t.Fatal("expected query span to be open before Close")
+		}
+	}
+	if err := cur.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := cur.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	var query []sdktrace.ReadOnlySpan
+	for _, s := range spans.Ended() {
+		if s.Name() == "find books" {
+			query = append(query, s)
+		}
+	}
+	if len(query) != 1 {
+		t.Fatalf("expected one ended query span, got %d", len(query))
+	}
+	if got, _ := attr(query[0].Attributes(), otel.AttrPages); got.AsInt64() != 1 {
+		t.Errorf("expected 1 page on query span, got %v", got.Emit())
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package astradb
+
+import (
+	"encoding/json"
+
+	"github.com/datastax/astra-db-go/codec"
+)
+
+// findPage is the response of the find command.
+type findPage struct {
+	Data struct {
+		Documents     []json.RawMessage `json:"documents"`
+		NextPageState *string           `json:"nextPageState"`
+	} `json:"data"`
+}
+
+// decodePage returns the documents and next page state of the find
+// response body, decoded with c.
+func decodePage(c codec.Codec, body []byte) (docs []json.RawMessage, nextPageState *string, err error) {
+	var page findPage
+	if err := c.Decode(body, &page); err != nil {
+		return nil, nil, err
+	}
+	return page.Data.Documents, page.Data.NextPageState, nil
+}
@@ -0,0 +1,60 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package astradb
+
+import (
+	"testing"
+
+	"github.com/datastax/astra-db-go/codec"
+)
+
+func TestDecodePage(t *testing.T) {
+	body := []byte(`{"status":{"warnings":[]}, "data":{"documents": [ {"_id":"1","tags":["a",{"b":[1,"]}"]}]} ,
+		{"_id":"2"},"three" ],"next\u0050ageState":"abc"}}`)
+	docs, next, err := decodePage(codec.JSON{}, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{`{"_id":"1","tags":["a",{"b":[1,"]}"]}]}`, `{"_id":"2"}`, `"three"`}
+	if len(docs) != len(want) {
+		t.Fatalf("expected %d documents, got %q", len(want), docs)
+	}
+	for i, doc := range docs {
+		if string(doc) != want[i] {
+			t.Errorf("document %d: expected %s, got %s", i, want[i], doc)
+		}
+	}
+	if next == nil || *next != "abc" {
+		t.Errorf("expected next page state abc, got %v", next)
+	}
+
+	for _, tt := range []struct {
+		json    string
+		wantErr bool
+	}{
+		{`{"data":{"documents":null,"nextPageState":null}}`, false},
+		{`{"data":{"documents":[]}}`, false},
+		{`{}`, false},
+		{`{"data":{"documents":{}}}`, true},
+		{`{"data":{"documents":[1,]}}`, true},
+		{`{"data":{"documents":[1]}} x`, true},
+		{``, true},
+	} {
+		docs, next, err := decodePage(codec.JSON{}, []byte(tt.json))
+		if (err != nil) != tt.wantErr || len(docs) != 0 && tt.wantErr || next != nil {
+			t.Errorf("%s: unexpected documents %q, next page state %v, error %v", tt.json, docs, next, err)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package projection defines projections that select which fields or
+// columns are returned by Astra DB queries.
+//
+// Example:
+//
+//	p := projection.Include("title", "author").Exclude("_id").Slice("reviews", 0, 5)
+//	// Marshals to: {"title":true,"author":true,"_id":false,"reviews":{"$slice":[0,5]}}
+package projection
+
+import "github.com/datastax/astra-db-go/internal/ordered"
+
+// Wildcard is the special field name that matches every field.
+const Wildcard = "*"
+
+// field is a single projection entry.
+type field = ordered.Field
+
+// sliceOp is the $slice operator applied to an array field.
+type sliceOp struct {
+	Slice any `json:"$slice"`
+}
+
+// Projection is an ordered projection. Build one with [Include], [Exclude],
+// [All] or [None] and chain further fields with methods. The zero value is
+// an empty projection.
+type Projection struct {
+	fields []field
+}
+
+// New returns an empty Projection.
+func New() *Projection {
+	return &Projection{}
+}
+
+// Include returns a Projection that includes the named fields.
+func Include(names ...string) *Projection {
+	return New().Include(names...)
+}
+
+// Exclude returns a Projection that excludes the named fields.
+func Exclude(names ...string) *Projection {
+	return New().Exclude(names...)
+}
+
+// All returns a Projection that includes every field ({"*": true}).
+func All() *Projection {
+	return New().Include(Wildcard)
+}
+
+// None returns a Projection that excludes every field ({"*": false}).
+func None() *Projection {
+	return New().Exclude(Wildcard)
+}
+
+// Include appends the named fields as included.
+func (p *Projection) Include(names ...string) *Projection {
+	for _, name := range names {
+		p.add(name, true)
+	}
+	return p
+}
+
+// Exclude appends the named fields as excluded.
+func (p *Projection) Exclude(names ...string) *Projection {
+	for _, name := range names {
+		p.add(name, false)
+	}
+	return p
+}
+
+// Slice returns limit elements of the array field name starting at skip.
+// A negative skip counts from the end of the array.
+//
+// Only supported on collections.
+func (p *Projection) Slice(name string, skip, limit int) *Projection {
+	return p.add(name, sliceOp{Slice: []int{skip, limit}})
+}
+
+// SliceN returns the first n elements of the array field name, or the
+// last -n elements when n is negative.
+//
+// Only supported on collections.
+func (p *Projection) SliceN(name string, n int) *Projection {
+	return p.add(name, sliceOp{Slice: n})
+}
+
+// add appends name with value. Adding a name twice replaces the value
+// but keeps the original position.
+func (p *Projection) add(name string, value any) *Projection {
+	for i := range p.fields {
+		if p.fields[i].Name == name {
+			p.fields[i].Value = value
+			return p
+		}
+	}
+	p.fields = append(p.fields, field{Name: name, Value: value})
+	return p
+}
+
+// Len returns the number of fields in the projection.
+func (p *Projection) Len() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.fields)
+}
+
+// Fields returns the field names in the order they were added.
+func (p *Projection) Fields() []string {
+	if p == nil {
+		return nil
+	}
+	names := make([]string, len(p.fields))
+	for i, f := range p.fields {
+		names[i] = f.Name
+	}
+	return names
+}
+
+// Includes reports whether name is explicitly included. Slices count as
+// included.
+func (p *Projection) Includes(name string) bool {
+	if p == nil {
+		return false
+	}
+	for _, f := range p.fields {
+		if f.Name == name {
+			v, ok := f.Value.(bool)
+			return !ok || v
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements [json.Marshaler], writing fields in insertion order.
+func (p *Projection) MarshalJSON() ([]byte, error) {
+	if p == nil {
+		return []byte("null"), nil
+	}
+	return ordered.Marshal(p.fields)
+}
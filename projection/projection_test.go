@@ -0,0 +1,102 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package projection_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/datastax/astra-db-go/projection"
+)
+
+func TestProjectionMarshal(t *testing.T) {
+	tests := []struct {
+		name       string
+		projection *projection.Projection
+		expected   string
+	}{
+		{
+			name:       "include",
+			projection: projection.Include("title", "author"),
+			expected:   `{"title":true,"author":true}`,
+		},
+		{
+			name:       "exclude",
+			projection: projection.Exclude("_id", "$vector"),
+			expected:   `{"_id":false,"$vector":false}`,
+		},
+		{
+			name:       "include and exclude",
+			projection: projection.Include("title").Exclude("_id"),
+			expected:   `{"title":true,"_id":false}`,
+		},
+		{
+			name:       "all",
+			projection: projection.All(),
+			expected:   `{"*":true}`,
+		},
+		{
+			name:       "none",
+			projection: projection.None(),
+			expected:   `{"*":false}`,
+		},
+		{
+			name:       "slice with skip and limit",
+			projection: projection.Include("title").Slice("reviews", 2, 5),
+			expected:   `{"title":true,"reviews":{"$slice":[2,5]}}`,
+		},
+		{
+			name:       "slice last n",
+			projection: projection.New().SliceN("reviews", -3),
+			expected:   `{"reviews":{"$slice":-3}}`,
+		},
+		{
+			name:       "duplicate field keeps position",
+			projection: projection.Include("a", "b").Exclude("a"),
+			expected:   `{"a":false,"b":true}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.projection)
+			if err != nil {
+				t.Fatalf("json.Marshal: %v", err)
+			}
+			if string(b) != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, string(b))
+			}
+		})
+	}
+}
+
+func TestProjectionIncludes(t *testing.T) {
+	p := projection.Include("title").Exclude("_id").SliceN("reviews", 2)
+	if !p.Includes("title") {
+		t.Error("expected title to be included")
+	}
+	if p.Includes("_id") {
+		t.Error("expected _id to be excluded")
+	}
+	if !p.Includes("reviews") {
+		t.Error("expected sliced field to count as included")
+	}
+	if p.Includes("missing") {
+		t.Error("expected missing field to not be included")
+	}
+	if p.Len() != 3 {
+		t.Errorf("expected 3 fields, got %d", p.Len())
+	}
+}
@@ -0,0 +1,386 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package astradb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/datastax/astra-db-go/options"
+	"github.com/datastax/astra-db-go/table"
+)
+
+// Authentication methods an embedding or reranking provider may support.
+const (
+	// AuthNone means no credentials are needed, e.g. for Astra-hosted models.
+	AuthNone = "NONE"
+	// AuthHeader means an API key is passed with each request in a header.
+	AuthHeader = "HEADER"
+	// AuthSharedSecret means an API key stored in Astra is referenced by
+	// the "providerKey" authentication entry.
+	AuthSharedSecret = "SHARED_SECRET"
+)
+
+// Model support statuses reported in [ModelSupport].
+const (
+	ModelSupported  = "SUPPORTED"
+	ModelDeprecated = "DEPRECATED"
+	ModelEndOfLife  = "END_OF_LIFE"
+)
+
+// vectorDimensionParam is the model parameter that corresponds to the
+// vector dimension rather than to a service parameter.
+const vectorDimensionParam = "vectorDimension"
+
+// ProviderAuthentication describes one supported authentication method.
+type ProviderAuthentication struct {
+	Enabled bool                `json:"enabled"`
+	Tokens  []ProviderAuthToken `json:"tokens,omitempty"`
+}
+
+// ProviderAuthToken describes a header accepted by the Data API and the
+// header it is forwarded to the provider as.
+type ProviderAuthToken struct {
+	Accepted  string `json:"accepted"`
+	Forwarded string `json:"forwarded"`
+}
+
+// ProviderParameter describes a provider or model parameter.
+type ProviderParameter struct {
+	Name         string              `json:"name"`
+	DisplayName  string              `json:"displayName,omitempty"`
+	Type         string              `json:"type"`
+	Required     bool                `json:"required"`
+	DefaultValue string              `json:"defaultValue,omitempty"`
+	Validation   ParameterValidation `json:"validation"`
+	Help         string              `json:"help,omitempty"`
+}
+
+// ParameterValidation constrains the values of a parameter.
+type ParameterValidation struct {
+	// NumericRange is the inclusive [min, max] range of a numeric parameter.
+	NumericRange []float64 `json:"numericRange,omitempty"`
+
+	// Options lists the allowed values of a numeric parameter.
+	Options []float64 `json:"options,omitempty"`
+}
+
+// ModelSupport describes whether a model is still supported.
+type ModelSupport struct {
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// EmbeddingModel describes an embedding model.
+type EmbeddingModel struct {
+	Name string `json:"name"`
+
+	// VectorDimension is the fixed dimension of the model, or nil when the
+	// dimension is configurable via the "vectorDimension" parameter.
+	VectorDimension *int                `json:"vectorDimension"`
+	Parameters      []ProviderParameter `json:"parameters,omitempty"`
+	APIModelSupport ModelSupport        `json:"apiModelSupport"`
+}
+
+// EmbeddingProvider describes an embedding provider available for vectorize.
+type EmbeddingProvider struct {
+	DisplayName             string                            `json:"displayName"`
+	URL                     string                            `json:"url,omitempty"`
+	SupportedAuthentication map[string]ProviderAuthentication `json:"supportedAuthentication"`
+	Parameters              []ProviderParameter               `json:"parameters,omitempty"`
+	Models                  []EmbeddingModel                  `json:"models"`
+}
+
+// Model returns the named model, or nil if the provider has no such model.
+func (p *EmbeddingProvider) Model(name string) *EmbeddingModel {
+	for i := range p.Models {
+		if p.Models[i].Name == name {
+			return &p.Models[i]
+		}
+	}
+	return nil
+}
+
+// RerankingModel describes a reranking model.
+type RerankingModel struct {
+	Name            string              `json:"name"`
+	IsDefault       bool                `json:"isDefault"`
+	URL             string              `json:"url,omitempty"`
+	Parameters      []ProviderParameter `json:"parameters,omitempty"`
+	APIModelSupport ModelSupport        `json:"apiModelSupport"`
+}
+
+// RerankingProvider describes a reranking provider.
+type RerankingProvider struct {
+	IsDefault               bool                              `json:"isDefault"`
+	DisplayName             string                            `json:"displayName"`
+	SupportedAuthentication map[string]ProviderAuthentication `json:"supportedAuthentication"`
+	Models                  []RerankingModel                  `json:"models"`
+}
+
+// EmbeddingProviders maps provider names to their descriptions.
+type EmbeddingProviders map[string]EmbeddingProvider
+
+// RerankingProviders maps provider names to their descriptions.
+type RerankingProviders map[string]RerankingProvider
+
+// FindEmbeddingProviders returns the embedding providers available for
+// vectorize in the database.
+//
+// Example:
+//
+//	providers, err := db.Admin().FindEmbeddingProviders(ctx)
+//	for _, m := range providers["openai"].Models {
+//		fmt.Println(m.Name)
+//	}
+func (a *DatabaseAdmin) FindEmbeddingProviders(ctx context.Context) (EmbeddingProviders, error) {
+	var resp struct {
+		Status struct {
+			EmbeddingProviders EmbeddingProviders `json:"embeddingProviders"`
+		} `json:"status"`
+	}
+	if err := a.findProviders(ctx, "findEmbeddingProviders", options.FeatureVectorize, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Status.EmbeddingProviders, nil
+}
+
+// FindRerankingProviders returns the reranking providers available in
+// the database.
+func (a *DatabaseAdmin) FindRerankingProviders(ctx context.Context) (RerankingProviders, error) {
+	var resp struct {
+		Status struct {
+			RerankingProviders RerankingProviders `json:"rerankingProviders"`
+		} `json:"status"`
+	}
+	if err := a.findProviders(ctx, "findRerankingProviders", options.FeatureReranking, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Status.RerankingProviders, nil
+}
+
+// findProviders runs a database-level find*Providers command and decodes
+// the response into out. It fails with [ErrUnsupportedEnvironment] outside
+// environments that support feature.
+func (a *DatabaseAdmin) findProviders(ctx context.Context, name string, feature options.Feature, out any) error {
+	if err := requireFeature(a.resolveOptions(), feature); err != nil {
+		return err
+	}
+	cmd := a.dataAPICmd(name, struct{}{})
+	body, _, err := cmd.Execute(ctx)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, out)
+}
+
+// validateProviders runs validate against the embedding providers of d,
+// for CreateCollection and CreateTable with provider validation on.
+func (d *Db) validateProviders(ctx context.Context, validate func(EmbeddingProviders) error) error {
+	providers, err := d.Admin().FindEmbeddingProviders(ctx)
+	if err != nil {
+		return fmt.Errorf("finding embedding providers: %w", err)
+	}
+	return validate(providers)
+}
+
+// ValidateVectorOptions checks collection vector options that use a
+// vectorize service against the provider metadata, so that mistakes in
+// provider, model, dimension, authentication or parameters are reported
+// before the collection is created. Options without a service are valid.
+//
+// [Db.CreateCollection] runs it when given
+// [options.WithCollectionProviderValidation]; call it directly to reuse one
+// set of providers for several collections:
+//
+//	providers, _ := db.Admin().FindEmbeddingProviders(ctx)
+//	if err := providers.ValidateVectorOptions(collOpts.Vector); err != nil {
+//		return err
+//	}
+//	coll, err := db.CreateCollection(ctx, "docs", collOpts)
+func (p EmbeddingProviders) ValidateVectorOptions(v *options.VectorOptions) error {
+	if v == nil || v.Service == nil {
+		return nil
+	}
+	return p.validateService(v.Service.Provider, v.Service.ModelName, v.Dimension, v.Service.Authentication, v.Service.Parameters)
+}
+
+// ValidateTableDefinition checks every vector column of def that uses a
+// vectorize service against the provider metadata. [Db.CreateTable] runs
+// it when given [options.WithProviderValidation].
+func (p EmbeddingProviders) ValidateTableDefinition(def table.Definition) error {
+	var errs []error
+	for _, name := range slices.Sorted(maps.Keys(def.Columns)) {
+		col := def.Columns[name]
+		if col.Service == nil {
+			continue
+		}
+		dimension := 0
+		if col.Dimension != nil {
+			dimension = *col.Dimension
+		}
+		params := make(map[string]any, len(col.Service.Parameters))
+		for k, v := range col.Service.Parameters {
+			params[k] = v
+		}
+		if err := p.validateService(col.Service.Provider, col.Service.ModelName, dimension, col.Service.Authentication, params); err != nil {
+			errs = append(errs, fmt.Errorf("column %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateService reports every problem found with a service configuration.
+// A dimension of 0 means the dimension is left to the model default.
+func (p EmbeddingProviders) validateService(providerName, modelName string, dimension int, auth map[string]string, params map[string]any) error {
+	provider, ok := p[providerName]
+	if !ok {
+		return fmt.Errorf("unknown embedding provider %q (available: %s)",
+			providerName, strings.Join(slices.Sorted(maps.Keys(p)), ", "))
+	}
+
+	var errs []error
+	var modelParams []ProviderParameter
+	if model := provider.Model(modelName); model != nil {
+		modelParams = model.Parameters
+		if model.APIModelSupport.Status == ModelEndOfLife {
+			errs = append(errs, fmt.Errorf("model %q of provider %q is end of life", modelName, providerName))
+		}
+		errs = append(errs, validateDimension(model, dimension))
+	} else if len(provider.Models) > 0 {
+		names := make([]string, len(provider.Models))
+		for i, m := range provider.Models {
+			names[i] = m.Name
+		}
+		errs = append(errs, fmt.Errorf("unknown model %q for provider %q (available: %s)",
+			modelName, providerName, strings.Join(names, ", ")))
+	}
+
+	errs = append(errs, validateAuthentication(providerName, provider.SupportedAuthentication, auth))
+	errs = append(errs, validateParameters(providerName, append(slices.Clone(provider.Parameters), modelParams...), params)...)
+	return errors.Join(errs...)
+}
+
+// validateDimension checks dimension against a model's fixed dimension or
+// its vectorDimension parameter.
+func validateDimension(model *EmbeddingModel, dimension int) error {
+	if dimension == 0 {
+		return nil
+	}
+	if model.VectorDimension != nil {
+		if *model.VectorDimension != dimension {
+			return fmt.Errorf("model %q has dimension %d, got %d", model.Name, *model.VectorDimension, dimension)
+		}
+		return nil
+	}
+	for _, param := range model.Parameters {
+		if param.Name == vectorDimensionParam {
+			return validateNumber(model.Name+" dimension", param.Validation, float64(dimension))
+		}
+	}
+	return nil
+}
+
+// validateAuthentication checks that the configured credentials use an
+// authentication method the provider has enabled.
+func validateAuthentication(providerName string, supported map[string]ProviderAuthentication, auth map[string]string) error {
+	method := AuthSharedSecret
+	if len(auth) == 0 {
+		// Without stored credentials the key is either not needed or sent
+		// as a header with each request
+		if supported[AuthNone].Enabled || supported[AuthHeader].Enabled {
+			return nil
+		}
+		method = AuthNone
+	} else if supported[AuthSharedSecret].Enabled {
+		if _, ok := auth["providerKey"]; !ok {
+			return fmt.Errorf("provider %q requires a \"providerKey\" authentication entry", providerName)
+		}
+		return nil
+	}
+	var enabled []string
+	for _, m := range slices.Sorted(maps.Keys(supported)) {
+		if supported[m].Enabled {
+			enabled = append(enabled, m)
+		}
+	}
+	return fmt.Errorf("provider %q does not support %s authentication (supported: %s)",
+		providerName, method, strings.Join(enabled, ", "))
+}
+
+// validateParameters checks that required parameters are present, that
+// no unknown parameters are set, and that numeric values are in range.
+func validateParameters(providerName string, defs []ProviderParameter, params map[string]any) []error {
+	var errs []error
+	known := make(map[string]ProviderParameter, len(defs))
+	for _, def := range defs {
+		if def.Name == vectorDimensionParam {
+			continue
+		}
+		known[def.Name] = def
+		if _, ok := params[def.Name]; !ok && def.Required && def.DefaultValue == "" {
+			errs = append(errs, fmt.Errorf("provider %q requires parameter %q", providerName, def.Name))
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(params)) {
+		def, ok := known[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("unknown parameter %q for provider %q", name, providerName))
+			continue
+		}
+		if n, ok := toFloat(params[name]); ok {
+			if err := validateNumber("parameter "+name, def.Validation, n); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errs
+}
+
+// validateNumber checks n against a numeric range or list of options.
+func validateNumber(what string, v ParameterValidation, n float64) error {
+	if len(v.NumericRange) == 2 && (n < v.NumericRange[0] || n > v.NumericRange[1]) {
+		return fmt.Errorf("%s must be between %g and %g, got %g", what, v.NumericRange[0], v.NumericRange[1], n)
+	}
+	if len(v.Options) > 0 && !slices.Contains(v.Options, n) {
+		return fmt.Errorf("%s must be one of %v, got %g", what, v.Options, n)
+	}
+	return nil
+}
+
+// toFloat converts numeric parameter values, including numeric strings
+// from table definitions, to float64.
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
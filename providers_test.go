@@ -0,0 +1,225 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package astradb
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/datastax/astra-db-go/options"
+	"github.com/datastax/astra-db-go/table"
+)
+
+// Trimmed findEmbeddingProviders response
+const embeddingProvidersResponse = `{"status":{"embeddingProviders":{
+	"openai":{
+		"displayName":"OpenAI",
+		"url":"https://api.openai.com/v1/",
+		"supportedAuthentication":{
+			"HEADER":{"enabled":true,"tokens":[{"accepted":"x-embedding-api-key","forwarded":"Authorization"}]},
+			"SHARED_SECRET":{"enabled":true,"tokens":[{"accepted":"providerKey","forwarded":"Authorization"}]},
+			"NONE":{"enabled":false,"tokens":[]}
+		},
+		"parameters":[{"name":"organizationId","type":"STRING","required":false,"defaultValue":"","validation":{},"help":"Organization ID"}],
+		"models":[
+			{"name":"text-embedding-3-small","vectorDimension":null,"parameters":[
+				{"name":"vectorDimension","type":"number","required":true,"defaultValue":"1536","validation":{"numericRange":[2,1536]}}
+			],"apiModelSupport":{"status":"SUPPORTED"}},
+			{"name":"text-embedding-ada-002","vectorDimension":1536,"parameters":[],"apiModelSupport":{"status":"END_OF_LIFE"}}
+		]
+	},
+	"nvidia":{
+		"displayName":"Nvidia",
+		"supportedAuthentication":{"NONE":{"enabled":true,"tokens":[]}},
+		"parameters":[],
+		"models":[{"name":"NV-Embed-QA","vectorDimension":1024,"parameters":[],"apiModelSupport":{"status":"SUPPORTED"}}]
+	}
+}}}`
+
+const rerankingProvidersResponse = `{"status":{"rerankingProviders":{
+	"nvidia":{"isDefault":true,"displayName":"Nvidia","supportedAuthentication":{"NONE":{"enabled":true,"tokens":[]}},
+		"models":[{"name":"nvidia/llama-3.2-nv-rerankqa-1b-v2","isDefault":true,"url":"https://example.com/ranking","parameters":null,"apiModelSupport":{"status":"SUPPORTED"}}]}
+}}}`
+
+func newProvidersDb(t *testing.T) *Db {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(body), "findRerankingProviders") {
+			w.Write([]byte(rerankingProvidersResponse))
+			return
+		}
+		w.Write([]byte(embeddingProvidersResponse))
+	}))
+	t.Cleanup(srv.Close)
+	return NewClient(options.WithToken("test-token")).Database(srv.URL)
+}
+
+func TestFindProviders(t *testing.T) {
+	db := newProvidersDb(t)
+	ctx := context.Background()
+
+	embedding, err := db.Admin().FindEmbeddingProviders(ctx)
+	if err != nil {
+		t.Fatalf("FindEmbeddingProviders failed: %v", err)
+	}
+	openai, ok := embedding["openai"]
+	if !ok {
+		t.Fatal("expected openai provider")
+	}
+	if !openai.SupportedAuthentication[AuthSharedSecret].Enabled {
+		t.Error("expected openai to support shared secrets")
+	}
+	model := openai.Model("text-embedding-3-small")
+	if model == nil || model.VectorDimension != nil || model.Parameters[0].Validation.NumericRange[1] != 1536 {
+		t.Errorf("unexpected model %+v", model)
+	}
+
+	reranking, err := db.Admin().FindRerankingProviders(ctx)
+	if err != nil {
+		t.Fatalf("FindRerankingProviders failed: %v", err)
+	}
+	if !reranking["nvidia"].IsDefault || reranking["nvidia"].Models[0].Name != "nvidia/llama-3.2-nv-rerankqa-1b-v2" {
+		t.Errorf("unexpected reranking providers %+v", reranking)
+	}
+}
+
+func TestValidateVectorOptions(t *testing.T) {
+	providers, err := newProvidersDb(t).Admin().FindEmbeddingProviders(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		vector  *options.VectorOptions
+		wantErr string
+	}{
+		{"no service", &options.VectorOptions{Dimension: 3}, ""},
+		{"valid header auth", &options.VectorOptions{Dimension: 512, Service: &options.VectorServiceOptions{
+			Provider: "openai", ModelName: "text-embedding-3-small",
+		}}, ""},
+		{"valid shared secret", &options.VectorOptions{Service: &options.VectorServiceOptions{
+			Provider: "openai", ModelName: "text-embedding-3-small",
+			Authentication: map[string]string{"providerKey": "my_key"},
+			Parameters:     map[string]any{"organizationId": "org"},
+		}}, ""},
+		{"unknown provider", &options.VectorOptions{Service: &options.VectorServiceOptions{Provider: "acme"}}, `unknown embedding provider "acme"`},
+		{"unknown model", &options.VectorOptions{Service: &options.VectorServiceOptions{
+			Provider: "nvidia", ModelName: "nope",
+		}}, `unknown model "nope"`},
+		{"dimension out of range", &options.VectorOptions{Dimension: 4096, Service: &options.VectorServiceOptions{
+			Provider: "openai", ModelName: "text-embedding-3-small",
+		}}, "must be between 2 and 1536"},
+		{"fixed dimension mismatch", &options.VectorOptions{Dimension: 768, Service: &options.VectorServiceOptions{
+			Provider: "nvidia", ModelName: "NV-Embed-QA",
+		}}, "has dimension 1024"},
+		{"end of life", &options.VectorOptions{Service: &options.VectorServiceOptions{
+			Provider: "openai", ModelName: "text-embedding-ada-002",
+		}}, "end of life"},
+		{"unsupported auth", &options.VectorOptions{Service: &options.VectorServiceOptions{
+			Provider: "nvidia", ModelName: "NV-Embed-QA",
+			Authentication: map[string]string{"providerKey": "my_key"},
+		}}, "does not support SHARED_SECRET"},
+		{"unknown parameter", &options.VectorOptions{Service: &options.VectorServiceOptions{
+			Provider: "openai", ModelName: "text-embedding-3-small",
+			Parameters: map[string]any{"temperature": 1},
+		}}, `unknown parameter "temperature"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := providers.ValidateVectorOptions(tt.vector)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateTableDefinition(t *testing.T) {
+	providers, err := newProvidersDb(t).Admin().FindEmbeddingProviders(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	def := table.NewDefinition().
+		AddTextColumn("id").
+		AddVectorColumnWithService("ok", 1024, &table.VectorService{Provider: "nvidia", ModelName: "NV-Embed-QA"}).
+		AddVectorColumnWithService("bad", 2048, &table.VectorService{Provider: "openai", ModelName: "text-embedding-3-small"}).
+		SetPartitionBy("id").
+		Build()
+	err = providers.ValidateTableDefinition(def)
+	if err == nil || !strings.Contains(err.Error(), "column bad") || strings.Contains(err.Error(), "column ok") {
+		t.Errorf("expected error for column bad only, got %v", err)
+	}
+}
+
+func TestCreateWithProviderValidation(t *testing.T) {
+	var commands []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		name, _, _ := strings.Cut(strings.TrimPrefix(string(body), `{"`), `"`)
+		commands = append(commands, name)
+		if name == "findEmbeddingProviders" {
+			w.Write([]byte(embeddingProvidersResponse))
+			return
+		}
+		w.Write([]byte(`{"status":{"ok":1}}`))
+	}))
+	defer srv.Close()
+	db := NewClient(options.WithToken("test-token")).Database(srv.URL)
+	ctx := context.Background()
+
+	bad := &options.CollectionOptions{Vector: &options.VectorOptions{Dimension: 4096, Service: &options.VectorServiceOptions{
+		Provider: "openai", ModelName: "text-embedding-3-small",
+	}}}
+	if _, err := db.CreateCollection(ctx, "docs", bad, options.WithCollectionProviderValidation(true)); err == nil || !strings.Contains(err.Error(), "must be between 2 and 1536") {
+		t.Errorf("expected dimension error, got %v", err)
+	}
+	badDef := table.NewDefinition().
+		AddTextColumn("id").
+		AddVectorColumnWithService("v", 2048, &table.VectorService{Provider: "openai", ModelName: "text-embedding-3-small"}).
+		SetPartitionBy("id").
+		Build()
+	if _, err := db.CreateTable(ctx, "docs", badDef, options.WithProviderValidation(true)); err == nil || !strings.Contains(err.Error(), "column v") {
+		t.Errorf("expected column v error, got %v", err)
+	}
+	if !slices.Equal(commands, []string{"findEmbeddingProviders", "findEmbeddingProviders"}) {
+		t.Errorf("expected only provider lookups to be sent, got %v", commands)
+	}
+
+	// Without the option, nothing is checked
+	commands = nil
+	if _, err := db.CreateCollection(ctx, "docs", bad); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.CreateTable(ctx, "docs", badDef); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(commands, []string{"createCollection", "createTable"}) {
+		t.Errorf("expected no provider lookups, got %v", commands)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package astradb
+
+import (
+	"sync/atomic"
+
+	"github.com/datastax/astra-db-go/options"
+)
+
+// Merging the options of every layer for each command is costly, so
+// databases, collections and tables cache their merged options. A database
+// replaces its options with a modified copy when they change (see
+// [Db.UseKeyspace]) and then bumps its generation, and caches built from an
+// older generation are rebuilt on next use.
+//
+// Cached options are shared by all commands of a handle and must not be
+// modified; [command.Execute] hands interceptors a clone.
+
+// resolvedOptions is a merged set of options and the database generation
+// it was merged from.
+type resolvedOptions struct {
+	opts *options.APIOptions
+	gen  uint64
+}
+
+// optionsCache caches the options of a collection or table merged with
+// those of its database and client.
+type optionsCache struct {
+	resolved atomic.Pointer[resolvedOptions]
+}
+
+// resolve returns own merged with the options of d, from the cache if d's
+// options haven't changed since it was filled.
+func (c *optionsCache) resolve(d *Db, own *options.APIOptions) *options.APIOptions {
+	parent, gen := d.resolveOptions()
+	if r := c.resolved.Load(); r != nil && r.gen == gen {
+		return r.opts
+	}
+	opts := options.Merge(parent, own)
+	c.resolved.Store(&resolvedOptions{opts: opts, gen: gen})
+	return opts
+}
+
+// resolveOptions returns the client and database options merged, and the
+// generation of the database options.
+func (d *Db) resolveOptions() (*options.APIOptions, uint64) {
+	if d == nil {
+		return options.Merge(), 0
+	}
+	gen := d.gen.Load()
+	if r := d.resolved.Load(); r != nil && r.gen == gen {
+		return r.opts, gen
+	}
+	var clientOpts *options.APIOptions
+	if d.client != nil {
+		clientOpts = d.client.options
+	}
+	opts := options.Merge(clientOpts, d.options.Load())
+	d.resolved.Store(&resolvedOptions{opts: opts, gen: gen})
+	return opts, gen
+}
@@ -0,0 +1,47 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package results
+
+// CommandResult is returned by commands whose response carries only a
+// status, such as dropTable or createIndex.
+type CommandResult struct {
+	rawResp  []byte
+	warnings Warnings
+}
+
+// NewCommandResult creates a new CommandResult with the given response and warnings.
+func NewCommandResult(rawResp []byte, warnings Warnings) *CommandResult {
+	return &CommandResult{
+		rawResp:  rawResp,
+		warnings: warnings,
+	}
+}
+
+// Warnings returns any warnings from the API response.
+// Returns nil if there were no warnings.
+func (cr *CommandResult) Warnings() Warnings {
+	if cr == nil {
+		return nil
+	}
+	return cr.warnings
+}
+
+// Raw returns the raw JSON response body.
+func (cr *CommandResult) Raw() []byte {
+	if cr == nil {
+		return nil
+	}
+	return cr.rawResp
+}
@@ -4,5 +4,21 @@ import "errors"
 
 var ErrNoDocuments error = errors.New("no documents found")
 
+// ErrNotFound is returned by [SingleResult] when a findOne-style command
+// matched no document. It also matches [ErrNoDocuments] with [errors.Is].
+var ErrNotFound error = notFoundError{}
+
+// notFoundError is the type of [ErrNotFound].
+type notFoundError struct{}
+
+func (notFoundError) Error() string {
+	return "not found"
+}
+
+// Is lets ErrNotFound match ErrNoDocuments.
+func (notFoundError) Is(target error) bool {
+	return target == ErrNoDocuments
+}
+
 // ErrTooManyDocumentsToCount is returned when a Count command exceeds the upper bounds.
 var ErrTooManyDocumentsToCount error = errors.New("too many documents")
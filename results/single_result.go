@@ -16,22 +16,43 @@ package results
 
 import (
 	"encoding/json"
+
+	"github.com/datastax/astra-db-go/codec"
 )
 
 // SingleResult represents a document returned from an operation.
+//
+// Use [SingleResult.Err] to tell a missing document ([ErrNotFound]) apart
+// from a failed command without decoding.
 type SingleResult struct {
 	err      error
 	rawResp  []byte
+	document json.RawMessage
 	warnings Warnings
+	codec    codec.Codec
 }
 
 // NewSingleResult creates a new SingleResult with the given response, warnings, and error.
 func NewSingleResult(rawResp []byte, warnings Warnings, err error) *SingleResult {
-	return &SingleResult{
+	return NewSingleResultWithCodec(rawResp, warnings, err, nil)
+}
+
+// NewSingleResultWithCodec is like [NewSingleResult], but decodes the
+// document with c. A nil c uses [codec.JSON].
+func NewSingleResultWithCodec(rawResp []byte, warnings Warnings, err error, c codec.Codec) *SingleResult {
+	if c == nil {
+		c = codec.JSON{}
+	}
+	sr := &SingleResult{
 		rawResp:  rawResp,
 		warnings: warnings,
 		err:      err,
+		codec:    c,
+	}
+	if err == nil {
+		sr.document, sr.err = extractDocument(rawResp)
 	}
+	return sr
 }
 
 // Warnings returns any warnings from the API response.
@@ -49,25 +70,46 @@ type singleResultJSON struct {
 	} `json:"data"`
 }
 
-// Decode will unmarshal the document represented by this [SingleResult] into `v`.
-// If no documents are found, returns [ErrNoDocuments].
-func (sr *SingleResult) Decode(v any) error {
-	if sr.err != nil {
-		return sr.err
+// extractDocument returns data.document from rawResp, or [ErrNotFound]
+// if the response holds no document.
+func extractDocument(rawResp []byte) (json.RawMessage, error) {
+	if len(rawResp) == 0 {
+		return nil, ErrNotFound
 	}
-	if len(sr.rawResp) == 0 {
-		return ErrNoDocuments
-	}
-	// First unmarshal to get rawmessage in data.document
 	var singleResult singleResultJSON
-	err := json.Unmarshal(sr.rawResp, &singleResult)
-	if err != nil {
-		return err
+	if err := json.Unmarshal(rawResp, &singleResult); err != nil {
+		return nil, err
+	}
+	// If document is null or missing, that means we found no document
+	doc := singleResult.Data.Document
+	if len(doc) == 0 || string(doc) == "null" {
+		return nil, ErrNotFound
 	}
-	// If document is null, that means we found no document
-	if string(singleResult.Data.Document) == "null" {
-		return ErrNoDocuments
+	return doc, nil
+}
+
+// Err returns the error associated with this result: the command error if
+// the command failed, [ErrNotFound] if it succeeded but matched no document,
+// or nil if a document was found.
+func (sr *SingleResult) Err() error {
+	return sr.err
+}
+
+// Raw returns the raw JSON of the document, or nil if [SingleResult.Err]
+// is non-nil.
+func (sr *SingleResult) Raw() json.RawMessage {
+	if sr.err != nil {
+		return nil
+	}
+	return sr.document
+}
+
+// Decode will unmarshal the document represented by this [SingleResult] into `v`,
+// with the codec of the options it was found with.
+// If no document was found, returns [ErrNotFound].
+func (sr *SingleResult) Decode(v any) error {
+	if sr.err != nil {
+		return sr.err
 	}
-	// Then return/unmarshal the document
-	return json.Unmarshal(singleResult.Data.Document, v)
+	return sr.codec.Decode(sr.document, v)
 }
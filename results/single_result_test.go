@@ -0,0 +1,81 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package results_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/datastax/astra-db-go/results"
+)
+
+func TestSingleResult_Found(t *testing.T) {
+	sr := results.NewSingleResult([]byte(`{"data":{"document":{"name":"a"}}}`), nil, nil)
+	if sr.Err() != nil {
+		t.Fatalf("expected no error, got %v", sr.Err())
+	}
+	if string(sr.Raw()) != `{"name":"a"}` {
+		t.Errorf("unexpected raw document: %s", sr.Raw())
+	}
+	var doc struct {
+		Name string `json:"name"`
+	}
+	if err := sr.Decode(&doc); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if doc.Name != "a" {
+		t.Errorf("expected name a, got %q", doc.Name)
+	}
+}
+
+func TestSingleResult_NotFound(t *testing.T) {
+	responses := []string{
+		`{"data":{"document":null}}`,
+		`{"data":{}}`,
+		``,
+	}
+	for _, resp := range responses {
+		sr := results.NewSingleResult([]byte(resp), nil, nil)
+		if !errors.Is(sr.Err(), results.ErrNotFound) {
+			t.Errorf("%q: expected ErrNotFound from Err, got %v", resp, sr.Err())
+		}
+		var v map[string]any
+		err := sr.Decode(&v)
+		if !errors.Is(err, results.ErrNotFound) {
+			t.Errorf("%q: expected ErrNotFound from Decode, got %v", resp, err)
+		}
+		if !errors.Is(err, results.ErrNoDocuments) {
+			t.Errorf("%q: expected ErrNotFound to match ErrNoDocuments", resp)
+		}
+		if sr.Raw() != nil {
+			t.Errorf("%q: expected nil Raw, got %s", resp, sr.Raw())
+		}
+	}
+}
+
+func TestSingleResult_CommandError(t *testing.T) {
+	cmdErr := errors.New("connection refused")
+	sr := results.NewSingleResult(nil, nil, cmdErr)
+	if sr.Err() != cmdErr {
+		t.Errorf("expected command error, got %v", sr.Err())
+	}
+	if errors.Is(sr.Err(), results.ErrNotFound) {
+		t.Error("did not expect command error to match ErrNotFound")
+	}
+	var v map[string]any
+	if err := sr.Decode(&v); err != cmdErr {
+		t.Errorf("expected command error from Decode, got %v", err)
+	}
+}
@@ -65,3 +65,33 @@ func (w *Warning) String() string {
 
 // Warnings is a slice of warnings returned from API responses.
 type Warnings []Warning
+
+// Has reports whether any warning has the given error code.
+func (ws Warnings) Has(code string) bool {
+	for _, w := range ws {
+		if w.ErrorCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// Well-known warning codes returned in the errorCode field of a [Warning].
+const (
+	// WarningZeroFilterOperations means the query had no filter and will
+	// scan the whole collection or table.
+	WarningZeroFilterOperations = "ZERO_FILTER_OPERATIONS"
+	// WarningMissingIndex means the filter used a column with no index.
+	WarningMissingIndex = "MISSING_INDEX"
+	// WarningInMemorySorting means the sort did not use the partition
+	// sorting columns and was performed in memory.
+	WarningInMemorySorting = "IN_MEMORY_SORTING_DUE_TO_NON_PARTITION_SORTING"
+	// WarningIncompletePrimaryKeyFilter means the filter did not include
+	// the full primary key.
+	WarningIncompletePrimaryKeyFilter = "INCOMPLETE_PRIMARY_KEY_FILTER"
+	// WarningNotEqualsUnsupportedByIndexing means a $ne filter could not
+	// use an index.
+	WarningNotEqualsUnsupportedByIndexing = "NOT_EQUALS_UNSUPPORTED_BY_INDEXING"
+	// WarningDeprecatedCommand means the command is deprecated.
+	WarningDeprecatedCommand = "DEPRECATED_COMMAND"
+)
@@ -0,0 +1,169 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sort defines ordered sort clauses for Astra DB queries.
+//
+// Go maps do not preserve insertion order, so a multi-field sort expressed
+// as a map[string]any can reach the Data API with its fields shuffled. The
+// [Sort] builder keeps fields in the order they were added.
+//
+// Example:
+//
+//	s := sort.Asc("rating").Desc("title")
+//	// Marshals to: {"rating":1,"title":-1}
+package sort
+
+import "github.com/datastax/astra-db-go/internal/ordered"
+
+// Sort order values understood by the Data API.
+const (
+	Ascending  = 1
+	Descending = -1
+)
+
+// Reserved field names used by collection sorts.
+const (
+	// FieldVector is the reserved field for vector search on collections.
+	FieldVector = "$vector"
+	// FieldVectorize is the reserved field for vectorize search on collections.
+	FieldVectorize = "$vectorize"
+	// FieldLexical is the reserved field for lexical (BM25) search on collections.
+	FieldLexical = "$lexical"
+)
+
+// field is a single sort clause entry.
+type field = ordered.Field
+
+// Sort is an ordered sort clause. Build one with [Asc], [Desc], [Vector],
+// [Vectorize] or [Lexical] and chain further fields with the methods of
+// the same name. The zero value is an empty sort.
+type Sort struct {
+	fields []field
+}
+
+// New returns an empty Sort.
+func New() *Sort {
+	return &Sort{}
+}
+
+// Asc returns a Sort ordering by name ascending.
+func Asc(name string) *Sort {
+	return New().Asc(name)
+}
+
+// Desc returns a Sort ordering by name descending.
+func Desc(name string) *Sort {
+	return New().Desc(name)
+}
+
+// Vector returns a Sort performing a vector search on name.
+//
+// For collections use [FieldVector] as the name. For tables use the name
+// of the vector column.
+func Vector(name string, vector []float32) *Sort {
+	return New().Vector(name, vector)
+}
+
+// Vectorize returns a Sort performing a vectorize search on name using
+// the embedding provider configured on the collection or column.
+//
+// For collections use [FieldVectorize] as the name. For tables use the
+// name of the vector column.
+func Vectorize(name string, text string) *Sort {
+	return New().Vectorize(name, text)
+}
+
+// Lexical returns a Sort performing a lexical search on a collection.
+func Lexical(text string) *Sort {
+	return New().Lexical(text)
+}
+
+// Asc appends an ascending sort on name.
+func (s *Sort) Asc(name string) *Sort {
+	return s.add(name, Ascending)
+}
+
+// Desc appends a descending sort on name.
+func (s *Sort) Desc(name string) *Sort {
+	return s.add(name, Descending)
+}
+
+// Vector appends a vector search on name.
+func (s *Sort) Vector(name string, vector []float32) *Sort {
+	return s.add(name, vector)
+}
+
+// Vectorize appends a vectorize search on name.
+func (s *Sort) Vectorize(name string, text string) *Sort {
+	return s.add(name, text)
+}
+
+// Lexical appends a lexical search on [FieldLexical].
+func (s *Sort) Lexical(text string) *Sort {
+	return s.add(FieldLexical, text)
+}
+
+// add appends name with value. Adding a name twice replaces the value
+// but keeps the original position.
+func (s *Sort) add(name string, value any) *Sort {
+	for i := range s.fields {
+		if s.fields[i].Name == name {
+			s.fields[i].Value = value
+			return s
+		}
+	}
+	s.fields = append(s.fields, field{Name: name, Value: value})
+	return s
+}
+
+// Len returns the number of fields in the sort.
+func (s *Sort) Len() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.fields)
+}
+
+// Fields returns the field names in the order they were added.
+func (s *Sort) Fields() []string {
+	if s == nil {
+		return nil
+	}
+	names := make([]string, len(s.fields))
+	for i, f := range s.fields {
+		names[i] = f.Name
+	}
+	return names
+}
+
+// Get returns the value for name and whether it was present.
+func (s *Sort) Get(name string) (any, bool) {
+	if s == nil {
+		return nil, false
+	}
+	for _, f := range s.fields {
+		if f.Name == name {
+			return f.Value, true
+		}
+	}
+	return nil, false
+}
+
+// MarshalJSON implements [json.Marshaler], writing fields in insertion order.
+func (s *Sort) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+	return ordered.Marshal(s.fields)
+}
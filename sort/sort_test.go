@@ -0,0 +1,118 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sort_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/datastax/astra-db-go/sort"
+)
+
+func TestSortMarshalPreservesOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		sort     *sort.Sort
+		expected string
+	}{
+		{
+			name:     "asc then desc",
+			sort:     sort.Asc("rating").Desc("title"),
+			expected: `{"rating":1,"title":-1}`,
+		},
+		{
+			name:     "desc then asc",
+			sort:     sort.Desc("title").Asc("rating"),
+			expected: `{"title":-1,"rating":1}`,
+		},
+		{
+			name:     "many fields",
+			sort:     sort.Asc("z").Asc("y").Desc("x").Asc("a"),
+			expected: `{"z":1,"y":1,"x":-1,"a":1}`,
+		},
+		{
+			name:     "vector",
+			sort:     sort.Vector(sort.FieldVector, []float32{0.1, 0.2}),
+			expected: `{"$vector":[0.1,0.2]}`,
+		},
+		{
+			name:     "vectorize",
+			sort:     sort.Vectorize("embedding", "hello"),
+			expected: `{"embedding":"hello"}`,
+		},
+		{
+			name:     "lexical",
+			sort:     sort.Lexical("tree"),
+			expected: `{"$lexical":"tree"}`,
+		},
+		{
+			name:     "duplicate field keeps position",
+			sort:     sort.Asc("a").Asc("b").Desc("a"),
+			expected: `{"a":-1,"b":1}`,
+		},
+		{
+			name:     "empty",
+			sort:     sort.New(),
+			expected: `{}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.sort)
+			if err != nil {
+				t.Fatalf("json.Marshal: %v", err)
+			}
+			if string(b) != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, string(b))
+			}
+		})
+	}
+}
+
+func TestSortInStruct(t *testing.T) {
+	payload := struct {
+		Sort *sort.Sort `json:"sort,omitempty"`
+	}{}
+	b, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	if string(b) != `{}` {
+		t.Errorf("expected nil sort to be omitted, got %s", string(b))
+	}
+}
+
+func TestSortAccessors(t *testing.T) {
+	s := sort.Desc("created").Asc("title")
+	if s.Len() != 2 {
+		t.Errorf("expected 2 fields, got %d", s.Len())
+	}
+	fields := s.Fields()
+	if fields[0] != "created" || fields[1] != "title" {
+		t.Errorf("unexpected field order: %v", fields)
+	}
+	v, ok := s.Get("created")
+	if !ok || v != sort.Descending {
+		t.Errorf("expected created to be descending, got %v", v)
+	}
+	if _, ok := s.Get("missing"); ok {
+		t.Error("expected missing field to be absent")
+	}
+	var nilSort *sort.Sort
+	if nilSort.Len() != 0 {
+		t.Error("expected nil sort to have zero length")
+	}
+}
@@ -31,9 +31,11 @@ import (
 // Options set on the table are inherited by all commands
 // executed on it, unless overridden at the command level.
 type Table struct {
-	db      *Db
-	name    string
-	options *options.APIOptions
+	db       *Db
+	name     string
+	options  *options.APIOptions
+	warnings results.Warnings
+	cache    optionsCache // Merged client, database and table options
 }
 
 // Name returns the table name.
@@ -41,7 +43,9 @@ func (t *Table) Name() string {
 	return t.name
 }
 
-// Options returns the table's options (or empty options if nil).
+// Options returns the table's options (or empty options if nil). They
+// must not be modified: they are merged with the database's options and
+// cached when the first command runs.
 func (t *Table) Options() *options.APIOptions {
 	if t.options == nil {
 		return &options.APIOptions{}
@@ -54,9 +58,17 @@ func (t *Table) Database() *Db {
 	return t.db
 }
 
+// Warnings returns any warnings from the createTable command if this
+// handle was returned by [Db.CreateTable]. Returns nil otherwise.
+func (t *Table) Warnings() results.Warnings {
+	return t.warnings
+}
+
 // newCmd creates a command for this table
 func (t *Table) newCmd(name string, payload any, opts ...options.APIOption) command {
-	return newCmdWithOptions(t.db, t.name, name, payload, t.options, opts...)
+	cmd := newCmdWithOptions(t.db, t.name, name, payload, t.options, opts...)
+	cmd.cache = &t.cache
+	return cmd
 }
 
 // createTablePayload is the payload for the createTable command
@@ -116,6 +128,12 @@ func (d *Db) Table(name string, opts ...options.APIOption) *Table {
 //		},
 //	}
 //	tbl, err := db.CreateTable(ctx, "my_table", definition)
+//
+// With [options.WithProviderValidation], vectorize services are checked
+// with [EmbeddingProviders.ValidateTableDefinition] first.
+//
+// Any warnings from the command are available from the returned table's
+// [Table.Warnings] method.
 func (d *Db) CreateTable(ctx context.Context, name string, definition table.Definition, opts ...options.TableOption) (*Table, error) {
 	// Apply options
 	tableOpts := options.NewCreateTableOptions(opts...)
@@ -139,17 +157,30 @@ func (d *Db) CreateTable(ctx context.Context, name string, definition table.Defi
 		cmd.keyspace = tableOpts.Keyspace
 	}
 
+	if definition.UsesVectorize() {
+		if err := requireFeature(cmd.resolveOptions(), options.FeatureVectorize); err != nil {
+			return nil, err
+		}
+		if tableOpts.ValidateProviders {
+			if err := d.validateProviders(ctx, func(p EmbeddingProviders) error {
+				return p.ValidateTableDefinition(definition)
+			}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// Execute the command
 	// Response is in format: {"status":{"ok":1}}
-	// Note: Warnings are accessible via the WarningHandler option callback only.
-	_, _, err := cmd.Execute(ctx)
+	_, warnings, err := cmd.Execute(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	return &Table{
-		db:   d,
-		name: name,
+		db:       d,
+		name:     name,
+		warnings: warnings,
 	}, nil
 }
 
@@ -162,13 +193,52 @@ type dropTablePayload struct {
 //
 // Example usage:
 //
-//	err := db.DropTable(ctx, "my_table")
-//
-// Note: Warnings are accessible via the WarningHandler option callback only.
-func (d *Db) DropTable(ctx context.Context, name string) error {
+//	_, err := db.DropTable(ctx, "my_table")
+func (d *Db) DropTable(ctx context.Context, name string) (*results.CommandResult, error) {
 	cmd := d.newCmd("dropTable", dropTablePayload{Name: name})
-	_, _, err := cmd.Execute(ctx)
-	return err
+	b, warnings, err := cmd.Execute(ctx)
+	return results.NewCommandResult(b, warnings), err
+}
+
+// TableDescriptor describes a table as returned by [Db.ListTables].
+type TableDescriptor struct {
+	Name       string           `json:"name"`
+	Definition table.Definition `json:"definition"`
+}
+
+// listTablesPayload is the payload for the listTables command
+type listTablesPayload struct {
+	Options struct {
+		Explain bool `json:"explain"`
+	} `json:"options"`
+}
+
+// ListTables returns the tables in the database's keyspace with their
+// definitions.
+//
+// Example usage:
+//
+//	tables, err := db.ListTables(ctx)
+//	for _, t := range tables {
+//		fmt.Println(t.Name, len(t.Definition.Columns))
+//	}
+func (d *Db) ListTables(ctx context.Context) ([]TableDescriptor, error) {
+	var payload listTablesPayload
+	payload.Options.Explain = true
+	cmd := d.newCmd("listTables", payload)
+	b, _, err := cmd.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Status struct {
+			Tables []TableDescriptor `json:"tables"`
+		} `json:"status"`
+	}
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Status.Tables, nil
 }
 
 // dropIndexPayload is the payload for the dropIndex command
@@ -180,13 +250,11 @@ type dropIndexPayload struct {
 //
 // Example usage:
 //
-//	err := db.DropTableIndex(ctx, "rating_idx")
-//
-// Note: Warnings are accessible via the WarningHandler option callback only.
-func (d *Db) DropTableIndex(ctx context.Context, name string) error {
+//	_, err := db.DropTableIndex(ctx, "rating_idx")
+func (d *Db) DropTableIndex(ctx context.Context, name string) (*results.CommandResult, error) {
 	cmd := dropTableIndexCommand(d, name)
-	_, _, err := cmd.Execute(ctx)
-	return err
+	b, warnings, err := cmd.Execute(ctx)
+	return results.NewCommandResult(b, warnings), err
 }
 
 // dropTableIndexCommand builds the dropIndex command for the database
@@ -196,10 +264,10 @@ func dropTableIndexCommand(d *Db, name string) command {
 
 // tableFindPayload is the payload for the find command on tables
 type tableFindPayload struct {
-	Filter     any             `json:"filter,omitempty"`
-	Sort       map[string]any  `json:"sort,omitempty"`
-	Projection map[string]bool `json:"projection,omitempty"`
-	Options    *tableFindOpts  `json:"options,omitempty"`
+	Filter     any            `json:"filter,omitempty"`
+	Sort       any            `json:"sort,omitempty"`       // map[string]any or *sort.Sort
+	Projection any            `json:"projection,omitempty"` // map[string]bool or *projection.Projection
+	Options    *tableFindOpts `json:"options,omitempty"`
 }
 
 // tableFindOpts represents the options sub-object in find payload
@@ -210,14 +278,6 @@ type tableFindOpts struct {
 	PageState         *string `json:"pageState,omitempty"`
 }
 
-// tableFindResponse is the response from the find command on tables
-type tableFindResponse struct {
-	Data struct {
-		Documents     []json.RawMessage `json:"documents"`
-		NextPageState *string           `json:"nextPageState"`
-	} `json:"data"`
-}
-
 // Find returns a cursor for iterating over rows matching the filter criteria.
 //
 // The cursor automatically handles pagination, fetching new pages as needed.
@@ -251,10 +311,17 @@ type tableFindResponse struct {
 //	    return err
 //	}
 //
+// Example with sort and projection:
+//
+//	cursor := tbl.Find(ctx, filter.F{},
+//	    options.WithSort(sort.Desc("rating").Asc("title")),
+//	    options.WithProjection(projection.Include("title", "rating")),
+//	)
+//
 // Example with vector search:
 //
 //	cursor := tbl.Find(ctx, filter.F{},
-//	    options.WithSort(map[string]any{"vector_column": []float32{0.1, 0.2, 0.3}}),
+//	    options.WithSort(sort.Vector("vector_column", []float32{0.1, 0.2, 0.3})),
 //	    options.WithIncludeSimilarity(true),
 //	)
 func (t *Table) Find(ctx context.Context, f any, opts ...options.TableFindOption) *cursor.Cursor {
@@ -270,6 +337,8 @@ func (t *Table) Find(ctx context.Context, f any, opts ...options.TableFindOption
 	findOpts := options.NewTableFindOptions(opts...)
 
 	// Create a page fetcher that captures the table, filter, and options
+	cursorInfo := &options.CursorInfo{Context: ctx}
+	codec := t.cache.resolve(t.db, t.options).GetCodec()
 	fetcher := func(fetchCtx context.Context, pageState *string) ([]json.RawMessage, *string, results.Warnings, error) {
 		payload := tableFindPayload{
 			Filter:     f,
@@ -306,30 +375,39 @@ func (t *Table) Find(ctx context.Context, f any, opts ...options.TableFindOption
 			payload.Options = payloadOpts
 		}
 
+		cursorInfo.Page++
 		cmd := t.newCmd("find", payload)
+		cmd.cursor = cursorInfo
 		b, warnings, err := cmd.Execute(fetchCtx)
 		if err != nil {
 			return nil, nil, warnings, err
 		}
 
-		var resp tableFindResponse
-		if err := json.Unmarshal(b, &resp); err != nil {
+		docs, nextPageState, err := decodePage(codec, b)
+		if err != nil {
 			return nil, nil, warnings, err
 		}
 
-		return resp.Data.Documents, resp.Data.NextPageState, warnings, nil
+		return docs, nextPageState, warnings, nil
 	}
 
-	return cursor.New(fetcher)
+	cur := cursor.NewWithCodec(fetcher, codec)
+	cur.OnClose(cursorInfo.Close)
+	return cur
 }
 
 // FindOne finds a single row in a table matching the filter criteria.
 //
+// If no row matches, the result's Decode and Err methods return [ErrNotFound].
+//
 // Example usage:
 //
 //	result := table.FindOne(ctx, filter.Eq("id", "some-uuid"))
 //	var row MyRow
 //	err := result.Decode(&row)
+//	if errors.Is(err, astradb.ErrNotFound) {
+//	    // No matching row
+//	}
 func (t *Table) FindOne(ctx context.Context, f any, opts ...options.TableFindOption) *results.SingleResult {
 	// Validate filter type
 	switch f.(type) {
@@ -357,8 +435,9 @@ func (t *Table) FindOne(ctx context.Context, f any, opts ...options.TableFindOpt
 	}
 
 	cmd := t.newCmd("findOne", payload)
-	b, warnings, err := cmd.Execute(ctx)
-	return results.NewSingleResult(b, warnings, err)
+	cmdOpts := cmd.resolveOptions()
+	b, warnings, err := cmd.execute(ctx, cmdOpts)
+	return results.NewSingleResultWithCodec(b, warnings, err, cmdOpts.GetCodec())
 }
 
 // tableInsertOnePayload is the payload for insertOne on tables
@@ -384,6 +463,14 @@ type TableInsertResponse struct {
 		// Contains information about partition keys and clustering keys.
 		PrimaryKeySchema *PrimaryKeySchema `json:"primaryKeySchema,omitempty"`
 	} `json:"status"`
+
+	warnings results.Warnings
+}
+
+// Warnings returns any warnings from the API response.
+// Returns nil if there were no warnings.
+func (r TableInsertResponse) Warnings() results.Warnings {
+	return r.warnings
 }
 
 // PrimaryKeySchema describes the primary key structure returned in insert responses.
@@ -423,8 +510,8 @@ func (t *Table) InsertOne(ctx context.Context, row any, opts ...options.APIOptio
 	cmd := t.newCmd("insertOne", tableInsertOnePayload{
 		Document: row,
 	}, opts...)
-	// Note: Warnings are accessible via the WarningHandler option callback only.
-	b, _, err := cmd.Execute(ctx)
+	b, warnings, err := cmd.Execute(ctx)
+	resp.warnings = warnings
 	if err != nil {
 		return resp, err
 	}
@@ -458,8 +545,8 @@ func (t *Table) InsertMany(ctx context.Context, rows any, opts ...options.APIOpt
 	cmd := t.newCmd("insertMany", tableInsertManyPayload{
 		Documents: rows,
 	}, opts...)
-	// Note: Warnings are accessible via the WarningHandler option callback only.
-	b, _, err := cmd.Execute(ctx)
+	b, warnings, err := cmd.Execute(ctx)
+	resp.warnings = warnings
 	if err != nil {
 		return resp, err
 	}
@@ -467,6 +554,39 @@ func (t *Table) InsertMany(ctx context.Context, rows any, opts ...options.APIOpt
 	return resp, err
 }
 
+// alterTablePayload is the payload for the alterTable command
+type alterTablePayload struct {
+	Operation json.RawMessage `json:"operation"`
+}
+
+// Alter changes the table's schema with a single alterTable operation.
+//
+// Example usage:
+//
+//	_, err := tbl.Alter(ctx, table.AlterAdd{Columns: map[string]table.Column{
+//		"isbn": table.Text(),
+//	}})
+//
+// Use [table.DiffDefinitions] to compute the operations that bring a live
+// table to a desired definition.
+func (t *Table) Alter(ctx context.Context, op table.AlterOperation, opts ...options.APIOption) (*results.CommandResult, error) {
+	cmd, err := alterTableCommand(t, op, opts...)
+	if err != nil {
+		return nil, err
+	}
+	b, warnings, err := cmd.Execute(ctx)
+	return results.NewCommandResult(b, warnings), err
+}
+
+// alterTableCommand builds the alterTable command for the table
+func alterTableCommand(t *Table, op table.AlterOperation, opts ...options.APIOption) (command, error) {
+	operation, err := table.MarshalOperation(op)
+	if err != nil {
+		return command{}, err
+	}
+	return t.newCmd("alterTable", alterTablePayload{Operation: operation}, opts...), nil
+}
+
 // createIndexPayload is the payload for the createIndex command
 type createIndexPayload struct {
 	Name       string                `json:"name"`
@@ -517,40 +637,49 @@ type vectorIndexDefOpts struct {
 //   - A string for regular column indexes: "column_name"
 //   - A map for indexing map column keys or values: map[string]string{"map_col": "$keys"}
 //
+// An index on a list or set column covers its values, and an index on a map
+// column named by a string covers its entries. Use SetMapTarget on the
+// options builder to index the keys or values of a map column instead.
+//
 // For text columns, you can configure index behavior using SetAscii, SetNormalize,
-// and SetCaseSensitive on the options builder.
+// and SetCaseSensitive on the options builder. For lexical search, create a
+// text index with [Table.CreateTextIndex] instead.
 //
 // Example - basic column index:
 //
-//	err := tbl.CreateIndex(ctx, "rating_idx", "rating")
+//	_, err := tbl.CreateIndex(ctx, "rating_idx", "rating")
 //
 // Example - text column with case-insensitive matching:
 //
-//	err := tbl.CreateIndex(ctx, "title_idx", "title",
+//	_, err := tbl.CreateIndex(ctx, "title_idx", "title",
 //	    options.CreateIndex().SetCaseSensitive(false))
 //
 // Example - map column keys index:
 //
-//	err := tbl.CreateIndex(ctx, "tags_idx", map[string]string{"tags": "$keys"})
+//	_, err := tbl.CreateIndex(ctx, "tags_idx", map[string]string{"tags": "$keys"})
+//
+// or equivalently:
+//
+//	_, err := tbl.CreateIndex(ctx, "tags_idx", "tags",
+//	    options.CreateIndex().SetMapTarget(options.MapIndexKeys))
 //
 // Example - with ifNotExists:
 //
-//	err := tbl.CreateIndex(ctx, "rating_idx", "rating",
+//	_, err := tbl.CreateIndex(ctx, "rating_idx", "rating",
 //	    options.CreateIndex().SetIfNotExists(true))
 //
 // Example - combining multiple option sources:
 //
-//	err := tbl.CreateIndex(ctx, "title_idx", "title",
+//	_, err := tbl.CreateIndex(ctx, "title_idx", "title",
 //	    options.CreateIndex().SetAscii(true),
 //	    options.CreateIndex().SetIfNotExists(true))
-func (t *Table) CreateIndex(ctx context.Context, name string, column any, opts ...options.Builder[options.CreateIndexOptions]) error {
+func (t *Table) CreateIndex(ctx context.Context, name string, column any, opts ...options.Builder[options.CreateIndexOptions]) (*results.CommandResult, error) {
 	cmd, err := createIndexCommand(t, name, column, opts...)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	// Note: Warnings are accessible via the WarningHandler option callback only.
-	_, _, err = cmd.Execute(ctx)
-	return err
+	b, warnings, err := cmd.Execute(ctx)
+	return results.NewCommandResult(b, warnings), err
 }
 
 // Validate index name.
@@ -577,10 +706,21 @@ func validateIndexColumn(column any) error {
 			return fmt.Errorf("index column name cannot be empty")
 		}
 	case map[string]string:
-		// OK. But make sure not empty map.
+		// OK. But make sure it names exactly one column and a valid target.
 		if len(column) == 0 {
 			return fmt.Errorf("index column map cannot be empty")
 		}
+		if len(column) > 1 {
+			return fmt.Errorf("index column map must have exactly one column, got %d", len(column))
+		}
+		for name, target := range column {
+			if name == "" {
+				return fmt.Errorf("index column name cannot be empty")
+			}
+			if target != string(options.MapIndexKeys) && target != string(options.MapIndexValues) {
+				return fmt.Errorf("invalid map index target %q for column %s, want $keys or $values", target, name)
+			}
+		}
 	default:
 		return fmt.Errorf("invalid index column type: %T", column)
 	}
@@ -588,6 +728,22 @@ func validateIndexColumn(column any) error {
 	return nil
 }
 
+// indexColumn applies the map target option to column, which has already
+// been validated.
+func indexColumn(column any, target *options.MapIndexTarget) (any, error) {
+	if target == nil || *target == options.MapIndexEntries {
+		return column, nil
+	}
+	if *target != options.MapIndexKeys && *target != options.MapIndexValues {
+		return nil, fmt.Errorf("invalid map index target %q", *target)
+	}
+	name, ok := column.(string)
+	if !ok {
+		return nil, fmt.Errorf("map index target cannot be combined with a column map")
+	}
+	return map[string]string{name: string(*target)}, nil
+}
+
 // createIndexCommand builds the createIndex command for the table
 func createIndexCommand(t *Table, name string, column any, opts ...options.Builder[options.CreateIndexOptions]) (command, error) {
 	if err := validateIndexName(name); err != nil {
@@ -609,6 +765,11 @@ func createIndexCommand(t *Table, name string, column any, opts ...options.Build
 	}
 
 	if merged != nil {
+		payload.Definition.Column, err = indexColumn(column, merged.MapTarget)
+		if err != nil {
+			return command{}, err
+		}
+
 		// Add definition options if any text index options are set
 		if merged.Ascii != nil || merged.Normalize != nil || merged.CaseSensitive != nil {
 			payload.Definition.Options = &indexDefOpts{
@@ -636,25 +797,24 @@ func createIndexCommand(t *Table, name string, column any, opts ...options.Build
 //
 // Example - basic vector index:
 //
-//	err := tbl.CreateVectorIndex(ctx, "embedding_idx", "embedding")
+//	_, err := tbl.CreateVectorIndex(ctx, "embedding_idx", "embedding")
 //
 // Example - with metric and source model:
 //
-//	err := tbl.CreateVectorIndex(ctx, "embedding_idx", "embedding",
+//	_, err := tbl.CreateVectorIndex(ctx, "embedding_idx", "embedding",
 //	    options.CreateVectorIndex().SetMetric(options.MetricDotProduct).SetSourceModel("ada002"))
 //
 // Example - with ifNotExists:
 //
-//	err := tbl.CreateVectorIndex(ctx, "embedding_idx", "embedding",
+//	_, err := tbl.CreateVectorIndex(ctx, "embedding_idx", "embedding",
 //	    options.CreateVectorIndex().SetIfNotExists(true))
-func (t *Table) CreateVectorIndex(ctx context.Context, name string, column string, opts ...options.Builder[options.CreateVectorIndexOptions]) error {
+func (t *Table) CreateVectorIndex(ctx context.Context, name string, column string, opts ...options.Builder[options.CreateVectorIndexOptions]) (*results.CommandResult, error) {
 	cmd, err := createVectorIndexCommand(t, name, column, opts...)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	// Note: Warnings are accessible via the WarningHandler option callback only.
-	_, _, err = cmd.Execute(ctx)
-	return err
+	b, warnings, err := cmd.Execute(ctx)
+	return results.NewCommandResult(b, warnings), err
 }
 
 // createVectorIndexCommand builds the createVectorIndex command for the table
@@ -701,6 +861,95 @@ func createVectorIndexCommand(t *Table, name string, column string, opts ...opti
 	return t.newCmd("createVectorIndex", payload), nil
 }
 
+// createTextIndexPayload is the payload for the createTextIndex command
+type createTextIndexPayload struct {
+	Name       string                    `json:"name"`
+	Definition createTextIndexDefinition `json:"definition"`
+	Options    *createIndexOpts          `json:"options,omitempty"`
+}
+
+// createTextIndexDefinition defines which column to index and its analyzer
+type createTextIndexDefinition struct {
+	Column  string            `json:"column"`
+	Options *textIndexDefOpts `json:"options,omitempty"`
+}
+
+// textIndexDefOpts contains options for text index behavior
+type textIndexDefOpts struct {
+	Analyzer *options.TextAnalyzer `json:"analyzer,omitempty"`
+}
+
+// CreateTextIndex creates a text index on a text column in the table,
+// enabling lexical search and $match filters on it.
+//
+// Example - basic text index with the standard analyzer:
+//
+//	_, err := tbl.CreateTextIndex(ctx, "summary_idx", "summary")
+//
+// Example - with a named analyzer:
+//
+//	_, err := tbl.CreateTextIndex(ctx, "summary_idx", "summary",
+//	    options.CreateTextIndex().SetAnalyzer("english"))
+//
+// Example - with a custom analyzer:
+//
+//	_, err := tbl.CreateTextIndex(ctx, "summary_idx", "summary",
+//	    options.CreateTextIndex().SetCustomAnalyzer(options.TextAnalyzer{
+//	        Tokenizer: &options.AnalyzerComponent{Name: "standard"},
+//	        Filters:   []options.AnalyzerComponent{{Name: "lowercase"}, {Name: "porterstem"}},
+//	    }))
+func (t *Table) CreateTextIndex(ctx context.Context, name string, column string, opts ...options.Builder[options.CreateTextIndexOptions]) (*results.CommandResult, error) {
+	cmd, err := createTextIndexCommand(t, name, column, opts...)
+	if err != nil {
+		return nil, err
+	}
+	b, warnings, err := cmd.Execute(ctx)
+	return results.NewCommandResult(b, warnings), err
+}
+
+// createTextIndexCommand builds the createTextIndex command for the table
+func createTextIndexCommand(t *Table, name string, column string, opts ...options.Builder[options.CreateTextIndexOptions]) (command, error) {
+	if err := validateIndexName(name); err != nil {
+		return command{}, err
+	}
+	if err := validateIndexColumn(column); err != nil {
+		return command{}, err
+	}
+	payload := createTextIndexPayload{
+		Name: name,
+		Definition: createTextIndexDefinition{
+			Column: column,
+		},
+	}
+
+	merged, err := options.MergeOptions(opts...)
+	if err != nil {
+		return command{}, err
+	}
+
+	if merged != nil {
+		if merged.Analyzer != nil {
+			payload.Definition.Options = &textIndexDefOpts{Analyzer: merged.Analyzer}
+		}
+
+		// Add command options if ifNotExists is set
+		if merged.IfNotExists != nil && *merged.IfNotExists {
+			payload.Options = &createIndexOpts{
+				IfNotExists: true,
+			}
+		}
+	}
+
+	return t.newCmd("createTextIndex", payload), nil
+}
+
+// Index types reported in [IndexDescriptor.IndexType].
+const (
+	IndexTypeRegular = "regular"
+	IndexTypeVector  = "vector"
+	IndexTypeText    = "text"
+)
+
 // IndexDescriptor describes an index on a table.
 // When listing indexes with explain=true, all fields are populated.
 // When explain=false, only Name is populated.
@@ -710,7 +959,7 @@ type IndexDescriptor struct {
 	// Definition contains the column and options for the index.
 	// Only populated when explain=true.
 	Definition *IndexDefinition `json:"definition,omitempty"`
-	// IndexType is either "regular" or "vector".
+	// IndexType is one of [IndexTypeRegular], [IndexTypeVector] or [IndexTypeText].
 	// Only populated when explain=true.
 	IndexType string `json:"indexType,omitempty"`
 }
@@ -740,10 +989,58 @@ func (d *IndexDescriptor) UnmarshalJSON(data []byte) error {
 type IndexDefinition struct {
 	// Column is the name of the indexed column.
 	Column string `json:"column"`
+	// MapTarget is [options.MapIndexKeys] or [options.MapIndexValues] for
+	// indexes on the keys or values of a map column, and empty otherwise.
+	MapTarget options.MapIndexTarget `json:"-"`
 	// Options contains index-specific configuration.
 	Options *IndexDefinitionOptions `json:"options,omitempty"`
 }
 
+// indexDefinitionJSON is the wire format of IndexDefinition, whose column is
+// either a name or a {"column": "$keys"} map.
+type indexDefinitionJSON struct {
+	Column  json.RawMessage         `json:"column"`
+	Options *IndexDefinitionOptions `json:"options,omitempty"`
+}
+
+// MarshalJSON implements custom JSON marshaling for IndexDefinition.
+func (d IndexDefinition) MarshalJSON() ([]byte, error) {
+	var column any = d.Column
+	if d.MapTarget != "" && d.MapTarget != options.MapIndexEntries {
+		column = map[string]string{d.Column: string(d.MapTarget)}
+	}
+	b, err := json.Marshal(column)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(indexDefinitionJSON{Column: b, Options: d.Options})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for IndexDefinition.
+// It handles both column names and map key/value column objects.
+func (d *IndexDefinition) UnmarshalJSON(data []byte) error {
+	var raw indexDefinitionJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*d = IndexDefinition{Options: raw.Options}
+	if len(raw.Column) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(raw.Column, &d.Column); err == nil {
+		return nil
+	}
+	var column map[string]string
+	if err := json.Unmarshal(raw.Column, &column); err != nil {
+		return err
+	}
+	for name, target := range column {
+		d.Column = name
+		d.MapTarget = options.MapIndexTarget(target)
+	}
+	return nil
+}
+
 // IndexDefinitionOptions contains configuration for an index.
 type IndexDefinitionOptions struct {
 	// Metric is the similarity metric for vector indexes (cosine, dot_product, euclidean).
@@ -756,6 +1053,8 @@ type IndexDefinitionOptions struct {
 	Normalize *bool `json:"normalize,omitempty"`
 	// CaseSensitive if true, enforces case-sensitive matching.
 	CaseSensitive *bool `json:"caseSensitive,omitempty"`
+	// Analyzer is the analyzer of text indexes.
+	Analyzer *options.TextAnalyzer `json:"analyzer,omitempty"`
 }
 
 // listIndexesPayload is the payload for the listIndexes command
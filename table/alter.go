@@ -0,0 +1,205 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// ErrUnsupportedAlteration is returned by [DiffDefinitions] when the desired
+// definition can't be reached with alterTable, e.g. when the primary key or
+// the type of an existing column changes.
+var ErrUnsupportedAlteration = errors.New("unsupported table alteration")
+
+// AlterOperation is an operation of the alterTable command. It is one of
+// [AlterAdd], [AlterDrop], [AlterAddVectorize] or [AlterDropVectorize].
+type AlterOperation interface {
+	// OperationName returns the name of the operation in the alterTable payload.
+	OperationName() string
+}
+
+// AlterAdd adds columns to a table.
+//
+// Example:
+//
+//	op := table.AlterAdd{Columns: map[string]table.Column{
+//		"isbn":   table.Text(),
+//		"genres": table.Set(table.Text()),
+//	}}
+type AlterAdd struct {
+	Columns map[string]Column `json:"columns"`
+}
+
+// OperationName implements [AlterOperation].
+func (AlterAdd) OperationName() string { return "add" }
+
+// AlterDrop drops columns from a table. Primary key columns can't be dropped.
+type AlterDrop struct {
+	Columns []string `json:"columns"`
+}
+
+// OperationName implements [AlterOperation].
+func (AlterDrop) OperationName() string { return "drop" }
+
+// AlterAddVectorize adds or replaces the vectorize service of vector columns.
+type AlterAddVectorize struct {
+	Columns map[string]VectorService `json:"columns"`
+}
+
+// OperationName implements [AlterOperation].
+func (AlterAddVectorize) OperationName() string { return "addVectorize" }
+
+// AlterDropVectorize removes the vectorize service from vector columns.
+// The columns and their data are kept.
+type AlterDropVectorize struct {
+	Columns []string `json:"columns"`
+}
+
+// OperationName implements [AlterOperation].
+func (AlterDropVectorize) OperationName() string { return "dropVectorize" }
+
+// MarshalOperation marshals op as the operation object of an alterTable
+// command, e.g. {"add":{"columns":{...}}}.
+func MarshalOperation(op AlterOperation) ([]byte, error) {
+	if op == nil {
+		return nil, errors.New("nil alter operation")
+	}
+	return json.Marshal(map[string]AlterOperation{op.OperationName(): op})
+}
+
+// DiffDefinitions returns the alter operations that change the live table
+// definition from into the desired definition to. Operations are ordered
+// so they can be applied one after another: columns are added, vectorize
+// services added or replaced, vectorize services dropped, then columns
+// dropped. Empty operations are omitted, so identical definitions produce
+// no operations.
+//
+// An error wrapping [ErrUnsupportedAlteration] is returned if the primary
+// key differs or an existing column changes type.
+//
+// Example:
+//
+//	ops, err := table.DiffDefinitions(live, desired)
+//	for _, op := range ops {
+//		if _, err := tbl.Alter(ctx, op); err != nil {
+//			return err
+//		}
+//	}
+func DiffDefinitions(from, to Definition) ([]AlterOperation, error) {
+	if !samePrimaryKey(from.PrimaryKey, to.PrimaryKey) {
+		return nil, fmt.Errorf("%w: primary key cannot be changed", ErrUnsupportedAlteration)
+	}
+
+	add := AlterAdd{Columns: map[string]Column{}}
+	addVectorize := AlterAddVectorize{Columns: map[string]VectorService{}}
+	var drop AlterDrop
+	var dropVectorize AlterDropVectorize
+
+	for _, name := range slices.Sorted(maps.Keys(to.Columns)) {
+		want := to.Columns[name]
+		have, ok := from.Columns[name]
+		if !ok {
+			add.Columns[name] = want
+			continue
+		}
+		if !sameColumnType(have, want) {
+			return nil, fmt.Errorf("%w: column %s cannot change from %s to %s",
+				ErrUnsupportedAlteration, name, describeColumn(have), describeColumn(want))
+		}
+		switch {
+		case want.Service != nil && !sameService(have.Service, want.Service):
+			addVectorize.Columns[name] = *want.Service
+		case want.Service == nil && have.Service != nil:
+			dropVectorize.Columns = append(dropVectorize.Columns, name)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(from.Columns)) {
+		if _, ok := to.Columns[name]; !ok {
+			drop.Columns = append(drop.Columns, name)
+		}
+	}
+
+	var ops []AlterOperation
+	if len(add.Columns) > 0 {
+		ops = append(ops, add)
+	}
+	if len(addVectorize.Columns) > 0 {
+		ops = append(ops, addVectorize)
+	}
+	if len(dropVectorize.Columns) > 0 {
+		ops = append(ops, dropVectorize)
+	}
+	if len(drop.Columns) > 0 {
+		ops = append(ops, drop)
+	}
+	return ops, nil
+}
+
+// samePrimaryKey compares primary keys, treating nil and empty sort maps alike.
+func samePrimaryKey(a, b PrimaryKey) bool {
+	return slices.Equal(a.PartitionBy, b.PartitionBy) && maps.Equal(a.PartitionSort, b.PartitionSort)
+}
+
+// sameService compares vectorize services, treating nil and empty
+// authentication and parameter maps alike.
+func sameService(a, b *VectorService) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Provider == b.Provider && a.ModelName == b.ModelName &&
+		maps.Equal(a.Authentication, b.Authentication) && maps.Equal(a.Parameters, b.Parameters)
+}
+
+// sameColumnType compares column types, ignoring vectorize services. A nil
+// dimension on either side is treated as unspecified.
+func sameColumnType(a, b Column) bool {
+	if a.Type != b.Type || !equalPtr(a.KeyType, b.KeyType) || !equalPtr(a.UDTName, b.UDTName) {
+		return false
+	}
+	if a.Dimension != nil && b.Dimension != nil && *a.Dimension != *b.Dimension {
+		return false
+	}
+	if (a.ValueType == nil) != (b.ValueType == nil) {
+		return false
+	}
+	return a.ValueType == nil || sameColumnType(*a.ValueType, *b.ValueType)
+}
+
+// equalPtr reports whether two pointers are both nil or point to equal values.
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// describeColumn returns a short description of a column type for errors.
+func describeColumn(c Column) string {
+	switch {
+	case c.Type == TypeVector && c.Dimension != nil:
+		return fmt.Sprintf("vector(%d)", *c.Dimension)
+	case c.Type == TypeMap && c.KeyType != nil && c.ValueType != nil:
+		return fmt.Sprintf("map<%s,%s>", *c.KeyType, describeColumn(*c.ValueType))
+	case c.ValueType != nil:
+		return fmt.Sprintf("%s<%s>", c.Type, describeColumn(*c.ValueType))
+	case c.UDTName != nil:
+		return *c.UDTName
+	}
+	return c.Type
+}
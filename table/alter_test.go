@@ -0,0 +1,153 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/datastax/astra-db-go/table"
+)
+
+func TestDiffDefinitions(t *testing.T) {
+	openai := &table.VectorService{Provider: "openai", ModelName: "text-embedding-3-small"}
+	nvidia := &table.VectorService{Provider: "nvidia", ModelName: "NV-Embed-QA"}
+	live := table.NewDefinition().
+		AddTextColumn("id").
+		AddTextColumn("title").
+		AddIntColumn("old").
+		AddVectorColumnWithService("a", 1024, openai).
+		AddVectorColumnWithService("b", 1024, openai).
+		AddVectorColumn("c", 1024).
+		SetPartitionBy("id").
+		Build()
+
+	tests := []struct {
+		name    string
+		desired table.Definition
+		want    []table.AlterOperation
+		wantErr bool
+	}{
+		{
+			name:    "identical",
+			desired: live,
+			want:    nil,
+		},
+		{
+			name: "all operations",
+			desired: table.NewDefinition().
+				AddTextColumn("id").
+				AddTextColumn("title").
+				AddColumn("tags", table.Set(table.Text())).
+				AddVectorColumnWithService("a", 1024, nvidia).
+				AddVectorColumn("b", 1024).
+				AddVectorColumnWithService("c", 0, openai).
+				SetPartitionBy("id").
+				Build(),
+			want: []table.AlterOperation{
+				table.AlterAdd{Columns: map[string]table.Column{"tags": table.Set(table.Text())}},
+				table.AlterAddVectorize{Columns: map[string]table.VectorService{"a": *nvidia, "c": *openai}},
+				table.AlterDropVectorize{Columns: []string{"b"}},
+				table.AlterDrop{Columns: []string{"old"}},
+			},
+		},
+		{
+			name: "service with empty maps",
+			desired: table.NewDefinition().
+				AddTextColumn("id").
+				AddTextColumn("title").
+				AddIntColumn("old").
+				AddVectorColumnWithService("a", 1024, &table.VectorService{
+					Provider:       "openai",
+					ModelName:      "text-embedding-3-small",
+					Authentication: map[string]string{},
+					Parameters:     map[string]string{},
+				}).
+				AddVectorColumnWithService("b", 1024, openai).
+				AddVectorColumn("c", 1024).
+				SetPartitionBy("id").
+				Build(),
+			want: nil,
+		},
+		{
+			name: "service parameter change",
+			desired: table.NewDefinition().
+				AddTextColumn("id").
+				AddTextColumn("title").
+				AddIntColumn("old").
+				AddVectorColumnWithService("a", 1024, openai).
+				AddVectorColumnWithService("b", 1024, &table.VectorService{
+					Provider:   "openai",
+					ModelName:  "text-embedding-3-small",
+					Parameters: map[string]string{"region": "eu"},
+				}).
+				AddVectorColumn("c", 1024).
+				SetPartitionBy("id").
+				Build(),
+			want: []table.AlterOperation{
+				table.AlterAddVectorize{Columns: map[string]table.VectorService{"b": {
+					Provider:   "openai",
+					ModelName:  "text-embedding-3-small",
+					Parameters: map[string]string{"region": "eu"},
+				}}},
+			},
+		},
+		{
+			name: "primary key change",
+			desired: table.NewDefinition().
+				AddTextColumn("id").
+				AddTextColumn("title").
+				SetPartitionBy("title").
+				Build(),
+			wantErr: true,
+		},
+		{
+			name: "type change",
+			desired: table.NewDefinition().
+				AddTextColumn("id").
+				AddIntColumn("title").
+				SetPartitionBy("id").
+				Build(),
+			wantErr: true,
+		},
+		{
+			name: "dimension change",
+			desired: table.NewDefinition().
+				AddTextColumn("id").
+				AddVectorColumn("c", 512).
+				SetPartitionBy("id").
+				Build(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := table.DiffDefinitions(live, tt.desired)
+			if tt.wantErr {
+				if !errors.Is(err, table.ErrUnsupportedAlteration) {
+					t.Fatalf("expected ErrUnsupportedAlteration, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffDefinitions() =\n%#v\nwant\n%#v", got, tt.want)
+			}
+		})
+	}
+}
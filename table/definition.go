@@ -15,7 +15,10 @@
 // Package table provides types and utilities for working with Astra DB tables.
 package table
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 // Definition represents the full schema for a table, including column names,
 // column data types, and the primary key.
@@ -40,6 +43,16 @@ type Definition struct {
 	PrimaryKey PrimaryKey `json:"primaryKey"`
 }
 
+// UsesVectorize reports whether any column of d has a vectorize service.
+func (d Definition) UsesVectorize() bool {
+	for _, col := range d.Columns {
+		if col.Service != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // Column represents a column's type definition.
 // It can be a simple scalar type, a collection type (set, list, map),
 // a vector type, or a user-defined type.
@@ -63,6 +76,48 @@ type Column struct {
 	UDTName *string `json:"udtName,omitempty"`
 }
 
+// UnmarshalJSON implements [json.Unmarshaler]. Besides the object form
+// that columns are marshaled to, it accepts the form of listTables
+// responses, which give scalar value and key types as type names:
+//
+//	{"type":"map","keyType":"text","valueType":"int"}
+func (c *Column) UnmarshalJSON(data []byte) error {
+	type plain Column
+	var raw struct {
+		plain
+		ValueType json.RawMessage `json:"valueType"`
+		KeyType   json.RawMessage `json:"keyType"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	col := Column(raw.plain)
+	if len(raw.ValueType) > 0 && string(raw.ValueType) != "null" {
+		col.ValueType = &Column{}
+		if err := unmarshalColumnType(raw.ValueType, col.ValueType); err != nil {
+			return fmt.Errorf("valueType: %w", err)
+		}
+	}
+	if len(raw.KeyType) > 0 && string(raw.KeyType) != "null" {
+		var key Column
+		if err := unmarshalColumnType(raw.KeyType, &key); err != nil {
+			return fmt.Errorf("keyType: %w", err)
+		}
+		col.KeyType = &key.Type
+	}
+	*c = col
+	return nil
+}
+
+// unmarshalColumnType decodes a column type given either as a type name or
+// as a column object.
+func unmarshalColumnType(data []byte, c *Column) error {
+	if data[0] == '"' {
+		return json.Unmarshal(data, &c.Type)
+	}
+	return json.Unmarshal(data, c)
+}
+
 // VectorService defines the embedding provider configuration for vectorize
 type VectorService struct {
 	// Provider is the embedding provider name (e.g., "openai", "nvidia", "azureOpenAI")
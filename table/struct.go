@@ -0,0 +1,296 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnsupportedGoType is returned by [DefinitionFromStruct] when a struct
+// field's Go type has no column type equivalent.
+var ErrUnsupportedGoType = errors.New("unsupported Go type for column")
+
+var (
+	timeType   = reflect.TypeFor[time.Time]()
+	ipType     = reflect.TypeFor[net.IP]()
+	bigIntType = reflect.TypeFor[big.Int]()
+)
+
+// DefinitionFromStruct derives a table definition from the exported fields
+// of the struct type T, so row structs and table schemas can't drift apart.
+//
+// The column name is taken from the first element of the field's astra tag,
+// falling back to its json tag name and then to the field name, matching
+// how rows are encoded. Fields tagged astra:"-" or json:"-" are skipped and
+// embedded structs are flattened. The remaining tag elements are options:
+//
+//   - pk: the column is part of the partition key, in field order
+//   - cluster, cluster=asc, cluster=desc: the column is a clustering column
+//   - vector=N: the column is a vector of dimension N
+//   - set, list: the collection type for a slice field (default list)
+//   - udt=NAME: the user-defined type of a struct field or element
+//   - type=TYPE: overrides the column type of a scalar field, e.g. date
+//
+// Go types map to column types as follows:
+//
+//   - string: text
+//   - bool: boolean
+//   - int, int64: bigint; int32: int; int16: smallint; int8: tinyint
+//   - float32: float; float64: double
+//   - time.Time: timestamp
+//   - [16]byte arrays such as uuid.UUID: uuid
+//   - []byte: blob; net.IP: inet; big.Int: varint
+//   - []float32: vector (requires vector=N unless tagged list or set)
+//   - other slices: list, or set when tagged
+//   - map[K]V: map
+//
+// Pointer fields map to the type they point to. An error wrapping
+// [ErrUnsupportedGoType] is returned for fields of any other type, and an
+// error is returned if no field is tagged pk.
+//
+// Example:
+//
+//	type Book struct {
+//		Title     string    `json:"title" astra:"title,pk"`
+//		Published time.Time `json:"published" astra:"published,cluster=desc"`
+//		Genres    []string  `json:"genres" astra:",set"`
+//		Embedding []float32 `json:"embedding" astra:"embedding,vector=1536"`
+//	}
+//	def, err := table.DefinitionFromStruct[Book]()
+func DefinitionFromStruct[T any]() (Definition, error) {
+	return DefinitionFromType(reflect.TypeFor[T]())
+}
+
+// DefinitionFromType is like [DefinitionFromStruct] for a reflect.Type. It
+// accepts struct types and pointers to struct types.
+func DefinitionFromType(t reflect.Type) (Definition, error) {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return Definition{}, fmt.Errorf("table: %v is not a struct type", t)
+	}
+	b := NewDefinition()
+	var errs []error
+	collectColumns(t, b, &errs)
+	if len(b.partitionBy) == 0 {
+		errs = append(errs, fmt.Errorf("table: %s has no primary key field, tag one with astra:\",pk\"", t))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return Definition{}, err
+	}
+	return b.Build(), nil
+}
+
+// collectColumns adds a column to b for each field of the struct type t,
+// recursing into embedded structs.
+func collectColumns(t reflect.Type, b *DefinitionBuilder, errs *[]error) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag, _ := f.Tag.Lookup("astra")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		jsonName, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if jsonName == "-" && name == "" {
+			continue
+		}
+		ft := f.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && jsonName == "" && ft.Kind() == reflect.Struct {
+			collectColumns(ft, b, errs)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = jsonName
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		fo, err := parseFieldOptions(opts)
+		if err == nil {
+			var col Column
+			col, err = fieldColumn(f.Type, fo)
+			if err == nil {
+				b.AddColumn(name, col)
+			}
+		}
+		if err != nil {
+			*errs = append(*errs, fmt.Errorf("table: field %s.%s: %w", t.Name(), f.Name, err))
+			continue
+		}
+		if fo.pk {
+			b.AddPartitionBy(name)
+		}
+		if fo.cluster != 0 {
+			b.AddClusteringColumn(name, fo.cluster)
+		}
+	}
+}
+
+// fieldOptions holds the options parsed from an astra struct tag.
+type fieldOptions struct {
+	pk         bool
+	cluster    int
+	dimension  int
+	collection string
+	udt        string
+	typ        string
+}
+
+// parseFieldOptions parses the options of an astra tag, i.e. everything
+// after the column name.
+func parseFieldOptions(s string) (fieldOptions, error) {
+	var fo fieldOptions
+	if s == "" {
+		return fo, nil
+	}
+	for opt := range strings.SplitSeq(s, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(opt), "=")
+		switch key {
+		case "pk":
+			fo.pk = true
+		case "cluster":
+			switch value {
+			case "", "asc":
+				fo.cluster = SortAscending
+			case "desc":
+				fo.cluster = SortDescending
+			default:
+				return fo, fmt.Errorf("invalid cluster order %q, want asc or desc", value)
+			}
+		case "vector":
+			dim, err := strconv.Atoi(value)
+			if err != nil || dim <= 0 {
+				return fo, fmt.Errorf("invalid vector dimension %q", value)
+			}
+			fo.dimension = dim
+		case TypeSet, TypeList:
+			fo.collection = key
+		case "udt":
+			fo.udt = value
+		case "type":
+			fo.typ = value
+		default:
+			return fo, fmt.Errorf("unknown astra tag option %q", key)
+		}
+	}
+	return fo, nil
+}
+
+// fieldColumn returns the column type for a field of type t.
+func fieldColumn(t reflect.Type, fo fieldOptions) (Column, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if fo.typ != "" {
+		return Column{Type: fo.typ}, nil
+	}
+	switch {
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Float32 && fo.collection == "":
+		if fo.dimension == 0 {
+			return Column{}, errors.New("vector column needs a dimension, e.g. astra:\",vector=1536\"")
+		}
+		return Vector(fo.dimension), nil
+	case t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8:
+		elem, err := valueColumn(t.Elem(), fo)
+		if err != nil {
+			return Column{}, err
+		}
+		if fo.collection == TypeSet {
+			return Set(elem), nil
+		}
+		return List(elem), nil
+	case t.Kind() == reflect.Map:
+		key, err := scalarColumn(t.Key())
+		if err != nil {
+			return Column{}, fmt.Errorf("map key: %w", err)
+		}
+		value, err := valueColumn(t.Elem(), fo)
+		if err != nil {
+			return Column{}, err
+		}
+		return Map(key.Type, value), nil
+	}
+	return valueColumn(t, fo)
+}
+
+// valueColumn returns the column type for a scalar or user-defined type,
+// which may also be the element type of a collection.
+func valueColumn(t reflect.Type, fo fieldOptions) (Column, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Struct && t != timeType && t != bigIntType {
+		if fo.udt == "" {
+			return Column{}, fmt.Errorf("%w %s: tag struct fields with udt=NAME", ErrUnsupportedGoType, t)
+		}
+		return UDT(fo.udt), nil
+	}
+	return scalarColumn(t)
+}
+
+// scalarColumn returns the column type for a scalar Go type.
+func scalarColumn(t reflect.Type) (Column, error) {
+	switch t {
+	case timeType:
+		return Timestamp(), nil
+	case ipType:
+		return Inet(), nil
+	case bigIntType:
+		return Varint(), nil
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return Text(), nil
+	case reflect.Bool:
+		return Boolean(), nil
+	case reflect.Int, reflect.Int64:
+		return BigInt(), nil
+	case reflect.Int32:
+		return Int(), nil
+	case reflect.Int16:
+		return SmallInt(), nil
+	case reflect.Int8:
+		return TinyInt(), nil
+	case reflect.Float32:
+		return Float(), nil
+	case reflect.Float64:
+		return Double(), nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return Blob(), nil
+		}
+	case reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Len() == 16 {
+			return UUID(), nil
+		}
+	}
+	return Column{}, fmt.Errorf("%w %s", ErrUnsupportedGoType, t)
+}
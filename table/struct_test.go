@@ -0,0 +1,137 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table_test
+
+import (
+	"errors"
+	"math/big"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/datastax/astra-db-go/table"
+)
+
+type uuidLike [16]byte
+
+type Audit struct {
+	CreatedBy string `json:"created_by"`
+}
+
+type Address struct {
+	City string `json:"city"`
+}
+
+type Reading struct {
+	Audit
+	Sensor    string             `astra:"sensor,pk"`
+	Region    string             `json:"region" astra:",pk"`
+	Taken     time.Time          `json:"taken" astra:"taken,cluster=desc"`
+	Seq       int32              `json:"seq" astra:",cluster"`
+	ID        uuidLike           `json:"id"`
+	Value     *float64           `json:"value,omitempty"`
+	Weight    float32            `json:"weight"`
+	Count     int                `json:"count"`
+	Small     int16              `json:"small"`
+	Tiny      int8               `json:"tiny"`
+	OK        bool               `json:"ok"`
+	Raw       []byte             `json:"raw"`
+	Host      net.IP             `json:"host"`
+	Big       *big.Int           `json:"big"`
+	Day       time.Time          `json:"day" astra:",type=date"`
+	Embedding []float32          `json:"embedding" astra:"embedding,vector=3"`
+	Scores    []float32          `json:"scores" astra:",list"`
+	Tags      []string           `json:"tags" astra:",set"`
+	Notes     []string           `json:"notes"`
+	Counts    map[string]int     `json:"counts"`
+	Home      Address            `json:"home" astra:",udt=address"`
+	Previous  []Address          `json:"previous" astra:",udt=address"`
+	ByName    map[string]Address `json:"by_name" astra:",udt=address"`
+	Ignored   string             `json:"-"`
+	Skipped   string             `json:"skipped" astra:"-"`
+	internal  string
+}
+
+func TestDefinitionFromStruct(t *testing.T) {
+	def, err := table.DefinitionFromStruct[*Reading]()
+	if err != nil {
+		t.Fatalf("DefinitionFromStruct: %v", err)
+	}
+	want := table.NewDefinition().
+		AddTextColumn("created_by").
+		AddTextColumn("sensor").
+		AddTextColumn("region").
+		AddTimestampColumn("taken").
+		AddIntColumn("seq").
+		AddUUIDColumn("id").
+		AddDoubleColumn("value").
+		AddFloatColumn("weight").
+		AddBigIntColumn("count").
+		AddSmallIntColumn("small").
+		AddTinyIntColumn("tiny").
+		AddBooleanColumn("ok").
+		AddBlobColumn("raw").
+		AddInetColumn("host").
+		AddVarintColumn("big").
+		AddDateColumn("day").
+		AddVectorColumn("embedding", 3).
+		AddListColumn("scores", table.Float()).
+		AddSetColumn("tags", table.Text()).
+		AddListColumn("notes", table.Text()).
+		AddMapColumn("counts", table.TypeText, table.BigInt()).
+		AddUDTColumn("home", "address").
+		AddListColumn("previous", table.UDT("address")).
+		AddMapColumn("by_name", table.TypeText, table.UDT("address")).
+		SetPartitionBy("sensor", "region").
+		AddClusteringColumnDesc("taken").
+		AddClusteringColumnAsc("seq").
+		Build()
+	if !reflect.DeepEqual(def, want) {
+		t.Errorf("unexpected definition\n got: %+v\nwant: %+v", def, want)
+	}
+}
+
+func TestDefinitionFromStructErrors(t *testing.T) {
+	type noKey struct {
+		Name string `json:"name"`
+	}
+	type badTypes struct {
+		ID     string    `json:"id" astra:",pk"`
+		Length uint      `json:"length"`
+		Home   Address   `json:"home"`
+		Vec    []float32 `json:"vec"`
+		Any    any       `json:"any"`
+		Order  string    `json:"order" astra:",cluster=sideways"`
+	}
+
+	if _, err := table.DefinitionFromStruct[noKey](); err == nil || !strings.Contains(err.Error(), "no primary key") {
+		t.Errorf("expected missing primary key error, got %v", err)
+	}
+	if _, err := table.DefinitionFromStruct[string](); err == nil {
+		t.Error("expected error for non-struct type")
+	}
+
+	_, err := table.DefinitionFromStruct[badTypes]()
+	if !errors.Is(err, table.ErrUnsupportedGoType) {
+		t.Fatalf("expected ErrUnsupportedGoType, got %v", err)
+	}
+	for _, field := range []string{"Length", "Home", "Vec", "Any", "Order"} {
+		if !strings.Contains(err.Error(), "badTypes."+field+":") {
+			t.Errorf("expected error for field %s in %v", field, err)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// TypeDefinition represents the fields of a user-defined type (UDT). Once
+// created, the type can be used in table definitions with [UDT], either as a
+// column type or as the value type of a set, list or map column.
+//
+// Example:
+//
+//	def := table.TypeDefinition{
+//		Fields: map[string]table.Column{
+//			"street": table.Text(),
+//			"city":   table.Text(),
+//			"zip":    table.Int(),
+//		},
+//	}
+type TypeDefinition struct {
+	// Fields defines all fields of the type with their types
+	Fields map[string]Column `json:"fields"`
+}
+
+// TypeDefinitionBuilder provides a fluent API for constructing user-defined
+// type definitions, mirroring [DefinitionBuilder].
+//
+// Example:
+//
+//	def := table.NewTypeDefinition().
+//		AddTextField("street").
+//		AddTextField("city").
+//		AddIntField("zip").
+//		Build()
+type TypeDefinitionBuilder struct {
+	fields map[string]Column
+}
+
+// NewTypeDefinition creates a new TypeDefinitionBuilder for fluent type definition construction.
+func NewTypeDefinition() *TypeDefinitionBuilder {
+	return &TypeDefinitionBuilder{
+		fields: make(map[string]Column),
+	}
+}
+
+// AddField adds a field with the specified name and type.
+func (b *TypeDefinitionBuilder) AddField(name string, fieldType Column) *TypeDefinitionBuilder {
+	b.fields[name] = fieldType
+	return b
+}
+
+// AddTextField adds a text field.
+func (b *TypeDefinitionBuilder) AddTextField(name string) *TypeDefinitionBuilder {
+	return b.AddField(name, Text())
+}
+
+// AddIntField adds an int field.
+func (b *TypeDefinitionBuilder) AddIntField(name string) *TypeDefinitionBuilder {
+	return b.AddField(name, Int())
+}
+
+// AddBigIntField adds a bigint field.
+func (b *TypeDefinitionBuilder) AddBigIntField(name string) *TypeDefinitionBuilder {
+	return b.AddField(name, BigInt())
+}
+
+// AddSmallIntField adds a smallint field.
+func (b *TypeDefinitionBuilder) AddSmallIntField(name string) *TypeDefinitionBuilder {
+	return b.AddField(name, SmallInt())
+}
+
+// AddTinyIntField adds a tinyint field.
+func (b *TypeDefinitionBuilder) AddTinyIntField(name string) *TypeDefinitionBuilder {
+	return b.AddField(name, TinyInt())
+}
+
+// AddFloatField adds a float field.
+func (b *TypeDefinitionBuilder) AddFloatField(name string) *TypeDefinitionBuilder {
+	return b.AddField(name, Float())
+}
+
+// AddDoubleField adds a double field.
+func (b *TypeDefinitionBuilder) AddDoubleField(name string) *TypeDefinitionBuilder {
+	return b.AddField(name, Double())
+}
+
+// AddDecimalField adds a decimal field.
+func (b *TypeDefinitionBuilder) AddDecimalField(name string) *TypeDefinitionBuilder {
+	return b.AddField(name, Decimal())
+}
+
+// AddBooleanField adds a boolean field.
+func (b *TypeDefinitionBuilder) AddBooleanField(name string) *TypeDefinitionBuilder {
+	return b.AddField(name, Boolean())
+}
+
+// AddDateField adds a date field.
+func (b *TypeDefinitionBuilder) AddDateField(name string) *TypeDefinitionBuilder {
+	return b.AddField(name, Date())
+}
+
+// AddTimeField adds a time field.
+func (b *TypeDefinitionBuilder) AddTimeField(name string) *TypeDefinitionBuilder {
+	return b.AddField(name, Time())
+}
+
+// AddTimestampField adds a timestamp field.
+func (b *TypeDefinitionBuilder) AddTimestampField(name string) *TypeDefinitionBuilder {
+	return b.AddField(name, Timestamp())
+}
+
+// AddUUIDField adds a UUID field.
+func (b *TypeDefinitionBuilder) AddUUIDField(name string) *TypeDefinitionBuilder {
+	return b.AddField(name, UUID())
+}
+
+// AddTimeUUIDField adds a TimeUUID field.
+func (b *TypeDefinitionBuilder) AddTimeUUIDField(name string) *TypeDefinitionBuilder {
+	return b.AddField(name, TimeUUID())
+}
+
+// AddBlobField adds a blob field.
+func (b *TypeDefinitionBuilder) AddBlobField(name string) *TypeDefinitionBuilder {
+	return b.AddField(name, Blob())
+}
+
+// AddVarintField adds a varint field.
+func (b *TypeDefinitionBuilder) AddVarintField(name string) *TypeDefinitionBuilder {
+	return b.AddField(name, Varint())
+}
+
+// AddInetField adds an inet field.
+func (b *TypeDefinitionBuilder) AddInetField(name string) *TypeDefinitionBuilder {
+	return b.AddField(name, Inet())
+}
+
+// AddAsciiField adds an ascii field.
+func (b *TypeDefinitionBuilder) AddAsciiField(name string) *TypeDefinitionBuilder {
+	return b.AddField(name, Ascii())
+}
+
+// Build constructs the final TypeDefinition from the builder.
+func (b *TypeDefinitionBuilder) Build() TypeDefinition {
+	return TypeDefinition{Fields: b.fields}
+}
+
+// AlterTypeOperation is an operation of the alterType command. It is one of
+// [AlterTypeAdd] or [AlterTypeRename].
+type AlterTypeOperation interface {
+	// OperationName returns the name of the operation in the alterType payload.
+	OperationName() string
+}
+
+// AlterTypeAdd adds fields to a user-defined type. Existing rows read the
+// new fields as null.
+//
+// Example:
+//
+//	op := table.AlterTypeAdd{Fields: map[string]table.Column{
+//		"country": table.Text(),
+//	}}
+type AlterTypeAdd struct {
+	Fields map[string]Column `json:"fields"`
+}
+
+// OperationName implements [AlterTypeOperation].
+func (AlterTypeAdd) OperationName() string { return "add" }
+
+// AlterTypeRename renames fields of a user-defined type. Fields maps each
+// current field name to its new name.
+type AlterTypeRename struct {
+	Fields map[string]string `json:"fields"`
+}
+
+// OperationName implements [AlterTypeOperation].
+func (AlterTypeRename) OperationName() string { return "rename" }
+
+// MarshalTypeOperation marshals op as the operation object of an alterType
+// command, e.g. {"rename":{"fields":{"zip":"postcode"}}}.
+func MarshalTypeOperation(op AlterTypeOperation) ([]byte, error) {
+	if op == nil {
+		return nil, errors.New("nil alter type operation")
+	}
+	return json.Marshal(map[string]AlterTypeOperation{op.OperationName(): op})
+}
@@ -0,0 +1,78 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/datastax/astra-db-go/table"
+)
+
+func TestTypeDefinitionBuilder(t *testing.T) {
+	def := table.NewTypeDefinition().
+		AddTextField("street").
+		AddIntField("zip").
+		AddUUIDField("ref").
+		AddField("tags", table.Set(table.Text())).
+		Build()
+
+	want := table.TypeDefinition{Fields: map[string]table.Column{
+		"street": table.Text(),
+		"zip":    table.Int(),
+		"ref":    table.UUID(),
+		"tags":   table.Set(table.Text()),
+	}}
+	if !reflect.DeepEqual(def, want) {
+		t.Errorf("expected %+v, got %+v", want, def)
+	}
+
+	b, err := json.Marshal(table.NewTypeDefinition().AddTextField("city").Build())
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	if string(b) != `{"fields":{"city":{"type":"text"}}}` {
+		t.Errorf("unexpected JSON %s", b)
+	}
+}
+
+func TestMarshalTypeOperation(t *testing.T) {
+	tests := []struct {
+		op   table.AlterTypeOperation
+		want string
+	}{
+		{
+			op:   table.AlterTypeAdd{Fields: map[string]table.Column{"country": table.Text()}},
+			want: `{"add":{"fields":{"country":{"type":"text"}}}}`,
+		},
+		{
+			op:   table.AlterTypeRename{Fields: map[string]string{"zip": "postcode"}},
+			want: `{"rename":{"fields":{"zip":"postcode"}}}`,
+		},
+	}
+	for _, tt := range tests {
+		b, err := table.MarshalTypeOperation(tt.op)
+		if err != nil {
+			t.Fatalf("MarshalTypeOperation: %v", err)
+		}
+		if string(b) != tt.want {
+			t.Errorf("expected %s, got %s", tt.want, b)
+		}
+	}
+	if _, err := table.MarshalTypeOperation(nil); err == nil {
+		t.Error("expected error for nil operation")
+	}
+}
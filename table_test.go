@@ -15,11 +15,16 @@
 package astradb
 
 import (
+	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/datastax/astra-db-go/filter"
 	"github.com/datastax/astra-db-go/options"
+	"github.com/datastax/astra-db-go/projection"
+	"github.com/datastax/astra-db-go/sort"
 	"github.com/datastax/astra-db-go/table"
 )
 
@@ -327,6 +332,101 @@ func TestPrimaryKeyUnmarshal(t *testing.T) {
 	}
 }
 
+func TestColumnUnmarshal(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		valueType string
+		keyType   string
+	}{
+		{
+			name:      "set with string value type",
+			input:     `{"type":"set","valueType":"text"}`,
+			valueType: "text",
+		},
+		{
+			name:      "map with string key and value types",
+			input:     `{"type":"map","keyType":"text","valueType":"int"}`,
+			valueType: "int",
+			keyType:   "text",
+		},
+		{
+			name:      "list with object value type",
+			input:     `{"type":"list","valueType":{"type":"text"}}`,
+			valueType: "text",
+		},
+		{
+			name:      "map with object key type",
+			input:     `{"type":"map","keyType":{"type":"text"},"valueType":{"type":"userDefined","udtName":"address"}}`,
+			valueType: "userDefined",
+			keyType:   "text",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var col table.Column
+			if err := json.Unmarshal([]byte(tt.input), &col); err != nil {
+				t.Fatalf("failed to unmarshal: %v", err)
+			}
+			if col.ValueType == nil || col.ValueType.Type != tt.valueType {
+				t.Errorf("expected value type %q, got %+v", tt.valueType, col.ValueType)
+			}
+			if tt.keyType == "" {
+				if col.KeyType != nil {
+					t.Errorf("expected no key type, got %q", *col.KeyType)
+				}
+			} else if col.KeyType == nil || *col.KeyType != tt.keyType {
+				t.Errorf("expected key type %q, got %v", tt.keyType, col.KeyType)
+			}
+		})
+	}
+}
+
+func TestListTablesDecodesServerResponse(t *testing.T) {
+	// A listTables response with explain, as returned by the Data API.
+	const body = `{"status":{"tables":[{"name":"users","definition":{
+		"columns":{
+			"id":{"type":"uuid","apiSupport":{"createTable":true,"insert":true,"read":true,"filter":true,"cqlDefinition":"uuid"}},
+			"tags":{"type":"set","valueType":"text","apiSupport":{"createTable":true,"insert":true,"read":true,"filter":true,"cqlDefinition":"set<text>"}},
+			"scores":{"type":"list","valueType":"int","apiSupport":{"createTable":true,"insert":true,"read":true,"filter":true,"cqlDefinition":"list<int>"}},
+			"attrs":{"type":"map","keyType":"text","valueType":"float","apiSupport":{"createTable":true,"insert":true,"read":true,"filter":true,"cqlDefinition":"map<text, float>"}},
+			"home":{"type":"userDefined","udtName":"address","apiSupport":{"createTable":true,"insert":true,"read":true,"filter":false,"cqlDefinition":"address"}}
+		},
+		"primaryKey":{"partitionBy":["id"],"partitionSort":{}}
+	}}]}}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	db := NewClient(options.WithToken("token")).Database(srv.URL)
+	tables, err := db.ListTables(context.Background())
+	if err != nil {
+		t.Fatalf("ListTables failed: %v", err)
+	}
+	if len(tables) != 1 || tables[0].Name != "users" {
+		t.Fatalf("unexpected tables: %+v", tables)
+	}
+	cols := tables[0].Definition.Columns
+	if v := cols["tags"].ValueType; v == nil || v.Type != "text" {
+		t.Errorf("expected tags value type text, got %+v", v)
+	}
+	if v := cols["scores"].ValueType; v == nil || v.Type != "int" {
+		t.Errorf("expected scores value type int, got %+v", v)
+	}
+	attrs := cols["attrs"]
+	if attrs.KeyType == nil || *attrs.KeyType != "text" || attrs.ValueType == nil || attrs.ValueType.Type != "float" {
+		t.Errorf("unexpected attrs column: %+v", attrs)
+	}
+	if home := cols["home"]; home.UDTName == nil || *home.UDTName != "address" {
+		t.Errorf("unexpected home column: %+v", home)
+	}
+	if pk := tables[0].Definition.PrimaryKey.PartitionBy; len(pk) != 1 || pk[0] != "id" {
+		t.Errorf("unexpected primary key: %v", pk)
+	}
+}
+
 func TestTableFindPayloadMarshal(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -523,6 +623,69 @@ func TestTableFindOptions(t *testing.T) {
 	})
 }
 
+func TestFindPayloadWithSortAndProjectionBuilders(t *testing.T) {
+	t.Run("table", func(t *testing.T) {
+		findOpts := options.NewTableFindOptions(
+			options.WithSort(sort.Desc("rating").Asc("title")),
+			options.WithProjection(projection.Include("title", "rating")),
+		)
+		payload := tableFindPayload{
+			Filter:     filter.F{},
+			Sort:       findOpts.Sort,
+			Projection: findOpts.Projection,
+		}
+		b, err := json.Marshal(payload)
+		if err != nil {
+			t.Fatalf("failed to marshal: %v", err)
+		}
+		expected := `{"filter":{},"sort":{"rating":-1,"title":1},"projection":{"title":true,"rating":true}}`
+		if string(b) != expected {
+			t.Errorf("expected %s, got %s", expected, string(b))
+		}
+	})
+	t.Run("collection", func(t *testing.T) {
+		findOpts := options.NewCollectionFindOptions(
+			options.WithCollectionSort(sort.Asc("title").Desc("rating")),
+			options.WithCollectionProjection(projection.Exclude("_id").Slice("reviews", 0, 2)),
+		)
+		payload := collectionFindPayload{
+			Filter:     filter.F{},
+			Sort:       findOpts.Sort,
+			Projection: findOpts.Projection,
+		}
+		b, err := json.Marshal(payload)
+		if err != nil {
+			t.Fatalf("failed to marshal: %v", err)
+		}
+		expected := `{"filter":{},"sort":{"title":1,"rating":-1},"projection":{"_id":false,"reviews":{"$slice":[0,2]}}}`
+		if string(b) != expected {
+			t.Errorf("expected %s, got %s", expected, string(b))
+		}
+	})
+	t.Run("nil builders and empty maps are omitted", func(t *testing.T) {
+		var s *sort.Sort
+		var p *projection.Projection
+		tableOpts := options.NewTableFindOptions(options.WithSort(s), options.WithProjection(p))
+		collOpts := options.NewCollectionFindOptions(options.WithCollectionSort(s), options.WithCollectionProjection(p))
+		emptyTableOpts := options.NewTableFindOptions(options.WithSort(map[string]any{}), options.WithProjection(map[string]bool{}))
+		emptyCollOpts := options.NewCollectionFindOptions(options.WithCollectionSort(map[string]any{}), options.WithCollectionProjection(map[string]any{}))
+		for _, payload := range []any{
+			tableFindPayload{Filter: filter.F{}, Sort: emptyTableOpts.Sort, Projection: emptyTableOpts.Projection},
+			collectionFindPayload{Filter: filter.F{}, Sort: emptyCollOpts.Sort, Projection: emptyCollOpts.Projection},
+			tableFindPayload{Filter: filter.F{}, Sort: tableOpts.Sort, Projection: tableOpts.Projection},
+			collectionFindPayload{Filter: filter.F{}, Sort: collOpts.Sort, Projection: collOpts.Projection},
+		} {
+			b, err := json.Marshal(payload)
+			if err != nil {
+				t.Fatalf("failed to marshal: %v", err)
+			}
+			if string(b) != `{"filter":{}}` {
+				t.Errorf("expected unset sort and projection to be omitted, got %s", b)
+			}
+		}
+	})
+}
+
 func TestFilterWithStructuredFilters(t *testing.T) {
 	// Test using the structured filter types
 	f := filter.And(
@@ -1104,6 +1267,45 @@ func TestListIndexesResponseUnmarshal(t *testing.T) {
 		}
 	})
 
+	t.Run("explain response with map and text indexes", func(t *testing.T) {
+		jsonResp := `{"status":{"indexes":[
+			{"name":"m_keys","definition":{"column":{"m":"$keys"},"options":{}},"indexType":"regular"},
+			{"name":"tags_idx","definition":{"column":"tags","options":{}},"indexType":"regular"},
+			{"name":"summary_idx","definition":{"column":"summary","options":{"analyzer":"english"}},"indexType":"text"},
+			{"name":"body_idx","definition":{"column":"body","options":{"analyzer":{"tokenizer":{"name":"standard"},"filters":[{"name":"lowercase"}]}}},"indexType":"text"}
+		]}}`
+		var resp listIndexesResponse
+		if err := json.Unmarshal([]byte(jsonResp), &resp); err != nil {
+			t.Fatalf("failed to unmarshal: %v", err)
+		}
+		idx := resp.Status.Indexes
+		if len(idx) != 4 {
+			t.Fatalf("expected 4 indexes, got %d", len(idx))
+		}
+		if idx[0].Definition.Column != "m" || idx[0].Definition.MapTarget != options.MapIndexKeys {
+			t.Errorf("expected keys index on m, got %+v", idx[0].Definition)
+		}
+		if idx[1].Definition.Column != "tags" || idx[1].Definition.MapTarget != "" {
+			t.Errorf("expected plain index on tags, got %+v", idx[1].Definition)
+		}
+		if idx[2].IndexType != IndexTypeText || idx[2].Definition.Options.Analyzer.Name != "english" {
+			t.Errorf("expected english text index, got %+v", idx[2].Definition.Options)
+		}
+		analyzer := idx[3].Definition.Options.Analyzer
+		if analyzer.Tokenizer == nil || analyzer.Tokenizer.Name != "standard" || len(analyzer.Filters) != 1 {
+			t.Errorf("expected custom analyzer, got %+v", analyzer)
+		}
+
+		// Definitions marshal back to the wire format
+		b, err := json.Marshal(idx[0].Definition)
+		if err != nil {
+			t.Fatalf("json.Marshal: %v", err)
+		}
+		if string(b) != `{"column":{"m":"$keys"},"options":{}}` {
+			t.Errorf("unexpected JSON %s", b)
+		}
+	})
+
 	t.Run("empty indexes", func(t *testing.T) {
 		jsonResp := `{"status":{"indexes":[]}}`
 		var resp listIndexesResponse
@@ -1250,4 +1452,153 @@ func TestCreateIndedxOptionsValidation(t *testing.T) {
 			t.Fatal("expected no error for valid column name")
 		}
 	})
+
+	t.Run("invalid map target", func(t *testing.T) {
+		_, err := createIndexCommand(getTestTable(t), "some_index", map[string]string{"example_map_column": "$entries"})
+		if err == nil {
+			t.Fatal("expected error for invalid map target")
+		}
+	})
+
+	t.Run("multiple columns in map", func(t *testing.T) {
+		_, err := createIndexCommand(getTestTable(t), "some_index", map[string]string{"a": "$keys", "b": "$values"})
+		if err == nil {
+			t.Fatal("expected error for more than one column")
+		}
+	})
+
+	t.Run("map target with column map", func(t *testing.T) {
+		_, err := createIndexCommand(getTestTable(t), "some_index", map[string]string{"a": "$keys"},
+			options.CreateIndex().SetMapTarget(options.MapIndexValues))
+		if err == nil {
+			t.Fatal("expected error combining a map target with a column map")
+		}
+	})
+}
+
+// TestCreateIndexMapTargetCommandMarshal verifies that the map target option
+// produces the map key, value and entry index payloads.
+func TestCreateIndexMapTargetCommandMarshal(t *testing.T) {
+	tests := []struct {
+		target   options.MapIndexTarget
+		expected string
+	}{
+		{options.MapIndexKeys, `{"createIndex":{"name":"m_idx","definition":{"column":{"m":"$keys"}}}}`},
+		{options.MapIndexValues, `{"createIndex":{"name":"m_idx","definition":{"column":{"m":"$values"}}}}`},
+		{options.MapIndexEntries, `{"createIndex":{"name":"m_idx","definition":{"column":"m"}}}`},
+	}
+	for _, tt := range tests {
+		cmd, err := createIndexCommand(getTestTable(t), "m_idx", "m", options.CreateIndex().SetMapTarget(tt.target))
+		if err != nil {
+			t.Fatalf("createIndexCommand: %v", err)
+		}
+		cmdBytes, err := json.Marshal(cmd)
+		if err != nil {
+			t.Fatalf("json.Marshal: %v", err)
+		}
+		if string(cmdBytes) != tt.expected {
+			t.Errorf("expected JSON:\n%s\nGot:\n%s", tt.expected, string(cmdBytes))
+		}
+	}
+}
+
+// TestCreateTextIndexCommandMarshal verifies the createTextIndex payloads.
+func TestCreateTextIndexCommandMarshal(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []options.Builder[options.CreateTextIndexOptions]
+		expected string
+	}{
+		{
+			name:     "default analyzer",
+			expected: `{"createTextIndex":{"name":"summary_idx","definition":{"column":"summary"}}}`,
+		},
+		{
+			name:     "named analyzer",
+			opts:     []options.Builder[options.CreateTextIndexOptions]{options.CreateTextIndex().SetAnalyzer("english").SetIfNotExists(true)},
+			expected: `{"createTextIndex":{"name":"summary_idx","definition":{"column":"summary","options":{"analyzer":"english"}},"options":{"ifNotExists":true}}}`,
+		},
+		{
+			name: "custom analyzer",
+			opts: []options.Builder[options.CreateTextIndexOptions]{options.CreateTextIndex().SetCustomAnalyzer(options.TextAnalyzer{
+				Tokenizer: &options.AnalyzerComponent{Name: "standard"},
+				Filters:   []options.AnalyzerComponent{{Name: "lowercase"}, {Name: "stop", Args: map[string]any{"words": "_english_"}}},
+			})},
+			expected: `{"createTextIndex":{"name":"summary_idx","definition":{"column":"summary","options":{"analyzer":{"tokenizer":{"name":"standard"},"filters":[{"name":"lowercase"},{"name":"stop","args":{"words":"_english_"}}]}}}}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := createTextIndexCommand(getTestTable(t), "summary_idx", "summary", tt.opts...)
+			if err != nil {
+				t.Fatalf("createTextIndexCommand: %v", err)
+			}
+			cmdBytes, err := json.Marshal(cmd)
+			if err != nil {
+				t.Fatalf("json.Marshal: %v", err)
+			}
+			if string(cmdBytes) != tt.expected {
+				t.Errorf("expected JSON:\n%s\nGot:\n%s", tt.expected, string(cmdBytes))
+			}
+		})
+	}
+
+	_, err := createTextIndexCommand(getTestTable(t), "summary_idx", "summary", &options.CreateTextIndexOptions{
+		Analyzer: &options.TextAnalyzer{Name: "english", Tokenizer: &options.AnalyzerComponent{Name: "standard"}},
+	})
+	if err == nil {
+		t.Error("expected error for analyzer with both a name and a tokenizer")
+	}
+}
+
+// TestAlterTableCommandMarshal verifies that alterTableCommand produces the
+// alterTable payloads from the docs.
+func TestAlterTableCommandMarshal(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       table.AlterOperation
+		expected string
+	}{
+		{
+			name:     "add columns",
+			op:       table.AlterAdd{Columns: map[string]table.Column{"isbn": table.Text(), "tags": table.Set(table.Text())}},
+			expected: `{"alterTable":{"operation":{"add":{"columns":{"isbn":{"type":"text"},"tags":{"type":"set","valueType":{"type":"text"}}}}}}}`,
+		},
+		{
+			name:     "drop columns",
+			op:       table.AlterDrop{Columns: []string{"isbn", "tags"}},
+			expected: `{"alterTable":{"operation":{"drop":{"columns":["isbn","tags"]}}}}`,
+		},
+		{
+			name: "add vectorize",
+			op: table.AlterAddVectorize{Columns: map[string]table.VectorService{
+				"embedding": {Provider: "openai", ModelName: "text-embedding-3-small"},
+			}},
+			expected: `{"alterTable":{"operation":{"addVectorize":{"columns":{"embedding":{"provider":"openai","modelName":"text-embedding-3-small"}}}}}}`,
+		},
+		{
+			name:     "drop vectorize",
+			op:       table.AlterDropVectorize{Columns: []string{"embedding"}},
+			expected: `{"alterTable":{"operation":{"dropVectorize":{"columns":["embedding"]}}}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := alterTableCommand(getTestTable(t), tt.op)
+			if err != nil {
+				t.Fatalf("alterTableCommand: %v", err)
+			}
+			cmdBytes, err := json.Marshal(cmd)
+			if err != nil {
+				t.Fatalf("json.Marshal: %v", err)
+			}
+			if string(cmdBytes) != tt.expected {
+				t.Errorf("expected JSON:\n%s\nGot:\n%s", tt.expected, string(cmdBytes))
+			}
+		})
+	}
+
+	if _, err := alterTableCommand(getTestTable(t), nil); err == nil {
+		t.Error("expected error for nil operation")
+	}
 }
@@ -0,0 +1,162 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package astradb
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/datastax/astra-db-go/options"
+	"github.com/datastax/astra-db-go/results"
+	"github.com/datastax/astra-db-go/table"
+)
+
+// createTypePayload is the payload for the createType command
+type createTypePayload struct {
+	Name       string               `json:"name"`
+	Definition table.TypeDefinition `json:"definition"`
+	Options    *createTableOpts     `json:"options,omitempty"`
+}
+
+// CreateType creates a user-defined type (UDT) in the database. Use
+// [table.UDT] to reference the type from table columns once it exists.
+//
+// Supports the [options.WithIfNotExists] and [options.WithTableKeyspace]
+// options.
+//
+// Example usage:
+//
+//	def := table.NewTypeDefinition().
+//		AddTextField("street").
+//		AddTextField("city").
+//		Build()
+//	_, err := db.CreateType(ctx, "address", def, options.WithIfNotExists(true))
+//
+// UDT values are read and written as JSON objects, so a UDT column can be
+// bound to a nested struct or a map[string]any in rows:
+//
+//	type Address struct {
+//		Street string `json:"street"`
+//		City   string `json:"city"`
+//	}
+//	type Customer struct {
+//		ID        string    `json:"id"`
+//		Address   Address   `json:"address"`   // userDefined column
+//		Addresses []Address `json:"addresses"` // list column of address
+//	}
+func (d *Db) CreateType(ctx context.Context, name string, definition table.TypeDefinition, opts ...options.TableOption) (*results.CommandResult, error) {
+	cmd := createTypeCommand(d, name, definition, opts...)
+	b, warnings, err := cmd.Execute(ctx)
+	return results.NewCommandResult(b, warnings), err
+}
+
+// createTypeCommand builds the createType command for the database
+func createTypeCommand(d *Db, name string, definition table.TypeDefinition, opts ...options.TableOption) command {
+	typeOpts := options.NewCreateTableOptions(opts...)
+	payload := createTypePayload{
+		Name:       name,
+		Definition: definition,
+	}
+	if typeOpts.IfNotExists {
+		payload.Options = &createTableOpts{IfNotExists: true}
+	}
+	cmd := d.newCmd("createType", payload)
+	if typeOpts.Keyspace != "" {
+		cmd.keyspace = typeOpts.Keyspace
+	}
+	return cmd
+}
+
+// alterTypePayload is the payload for the alterType command
+type alterTypePayload struct {
+	Name      string          `json:"name"`
+	Operation json.RawMessage `json:"operation"`
+}
+
+// AlterType changes a user-defined type with a single alterType operation.
+//
+// Example usage:
+//
+//	_, err := db.AlterType(ctx, "address", table.AlterTypeRename{Fields: map[string]string{
+//		"zip": "postcode",
+//	}})
+func (d *Db) AlterType(ctx context.Context, name string, op table.AlterTypeOperation) (*results.CommandResult, error) {
+	cmd, err := alterTypeCommand(d, name, op)
+	if err != nil {
+		return nil, err
+	}
+	b, warnings, err := cmd.Execute(ctx)
+	return results.NewCommandResult(b, warnings), err
+}
+
+// alterTypeCommand builds the alterType command for the database
+func alterTypeCommand(d *Db, name string, op table.AlterTypeOperation) (command, error) {
+	operation, err := table.MarshalTypeOperation(op)
+	if err != nil {
+		return command{}, err
+	}
+	return d.newCmd("alterType", alterTypePayload{Name: name, Operation: operation}), nil
+}
+
+// dropTypePayload is the payload for the dropType command
+type dropTypePayload struct {
+	Name string `json:"name"`
+}
+
+// DropType drops (deletes) a user-defined type from the database. The type
+// can't be dropped while a table still uses it.
+//
+// Example usage:
+//
+//	_, err := db.DropType(ctx, "address")
+func (d *Db) DropType(ctx context.Context, name string) (*results.CommandResult, error) {
+	cmd := d.newCmd("dropType", dropTypePayload{Name: name})
+	b, warnings, err := cmd.Execute(ctx)
+	return results.NewCommandResult(b, warnings), err
+}
+
+// TypeDescriptor describes a user-defined type as returned by [Db.ListTypes].
+type TypeDescriptor struct {
+	Name       string               `json:"udtName"`
+	Definition table.TypeDefinition `json:"definition"`
+}
+
+// ListTypes returns the user-defined types in the database's keyspace with
+// their definitions.
+//
+// Example usage:
+//
+//	types, err := db.ListTypes(ctx)
+//	for _, t := range types {
+//		fmt.Println(t.Name, len(t.Definition.Fields))
+//	}
+func (d *Db) ListTypes(ctx context.Context) ([]TypeDescriptor, error) {
+	var payload listTablesPayload
+	payload.Options.Explain = true
+	cmd := d.newCmd("listTypes", payload)
+	b, _, err := cmd.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Status struct {
+			Types []TypeDescriptor `json:"types"`
+		} `json:"status"`
+	}
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Status.Types, nil
+}
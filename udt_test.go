@@ -0,0 +1,163 @@
+// Copyright DataStax, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package astradb
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/datastax/astra-db-go/options"
+	"github.com/datastax/astra-db-go/table"
+)
+
+// TestTypeCommandsMarshal verifies the createType and alterType payloads.
+func TestTypeCommandsMarshal(t *testing.T) {
+	def := table.NewTypeDefinition().AddTextField("city").Build()
+	tests := []struct {
+		name     string
+		cmd      func() (command, error)
+		expected string
+		url      string
+	}{
+		{
+			name: "create type",
+			cmd: func() (command, error) {
+				return createTypeCommand(getTestDb(t), "address", def), nil
+			},
+			expected: `{"createType":{"name":"address","definition":{"fields":{"city":{"type":"text"}}}}}`,
+			url:      "https://API_ENDPOINT/api/json/v1/some_keyspace",
+		},
+		{
+			name: "create type if not exists in keyspace",
+			cmd: func() (command, error) {
+				return createTypeCommand(getTestDb(t), "address", def,
+					options.WithIfNotExists(true), options.WithTableKeyspace("other")), nil
+			},
+			expected: `{"createType":{"name":"address","definition":{"fields":{"city":{"type":"text"}}},"options":{"ifNotExists":true}}}`,
+			url:      "https://API_ENDPOINT/api/json/v1/other",
+		},
+		{
+			name: "alter type",
+			cmd: func() (command, error) {
+				return alterTypeCommand(getTestDb(t), "address", table.AlterTypeRename{Fields: map[string]string{"zip": "postcode"}})
+			},
+			expected: `{"alterType":{"name":"address","operation":{"rename":{"fields":{"zip":"postcode"}}}}}`,
+			url:      "https://API_ENDPOINT/api/json/v1/some_keyspace",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := tt.cmd()
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := json.Marshal(cmd)
+			if err != nil {
+				t.Fatalf("json.Marshal: %v", err)
+			}
+			if string(b) != tt.expected {
+				t.Errorf("expected JSON:\n%s\nGot:\n%s", tt.expected, b)
+			}
+			if u, _ := cmd.url(); u != tt.url {
+				t.Errorf("expected URL %s, got %s", tt.url, u)
+			}
+		})
+	}
+	if _, err := alterTypeCommand(getTestDb(t), "address", nil); err == nil {
+		t.Error("expected error for nil operation")
+	}
+}
+
+func TestListTypesAndUDTValues(t *testing.T) {
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+	type Customer struct {
+		ID        string    `json:"id"`
+		Address   Address   `json:"address"`
+		Addresses []Address `json:"addresses"`
+	}
+
+	var inserted json.RawMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var cmd map[string]struct {
+			Document json.RawMessage `json:"document"`
+		}
+		json.NewDecoder(r.Body).Decode(&cmd)
+		switch {
+		case hasKey(cmd, "listTypes") && r.URL.Path == "/api/json/v1/ks":
+			w.Write([]byte(`{"status":{"types":[{"type":"userDefined","udtName":"address","definition":{"fields":{"street":{"type":"text"},"city":{"type":"text"}}},"apiSupport":{"createTable":true}}]}}`))
+		case hasKey(cmd, "insertOne"):
+			inserted = cmd["insertOne"].Document
+			w.Write([]byte(`{"status":{"primaryKeySchema":{"id":{"type":"text"}},"insertedIds":[["c1"]]}}`))
+		case hasKey(cmd, "findOne"):
+			w.Write([]byte(`{"data":{"document":` + string(inserted) + `}}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	db := NewClient(options.WithToken("test-token")).Database(srv.URL, options.WithKeyspace("ks"))
+	ctx := context.Background()
+
+	types, err := db.ListTypes(ctx)
+	if err != nil {
+		t.Fatalf("ListTypes failed: %v", err)
+	}
+	want := []TypeDescriptor{{Name: "address", Definition: table.NewTypeDefinition().AddTextField("street").AddTextField("city").Build()}}
+	if !reflect.DeepEqual(types, want) {
+		t.Errorf("expected %+v, got %+v", want, types)
+	}
+
+	customer := Customer{
+		ID:        "c1",
+		Address:   Address{Street: "1 Main St", City: "Springfield"},
+		Addresses: []Address{{Street: "2 Side St", City: "Shelbyville"}},
+	}
+	if _, err := db.Table("customers").InsertOne(ctx, customer); err != nil {
+		t.Fatalf("InsertOne failed: %v", err)
+	}
+	wantRow := `{"id":"c1","address":{"street":"1 Main St","city":"Springfield"},"addresses":[{"street":"2 Side St","city":"Shelbyville"}]}`
+	if string(inserted) != wantRow {
+		t.Errorf("expected row %s, got %s", wantRow, inserted)
+	}
+
+	var got Customer
+	if err := db.Table("customers").FindOne(ctx, map[string]any{"id": "c1"}).Decode(&got); err != nil {
+		t.Fatalf("FindOne failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, customer) {
+		t.Errorf("expected %+v, got %+v", customer, got)
+	}
+
+	var asMap map[string]any
+	if err := db.Table("customers").FindOne(ctx, map[string]any{"id": "c1"}).Decode(&asMap); err != nil {
+		t.Fatalf("FindOne failed: %v", err)
+	}
+	if addr, ok := asMap["address"].(map[string]any); !ok || addr["city"] != "Springfield" {
+		t.Errorf("expected address to decode as a map, got %#v", asMap["address"])
+	}
+}
+
+func hasKey[V any](m map[string]V, key string) bool {
+	_, ok := m[key]
+	return ok
+}